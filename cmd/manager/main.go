@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"os"
 
-	"github.com/minio/minio-go"
 	"github.com/vrischmann/envconfig"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -82,9 +81,9 @@ func main() {
 	ctrl.SetLogger(controller_zap.New(controller_zap.UseDevMode(true), controller_zap.Level(&atomicLevel)))
 
 	httpClient := &http.Client{}
-	minioClient, err := minio.New(cfg.Store.Endpoint, cfg.Store.AccessKey, cfg.Store.SecretKey, cfg.Store.UseSSL)
+	contentStore, err := store.NewForConfig(cfg.Store)
 	if err != nil {
-		setupLog.Error(err, "unable initialize Minio client")
+		setupLog.Error(err, "unable to initialize store")
 		os.Exit(1)
 	}
 
@@ -108,8 +107,8 @@ func main() {
 
 	container := &controllers.Container{
 		Manager:   mgr,
-		Store:     store.New(minioClient, cfg.Store.UploadWorkersCount),
-		Loader:    loader.New(dynamicClient, cfg.Loader.TemporaryDirectory, cfg.Loader.VerifySSL),
+		Store:     contentStore,
+		Loader:    loader.New(dynamicClient, cfg.Loader),
 		Validator: assethook.NewValidator(httpClient, cfg.Webhook.ValidationTimeout, cfg.Webhook.ValidationWorkersCount),
 		Mutator:   assethook.NewMutator(httpClient, cfg.Webhook.MutationTimeout, cfg.Webhook.MutationWorkersCount),
 		Extractor: assethook.NewMetadataExtractor(httpClient, cfg.Webhook.MetadataExtractionTimeout),
@@ -11,8 +11,9 @@ import (
 	"github.com/kyma-project/rafter/internal/bucket"
 	"github.com/kyma-project/rafter/internal/configurer"
 	"github.com/kyma-project/rafter/internal/requesthandler"
+	"github.com/kyma-project/rafter/internal/store"
 	"github.com/kyma-project/rafter/pkg/runtime/signal"
-	"github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/encrypt"
 	"github.com/pkg/errors"
 	"github.com/vrischmann/envconfig"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -53,7 +54,7 @@ func main() {
 
 	uploadEndpoint := fmt.Sprintf("%s:%d", cfg.Upload.Endpoint, cfg.Upload.Port)
 
-	client, err := minio.New(uploadEndpoint, cfg.Upload.AccessKey, cfg.Upload.SecretKey, cfg.Upload.Secure)
+	client, err := store.NewClient(uploadEndpoint, cfg.Upload.AccessKey, cfg.Upload.SecretKey, cfg.Upload.Secure)
 	exitOnError(err, "Error during upload client initialization")
 
 	k8sConfig, err := newRestClientConfig(cfg.KubeconfigPath)
@@ -89,7 +90,12 @@ func main() {
 		uploadExternalEndpoint = cfg.Upload.Endpoint
 	}
 
-	mux := requesthandler.SetupHandlers(client, buckets, uploadExternalEndpoint, cfg.UploadTimeout, cfg.MaxUploadWorkers)
+	var privateEncryption encrypt.ServerSide
+	if cfg.Bucket.PrivateEncryption {
+		privateEncryption = encrypt.NewSSE()
+	}
+
+	mux := requesthandler.SetupHandlers(client, buckets, privateEncryption, uploadExternalEndpoint, cfg.UploadTimeout, cfg.MaxUploadWorkers)
 
 	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 	srv := &http.Server{Addr: addr, Handler: mux}
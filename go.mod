@@ -3,13 +3,16 @@ module github.com/kyma-project/rafter
 go 1.15
 
 require (
+	github.com/andybalholm/brotli v1.0.4
 	github.com/asyncapi/converter-go v0.3.0
 	github.com/asyncapi/parser-go v0.3.0
 	github.com/gernest/front v0.0.0-20181129160812-ed80ca338b88
 	github.com/go-ini/ini v1.51.0 // indirect
 	github.com/go-logr/logr v0.1.0
 	github.com/golang/glog v0.0.0-20160126235308-23def4e6c14b
+	github.com/klauspost/compress v1.13.6
 	github.com/minio/minio-go v6.0.14+incompatible
+	github.com/minio/minio-go/v7 v7.0.10
 	github.com/onsi/ginkgo v1.14.0
 	github.com/onsi/gomega v1.10.1
 	github.com/pkg/errors v0.9.1
@@ -17,11 +20,11 @@ require (
 	github.com/sirupsen/logrus v1.6.0
 	github.com/smartystreets/goconvey v1.6.4 // indirect
 	github.com/stretchr/testify v1.6.1
+	github.com/ulikunitz/xz v0.5.10
 	github.com/vrischmann/envconfig v1.3.0
 	go.uber.org/multierr v1.5.0 // indirect
 	go.uber.org/zap v1.10.0
-	golang.org/x/net v0.0.0-20200520004742-59133d7f0dd7
-	gopkg.in/ini.v1 v1.48.0 // indirect
+	golang.org/x/net v0.0.0-20200707034311-ab3426394381
 	k8s.io/api v0.17.11
 	k8s.io/apimachinery v0.17.11
 	k8s.io/client-go v0.17.11
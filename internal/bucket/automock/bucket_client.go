@@ -43,6 +43,34 @@ func (_m *BucketClient) MakeBucket(bucketName string, location string) error {
 	return r0
 }
 
+// SetBucketEncryption provides a mock function with given fields: bucketName, algorithm
+func (_m *BucketClient) SetBucketEncryption(bucketName string, algorithm string) error {
+	ret := _m.Called(bucketName, algorithm)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(bucketName, algorithm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetBucketLifecycle provides a mock function with given fields: bucketName, lifecycle
+func (_m *BucketClient) SetBucketLifecycle(bucketName string, lifecycle string) error {
+	ret := _m.Called(bucketName, lifecycle)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(bucketName, lifecycle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetBucketPolicy provides a mock function with given fields: bucketName, policy
 func (_m *BucketClient) SetBucketPolicy(bucketName string, policy string) error {
 	ret := _m.Called(bucketName, policy)
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/minio/minio-go/pkg/policy"
 	"github.com/pkg/errors"
 )
@@ -17,11 +19,16 @@ const creationRetries = 5
 // creationRetryTime defines how much time handler should wait when retrying bucket creation
 const creationRetryTime = 100 * time.Millisecond
 
+// encryptionAlgorithm defines the algorithm of the SSE-S3 default encryption of the private bucket
+const encryptionAlgorithm = "AES256"
+
 //go:generate mockery -name=BucketClient -output=automock -outpkg=automock -case=underscore
 // BucketClient handles bucket operations on Minio
 type BucketClient interface {
 	BucketExists(bucketName string) (bool, error)
 	MakeBucket(bucketName string, location string) (err error)
+	SetBucketEncryption(bucketName, algorithm string) error
+	SetBucketLifecycle(bucketName, lifecycle string) error
 	SetBucketPolicy(bucketName, policy string) error
 }
 
@@ -30,6 +37,12 @@ type Config struct {
 	PrivatePrefix string `envconfig:"default=private"`
 	PublicPrefix  string `envconfig:"default=public"`
 	Region        string `envconfig:"default=us-east-1"`
+	// PrivateEncryption enables the SSE-S3 encryption of the objects in the private bucket
+	PrivateEncryption bool `envconfig:"default=false"`
+	// PrivateExpirationDays and PublicExpirationDays define after how many days the uploaded files expire,
+	// they never expire if the value is 0
+	PrivateExpirationDays int64 `envconfig:"default=0"`
+	PublicExpirationDays  int64 `envconfig:"default=0"`
 }
 
 // SystemBucketNames stores names for system buckets
@@ -59,6 +72,16 @@ func (h *Handler) CreateSystemBuckets() (SystemBucketNames, error) {
 		return SystemBucketNames{}, errors.Wrapf(err, "while creating private bucket with prefix %s", h.cfg.PrivatePrefix)
 	}
 
+	err = h.setPrivateEncryption(private)
+	if err != nil {
+		return SystemBucketNames{}, err
+	}
+
+	err = h.setExpiration(private, h.cfg.PrivateExpirationDays)
+	if err != nil {
+		return SystemBucketNames{}, err
+	}
+
 	public, err := h.tryCreatingBucket(h.cfg.PublicPrefix)
 	if err != nil {
 		return SystemBucketNames{}, errors.Wrapf(err, "while creating public bucket with prefix %s", h.cfg.PublicPrefix)
@@ -74,6 +97,11 @@ func (h *Handler) CreateSystemBuckets() (SystemBucketNames, error) {
 		return SystemBucketNames{}, errors.Wrapf(err, "while setting policy %s for %s bucket", readOnlyPolicy, public)
 	}
 
+	err = h.setExpiration(public, h.cfg.PublicExpirationDays)
+	if err != nil {
+		return SystemBucketNames{}, err
+	}
+
 	return SystemBucketNames{
 		Private: private,
 		Public:  public,
@@ -109,6 +137,16 @@ func (h *Handler) CheckBuckets(buckets SystemBucketNames) error {
 		return errors.Wrapf(err, "while creating private system buckets")
 	}
 
+	err = h.setPrivateEncryption(buckets.Private)
+	if err != nil {
+		return err
+	}
+
+	err = h.setExpiration(buckets.Private, h.cfg.PrivateExpirationDays)
+	if err != nil {
+		return err
+	}
+
 	err = h.CreateIfDoesntExist(buckets.Public, h.cfg.Region)
 	if err != nil {
 		return errors.Wrapf(err, "while creating public system buckets")
@@ -124,6 +162,11 @@ func (h *Handler) CheckBuckets(buckets SystemBucketNames) error {
 		return errors.Wrapf(err, "while setting policy %s for %s bucket", readOnlyPolicy, buckets.Public)
 	}
 
+	err = h.setExpiration(buckets.Public, h.cfg.PublicExpirationDays)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -138,6 +181,41 @@ func (h *Handler) SetPolicy(bucketName, policy string) error {
 	return nil
 }
 
+// setPrivateEncryption sets the default encryption of the private bucket, if it is enabled
+func (h *Handler) setPrivateEncryption(bucketName string) error {
+	if !h.cfg.PrivateEncryption {
+		return nil
+	}
+
+	glog.Infof("Setting default encryption on bucket `%s`...\n", bucketName)
+	err := h.client.SetBucketEncryption(bucketName, encryptionAlgorithm)
+	if err != nil {
+		return errors.Wrapf(err, "while setting default encryption on bucket `%s`", bucketName)
+	}
+
+	return nil
+}
+
+// setExpiration sets the lifecycle rule expiring the objects of the bucket, if the expiration is enabled
+func (h *Handler) setExpiration(bucketName string, days int64) error {
+	if days <= 0 {
+		return nil
+	}
+
+	lifecycle, err := store.BucketLifecycle([]v1beta1.BucketLifecycleRule{{ID: "expiration", ExpirationDays: &days}})
+	if err != nil {
+		return errors.Wrapf(err, "while creating lifecycle for bucket `%s`", bucketName)
+	}
+
+	glog.Infof("Setting %d days expiration on bucket `%s`...\n", days, bucketName)
+	err = h.client.SetBucketLifecycle(bucketName, lifecycle)
+	if err != nil {
+		return errors.Wrapf(err, "while setting lifecycle on bucket `%s`", bucketName)
+	}
+
+	return nil
+}
+
 func (h *Handler) tryCreatingBucket(prefix string) (string, error) {
 	for i := 0; i < creationRetries; i++ {
 		glog.Infof("Trying to create a bucket with prefix %s (attempt %d of %d)", prefix, i+1, creationRetries)
@@ -45,6 +45,133 @@ func TestHandler_CreateSystemBuckets(t *testing.T) {
 		g.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	t.Run("Private Encryption", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		privatePrefix := "private"
+		publicPrefix := "public"
+		region := "region"
+		cfg := bucket.Config{
+			PrivatePrefix:     privatePrefix,
+			PublicPrefix:      publicPrefix,
+			Region:            region,
+			PrivateEncryption: true,
+		}
+
+		minioCli := &automock.BucketClient{}
+		handler := bucket.NewHandler(minioCli, cfg)
+
+		minioCli.On("BucketExists", mock.MatchedBy(testBucketNameFn(publicPrefix))).Return(false, nil).Once()
+		minioCli.On("MakeBucket", mock.MatchedBy(testBucketNameFn(publicPrefix)), region).Return(nil).Once()
+		minioCli.On("SetBucketPolicy", mock.MatchedBy(testBucketNameFn(publicPrefix)), mock.MatchedBy(func(policy string) bool { return true })).Return(nil).Once()
+		minioCli.On("BucketExists", mock.MatchedBy(testBucketNameFn(privatePrefix))).Return(false, nil).Once()
+		minioCli.On("MakeBucket", mock.MatchedBy(testBucketNameFn(privatePrefix)), region).Return(nil).Once()
+		minioCli.On("SetBucketEncryption", mock.MatchedBy(testBucketNameFn(privatePrefix)), "AES256").Return(nil).Once()
+		defer minioCli.AssertExpectations(t)
+
+		// When
+		buckets, err := handler.CreateSystemBuckets()
+
+		// Then
+		g.Expect(buckets.Private).To(gomega.HavePrefix(privatePrefix))
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		privatePrefix := "private"
+		publicPrefix := "public"
+		region := "region"
+		cfg := bucket.Config{
+			PrivatePrefix:         privatePrefix,
+			PublicPrefix:          publicPrefix,
+			Region:                region,
+			PrivateExpirationDays: 1,
+			PublicExpirationDays:  30,
+		}
+
+		minioCli := &automock.BucketClient{}
+		handler := bucket.NewHandler(minioCli, cfg)
+
+		minioCli.On("BucketExists", mock.MatchedBy(testBucketNameFn(publicPrefix))).Return(false, nil).Once()
+		minioCli.On("MakeBucket", mock.MatchedBy(testBucketNameFn(publicPrefix)), region).Return(nil).Once()
+		minioCli.On("SetBucketPolicy", mock.MatchedBy(testBucketNameFn(publicPrefix)), mock.MatchedBy(func(policy string) bool { return true })).Return(nil).Once()
+		minioCli.On("SetBucketLifecycle", mock.MatchedBy(testBucketNameFn(publicPrefix)), mock.MatchedBy(testLifecycleFn(30))).Return(nil).Once()
+		minioCli.On("BucketExists", mock.MatchedBy(testBucketNameFn(privatePrefix))).Return(false, nil).Once()
+		minioCli.On("MakeBucket", mock.MatchedBy(testBucketNameFn(privatePrefix)), region).Return(nil).Once()
+		minioCli.On("SetBucketLifecycle", mock.MatchedBy(testBucketNameFn(privatePrefix)), mock.MatchedBy(testLifecycleFn(1))).Return(nil).Once()
+		defer minioCli.AssertExpectations(t)
+
+		// When
+		_, err := handler.CreateSystemBuckets()
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("Expiration Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		privatePrefix := "private"
+		region := "region"
+		cfg := bucket.Config{
+			PrivatePrefix:         privatePrefix,
+			PublicPrefix:          "public",
+			Region:                region,
+			PrivateExpirationDays: 1,
+		}
+		testErr := errors.New("Test err")
+
+		minioCli := &automock.BucketClient{}
+		handler := bucket.NewHandler(minioCli, cfg)
+
+		minioCli.On("BucketExists", mock.MatchedBy(testBucketNameFn(privatePrefix))).Return(false, nil).Once()
+		minioCli.On("MakeBucket", mock.MatchedBy(testBucketNameFn(privatePrefix)), region).Return(nil).Once()
+		minioCli.On("SetBucketLifecycle", mock.MatchedBy(testBucketNameFn(privatePrefix)), mock.MatchedBy(testLifecycleFn(1))).Return(testErr).Once()
+		defer minioCli.AssertExpectations(t)
+
+		// When
+		_, err := handler.CreateSystemBuckets()
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(err.Error()).To(gomega.ContainSubstring(testErr.Error()))
+	})
+
+	t.Run("Private Encryption Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		privatePrefix := "private"
+		region := "region"
+		cfg := bucket.Config{
+			PrivatePrefix:     privatePrefix,
+			PublicPrefix:      "public",
+			Region:            region,
+			PrivateEncryption: true,
+		}
+		testErr := errors.New("Test err")
+
+		minioCli := &automock.BucketClient{}
+		handler := bucket.NewHandler(minioCli, cfg)
+
+		minioCli.On("BucketExists", mock.MatchedBy(testBucketNameFn(privatePrefix))).Return(false, nil).Once()
+		minioCli.On("MakeBucket", mock.MatchedBy(testBucketNameFn(privatePrefix)), region).Return(nil).Once()
+		minioCli.On("SetBucketEncryption", mock.MatchedBy(testBucketNameFn(privatePrefix)), "AES256").Return(testErr).Once()
+		defer minioCli.AssertExpectations(t)
+
+		// When
+		_, err := handler.CreateSystemBuckets()
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(err.Error()).To(gomega.ContainSubstring(testErr.Error()))
+	})
+
 	t.Run("Exists", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
@@ -207,6 +334,62 @@ func TestBucketHandler_CheckBuckets(t *testing.T) {
 		g.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	t.Run("Private encryption", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		buckets := bucket.SystemBucketNames{
+			Private: "private-bucket",
+			Public:  "public-bucket",
+		}
+		cfg := bucket.Config{
+			Region:            "region",
+			PrivateEncryption: true,
+		}
+		minioCli := &automock.BucketClient{}
+		handler := bucket.NewHandler(minioCli, cfg)
+
+		minioCli.On("BucketExists", buckets.Private).Return(true, nil).Once()
+		minioCli.On("SetBucketEncryption", buckets.Private, "AES256").Return(nil).Once()
+		minioCli.On("BucketExists", buckets.Public).Return(true, nil).Once()
+		minioCli.On("SetBucketPolicy", buckets.Public, mock.MatchedBy(func(policy string) bool { return true })).Return(nil).Once()
+		defer minioCli.AssertExpectations(t)
+
+		// When
+		err := handler.CheckBuckets(buckets)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		buckets := bucket.SystemBucketNames{
+			Private: "private-bucket",
+			Public:  "public-bucket",
+		}
+		cfg := bucket.Config{
+			Region:               "region",
+			PublicExpirationDays: 7,
+		}
+		minioCli := &automock.BucketClient{}
+		handler := bucket.NewHandler(minioCli, cfg)
+
+		minioCli.On("BucketExists", buckets.Private).Return(true, nil).Once()
+		minioCli.On("BucketExists", buckets.Public).Return(true, nil).Once()
+		minioCli.On("SetBucketPolicy", buckets.Public, mock.MatchedBy(func(policy string) bool { return true })).Return(nil).Once()
+		minioCli.On("SetBucketLifecycle", buckets.Public, mock.MatchedBy(testLifecycleFn(7))).Return(nil).Once()
+		defer minioCli.AssertExpectations(t)
+
+		// When
+		err := handler.CheckBuckets(buckets)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	t.Run("Checking private bucket error", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
@@ -362,3 +545,9 @@ func testBucketNameFn(prefix string) func(string) bool {
 		return strings.HasPrefix(bucketName, prefix)
 	}
 }
+
+func testLifecycleFn(days int) func(string) bool {
+	return func(lifecycle string) bool {
+		return strings.Contains(lifecycle, fmt.Sprintf("<Expiration><Days>%d</Days></Expiration>", days))
+	}
+}
@@ -0,0 +1,181 @@
+package compressor
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+// defaultMinSize is the size of the smallest compressed file, as smaller files don't fit in fewer network packets
+const defaultMinSize = 1024
+
+// defaultContentTypes lists the compressible types of static sites, other types are usually compressed already
+var defaultContentTypes = []string{
+	"text/*",
+	"application/javascript",
+	"application/json",
+	"application/manifest+json",
+	"application/wasm",
+	"application/xml",
+	"image/svg+xml",
+}
+
+var extensions = map[v1beta1.AssetEncoding]string{
+	v1beta1.AssetEncodingGzip:   ".gz",
+	v1beta1.AssetEncodingBrotli: ".br",
+}
+
+// Result lists the files to upload, and the metadata which sets the content encoding of the compressed ones
+type Result struct {
+	Files      []string
+	Metadata   store.ObjectMetadata
+	Compressed int
+}
+
+// Compress encodes the compressible files in the directory. In the Variants mode, the encoded files are written
+// next to the original ones, with the extension of the encoding. In the Replace mode, the original files are replaced
+// with the ones encoded with the first encoding. Files which don't get smaller are not encoded.
+func Compress(basePath string, files []string, compression v1beta1.AssetCompression, metadata store.ObjectMetadata) (Result, error) {
+	if len(compression.Encodings) == 0 {
+		return Result{Files: files, Metadata: metadata}, nil
+	}
+	minSize := int64(defaultMinSize)
+	if compression.MinSize != nil {
+		minSize = *compression.MinSize
+	}
+	contentTypes := compression.ContentTypes
+	if len(contentTypes) == 0 {
+		contentTypes = defaultContentTypes
+	}
+
+	existing := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		existing[file] = struct{}{}
+	}
+
+	result := append([]string{}, files...)
+	encoded := make(map[string]store.EncodedFile)
+	for _, file := range files {
+		options := metadata.Options(file)
+		if options.ContentEncoding != "" || !matchesContentType(options.ContentType, contentTypes) {
+			continue
+		}
+		path := filepath.Join(basePath, file)
+		info, err := os.Stat(path)
+		if err != nil {
+			return Result{}, errors.Wrapf(err, "while reading file %s", file)
+		}
+		if info.Size() < minSize {
+			continue
+		}
+
+		if compression.Mode == v1beta1.AssetCompressionReplace {
+			encoding := compression.Encodings[0]
+			ok, err := encodeFile(path, path, info.Size(), encoding)
+			if err != nil {
+				return Result{}, errors.Wrapf(err, "while compressing file %s", file)
+			}
+			if ok {
+				encoded[file] = store.EncodedFile{Original: file, Encoding: string(encoding)}
+			}
+			continue
+		}
+
+		for _, encoding := range compression.Encodings {
+			name := file + extensions[encoding]
+			if _, ok := existing[name]; ok {
+				continue
+			}
+			ok, err := encodeFile(path, filepath.Join(basePath, name), info.Size(), encoding)
+			if err != nil {
+				return Result{}, errors.Wrapf(err, "while compressing file %s", file)
+			}
+			if ok {
+				encoded[name] = store.EncodedFile{Original: file, Encoding: string(encoding)}
+				result = append(result, name)
+			}
+		}
+	}
+
+	return Result{
+		Files:      result,
+		Metadata:   metadata.WithEncodedFiles(encoded),
+		Compressed: len(encoded),
+	}, nil
+}
+
+// encodeFile writes the encoded file through a temporary file, so the source can be replaced. It returns false
+// if the encoded file isn't smaller than the source.
+func encodeFile(sourcePath, path string, size int64, encoding v1beta1.AssetEncoding) (bool, error) {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return false, err
+	}
+	defer source.Close()
+
+	file, err := ioutil.TempFile(filepath.Dir(path), ".compressed")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(file.Name())
+
+	writer, err := newWriter(file, encoding)
+	if err != nil {
+		file.Close()
+		return false, err
+	}
+	_, err = io.Copy(writer, source)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	info, statErr := file.Stat()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = statErr
+	}
+	if err != nil {
+		return false, err
+	}
+	if info.Size() >= size {
+		return false, nil
+	}
+
+	return true, os.Rename(file.Name(), path)
+}
+
+func newWriter(writer io.Writer, encoding v1beta1.AssetEncoding) (io.WriteCloser, error) {
+	switch encoding {
+	case v1beta1.AssetEncodingGzip:
+		return gzip.NewWriterLevel(writer, gzip.BestCompression)
+	case v1beta1.AssetEncodingBrotli:
+		return brotli.NewWriterLevel(writer, brotli.BestCompression), nil
+	}
+
+	return nil, fmt.Errorf("%s: unsupported encoding", encoding)
+}
+
+// matchesContentType matches the content type without parameters against the types, which can end with a wildcard
+func matchesContentType(contentType string, types []string) bool {
+	contentType = strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	for _, pattern := range types {
+		if strings.HasSuffix(pattern, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+		if strings.EqualFold(pattern, contentType) {
+			return true
+		}
+	}
+
+	return false
+}
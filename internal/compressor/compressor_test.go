@@ -0,0 +1,154 @@
+package compressor_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/kyma-project/rafter/internal/compressor"
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestCompress(t *testing.T) {
+	content := strings.Repeat("<p>Rafter</p>\n", 100)
+	files := map[string]string{
+		"index.html": content,
+		"small.css":  strings.Repeat("p { margin: 0; }\n", 20),
+		"image.png":  content,
+	}
+	zero := int64(0)
+
+	for testName, testCase := range map[string]struct {
+		compression v1beta1.AssetCompression
+		rules       []v1beta1.AssetObjectMetadata
+		files       []string
+		encoded     map[string]string
+	}{
+		"Variants": {
+			compression: v1beta1.AssetCompression{Encodings: []v1beta1.AssetEncoding{v1beta1.AssetEncodingGzip, v1beta1.AssetEncodingBrotli}},
+			files:       []string{"index.html", "small.css", "image.png", "index.html.gz", "index.html.br"},
+			encoded:     map[string]string{"index.html.gz": "gzip", "index.html.br": "br"},
+		},
+		"Replace": {
+			compression: v1beta1.AssetCompression{Encodings: []v1beta1.AssetEncoding{v1beta1.AssetEncodingBrotli}, Mode: v1beta1.AssetCompressionReplace},
+			files:       []string{"index.html", "small.css", "image.png"},
+			encoded:     map[string]string{"index.html": "br"},
+		},
+		"MinSize": {
+			compression: v1beta1.AssetCompression{Encodings: []v1beta1.AssetEncoding{v1beta1.AssetEncodingGzip}, MinSize: &zero},
+			files:       []string{"index.html", "small.css", "image.png", "index.html.gz", "small.css.gz"},
+			encoded:     map[string]string{"index.html.gz": "gzip", "small.css.gz": "gzip"},
+		},
+		"ContentTypes": {
+			compression: v1beta1.AssetCompression{Encodings: []v1beta1.AssetEncoding{v1beta1.AssetEncodingGzip}, ContentTypes: []string{"image/*"}},
+			files:       []string{"index.html", "small.css", "image.png", "image.png.gz"},
+			encoded:     map[string]string{"image.png.gz": "gzip"},
+		},
+		"EncodedByRules": {
+			compression: v1beta1.AssetCompression{Encodings: []v1beta1.AssetEncoding{v1beta1.AssetEncodingGzip}},
+			rules:       []v1beta1.AssetObjectMetadata{{Pattern: "*.html", ContentEncoding: "gzip"}},
+			files:       []string{"index.html", "small.css", "image.png"},
+			encoded:     map[string]string{},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			basePath := fixDirectory(t, files)
+			defer os.RemoveAll(basePath)
+			metadata, err := store.NewObjectMetadata(testCase.rules)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			// When
+			result, err := compressor.Compress(basePath, []string{"index.html", "small.css", "image.png"}, testCase.compression, metadata)
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.Files).To(gomega.Equal(testCase.files))
+			g.Expect(result.Compressed).To(gomega.Equal(len(testCase.encoded)))
+			for name, encoding := range testCase.encoded {
+				original := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".br")
+				g.Expect(result.Metadata.Options(name).ContentEncoding).To(gomega.Equal(encoding))
+				g.Expect(result.Metadata.Options(name).ContentType).To(gomega.Equal(metadata.Options(original).ContentType))
+				g.Expect(decode(t, filepath.Join(basePath, name), encoding)).To(gomega.Equal(files[original]))
+			}
+		})
+	}
+}
+
+func TestCompress_ExistingVariant(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	content := strings.Repeat("console.log('Rafter');\n", 100)
+	basePath := fixDirectory(t, map[string]string{"app.js": content, "app.js.gz": "precompressed"})
+	defer os.RemoveAll(basePath)
+	compression := v1beta1.AssetCompression{Encodings: []v1beta1.AssetEncoding{v1beta1.AssetEncodingGzip}}
+
+	// When
+	result, err := compressor.Compress(basePath, []string{"app.js", "app.js.gz"}, compression, store.ObjectMetadata{})
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.Files).To(gomega.Equal([]string{"app.js", "app.js.gz"}))
+	g.Expect(result.Compressed).To(gomega.BeZero())
+	g.Expect(ioutil.ReadFile(filepath.Join(basePath, "app.js.gz"))).To(gomega.Equal([]byte("precompressed")))
+}
+
+func TestCompress_MissingFile(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	compression := v1beta1.AssetCompression{Encodings: []v1beta1.AssetEncoding{v1beta1.AssetEncodingGzip}}
+
+	// When
+	_, err := compressor.Compress("/tmp/not-existing", []string{"index.html"}, compression, store.ObjectMetadata{})
+
+	// Then
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func fixDirectory(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "compressor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func decode(t *testing.T, path, encoding string) string {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if encoding == "br" {
+		decoded, err := ioutil.ReadAll(brotli.NewReader(bytes.NewReader(content)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(decoded)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(decoded)
+}
@@ -31,9 +31,11 @@ type AssetReconciler struct {
 	cacheSynchronizer       func(stop <-chan struct{}) bool
 	recorder                record.EventRecorder
 	relistInterval          time.Duration
+	keptVersions            int
 	maxConcurrentReconciles int
 	store                   store.Store
 	loader                  loader.Loader
+	findEncryptionKey       asset.FindEncryptionKey
 	finalizer               finalizer.Finalizer
 	validator               assethook.Validator
 	mutator                 assethook.Mutator
@@ -43,6 +45,7 @@ type AssetReconciler struct {
 type AssetConfig struct {
 	MaxConcurrentReconciles int           `envconfig:"default=1"`
 	RelistInterval          time.Duration `envconfig:"default=30s"`
+	KeptVersions            int           `envconfig:"default=2"`
 }
 
 func NewAsset(config AssetConfig, log logr.Logger, di *Container) *AssetReconciler {
@@ -54,8 +57,10 @@ func NewAsset(config AssetConfig, log logr.Logger, di *Container) *AssetReconcil
 		Log:               log,
 		recorder:          di.Manager.GetEventRecorderFor("asset-controller"),
 		relistInterval:    config.RelistInterval,
+		keptVersions:      config.KeptVersions,
 		store:             di.Store,
 		loader:            di.Loader,
+		findEncryptionKey: asset.NewSecretEncryptionKeyFinder(di.Manager.GetAPIReader()),
 		finalizer:         deleteFinalizer,
 		validator:         di.Validator,
 		mutator:           di.Mutator,
@@ -69,6 +74,8 @@ func NewAsset(config AssetConfig, log logr.Logger, di *Container) *AssetReconcil
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=buckets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=buckets/status,verbs=get;list
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=assetsourcepolicies,verbs=get;list
 
 func (r *AssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -89,7 +96,7 @@ func (r *AssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 	}
 
 	assetLogger := r.Log.WithValues("kind", instance.GetObjectKind().GroupVersionKind().Kind, "name", instance.GetName(), "namespace", instance.GetNamespace())
-	commonHandler := asset.New(assetLogger, r.recorder, r.store, r.loader, r.findBucket, r.validator, r.mutator, r.metadataExtractor, r.relistInterval)
+	commonHandler := asset.New(assetLogger, r.recorder, r.store, r.loader, r.findBucket, r.findEncryptionKey, r.validator, r.mutator, r.metadataExtractor, r.relistInterval, r.keptVersions)
 	commonStatus, err := commonHandler.Do(ctx, time.Now(), instance, instance.Spec.CommonAssetSpec, instance.Status.CommonAssetStatus)
 	if updateErr := r.updateStatus(ctx, request.NamespacedName, commonStatus); updateErr != nil {
 		finalErr := updateErr
@@ -31,6 +31,8 @@ type BucketReconciler struct {
 	relistInterval          time.Duration
 	finalizer               finalizer.Finalizer
 	store                   store.Store
+	retainedBuckets         *retainedBucketService
+	remoteNames             *remoteNameService
 	externalEndpoint        string
 	maxConcurrentReconciles int
 }
@@ -51,6 +53,8 @@ func NewBucket(config BucketConfig, log logr.Logger, di *Container) *BucketRecon
 		recorder:                di.Manager.GetEventRecorderFor("bucket-controller"),
 		relistInterval:          config.RelistInterval,
 		store:                   di.Store,
+		retainedBuckets:         newRetainedBucketService(di.Manager.GetClient()),
+		remoteNames:             newRemoteNameService(di.Manager.GetClient()),
 		finalizer:               deleteFinalizer,
 		externalEndpoint:        config.ExternalEndpoint,
 		maxConcurrentReconciles: config.MaxConcurrentReconciles,
@@ -60,6 +64,8 @@ func NewBucket(config BucketConfig, log logr.Logger, di *Container) *BucketRecon
 // Reconcile reads that state of the cluster for a Bucket object and makes changes based on the state read
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=buckets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=buckets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=retainedbuckets,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=clusterbuckets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *BucketReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
@@ -81,7 +87,7 @@ func (r *BucketReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error)
 	}
 
 	bucketLogger := r.Log.WithValues("kind", instance.GetObjectKind().GroupVersionKind().Kind, "name", instance.GetName(), "namespace", instance.GetNamespace())
-	commonHandler := bucket.New(bucketLogger, r.recorder, r.store, r.externalEndpoint, r.relistInterval)
+	commonHandler := bucket.New(bucketLogger, r.recorder, r.store, r.retainedBuckets.Record, r.retainedBuckets.Forget, r.remoteNames.FindOwner, r.externalEndpoint, r.relistInterval)
 	commonStatus, err := commonHandler.Do(ctx, time.Now(), instance, instance.Spec.CommonBucketSpec, instance.Status.CommonBucketStatus)
 	if updateErr := r.updateStatus(ctx, request.NamespacedName, commonStatus); updateErr != nil {
 		finalErr := updateErr
@@ -31,9 +31,11 @@ type ClusterAssetReconciler struct {
 	cacheSynchronizer       func(stop <-chan struct{}) bool
 	recorder                record.EventRecorder
 	relistInterval          time.Duration
+	keptVersions            int
 	maxConcurrentReconciles int
 	store                   store.Store
 	loader                  loader.Loader
+	findEncryptionKey       asset.FindEncryptionKey
 	finalizer               finalizer.Finalizer
 	validator               assethook.Validator
 	mutator                 assethook.Mutator
@@ -43,6 +45,7 @@ type ClusterAssetReconciler struct {
 type ClusterAssetConfig struct {
 	MaxConcurrentReconciles int           `envconfig:"default=1"`
 	RelistInterval          time.Duration `envconfig:"default=30s"`
+	KeptVersions            int           `envconfig:"default=2"`
 }
 
 func NewClusterAsset(config ClusterAssetConfig, log logr.Logger, di *Container) *ClusterAssetReconciler {
@@ -54,8 +57,10 @@ func NewClusterAsset(config ClusterAssetConfig, log logr.Logger, di *Container)
 		Log:               log,
 		recorder:          di.Manager.GetEventRecorderFor("clusterasset-controller"),
 		relistInterval:    config.RelistInterval,
+		keptVersions:      config.KeptVersions,
 		store:             di.Store,
 		loader:            di.Loader,
+		findEncryptionKey: asset.NewSecretEncryptionKeyFinder(di.Manager.GetAPIReader()),
 		finalizer:         deleteFinalizer,
 		validator:         di.Validator,
 		mutator:           di.Mutator,
@@ -69,6 +74,8 @@ func NewClusterAsset(config ClusterAssetConfig, log logr.Logger, di *Container)
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=clusterbuckets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=clusterbuckets/status,verbs=get;list
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=assetsourcepolicies,verbs=get;list
 
 func (r *ClusterAssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -89,7 +96,7 @@ func (r *ClusterAssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, e
 	}
 
 	assetLogger := r.Log.WithValues("kind", instance.GetObjectKind().GroupVersionKind().Kind, "name", instance.GetName())
-	commonHandler := asset.New(assetLogger, r.recorder, r.store, r.loader, r.findClusterBucket, r.validator, r.mutator, r.metadataExtractor, r.relistInterval)
+	commonHandler := asset.New(assetLogger, r.recorder, r.store, r.loader, r.findClusterBucket, r.findEncryptionKey, r.validator, r.mutator, r.metadataExtractor, r.relistInterval, r.keptVersions)
 	commonStatus, err := commonHandler.Do(ctx, time.Now(), instance, instance.Spec.CommonAssetSpec, instance.Status.CommonAssetStatus)
 	if updateErr := r.updateStatus(ctx, request.NamespacedName, commonStatus); updateErr != nil {
 		finalErr := updateErr
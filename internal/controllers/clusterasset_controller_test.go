@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/kyma-project/rafter/internal/finalizer"
+	"github.com/kyma-project/rafter/internal/loader"
+	"github.com/kyma-project/rafter/internal/store"
 	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -80,16 +82,16 @@ var _ = Describe("ClusterAsset", func() {
 		validateAsset(asset.Status.CommonAssetStatus, asset.ObjectMeta, "", []string{}, assetstorev1beta1.AssetPending, assetstorev1beta1.AssetScheduled)
 
 		// On pending
-		mocks.Store.On("ListObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name).Return([]string{}, nil).Once()
-		mocks.Loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", []string{"test.file1", "test.file2"}, nil).Once()
+		mocks.Loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.Loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"test.file1", "test.file2"}}, nil).Once()
 		mocks.Loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.Store.On("PutObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name, "/tmp", []string{"test.file1", "test.file2"}).Return(nil).Once()
+		mocks.Store.On("SyncObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name+"/.v/1", "/tmp", []string{"test.file1", "test.file2"}, store.ObjectMetadata{}).Return(store.SyncResult{Uploaded: []string{"test.file1", "test.file2"}}, nil).Once()
 
 		result, err = reconciler.Reconcile(request)
 		validateReconcilation(err, result)
 		asset = &assetstorev1beta1.ClusterAsset{}
 		Expect(k8sClient.Get(context.TODO(), request.NamespacedName, asset)).To(Succeed())
-		validateAsset(asset.Status.CommonAssetStatus, asset.ObjectMeta, baseURL, []string{"test.file1", "test.file2"}, assetstorev1beta1.AssetReady, assetstorev1beta1.AssetUploaded)
+		validateAsset(asset.Status.CommonAssetStatus, asset.ObjectMeta, baseURL+"/.v/1", []string{"test.file1", "test.file2"}, assetstorev1beta1.AssetReady, assetstorev1beta1.AssetUploaded)
 
 		By("updating the ClusterAsset")
 		asset.Spec.Source.URL = "example.com/test.file"
@@ -101,27 +103,28 @@ var _ = Describe("ClusterAsset", func() {
 		validateReconcilation(err, result)
 		asset = &assetstorev1beta1.ClusterAsset{}
 		Expect(k8sClient.Get(context.TODO(), request.NamespacedName, asset)).To(Succeed())
-		validateAsset(asset.Status.CommonAssetStatus, asset.ObjectMeta, "", []string{}, assetstorev1beta1.AssetPending, assetstorev1beta1.AssetScheduled)
+		validateAsset(asset.Status.CommonAssetStatus, asset.ObjectMeta, baseURL+"/.v/1", []string{"test.file1", "test.file2"}, assetstorev1beta1.AssetPending, assetstorev1beta1.AssetScheduled)
 
 		// On pending
-		mocks.Store.On("ListObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name).Return([]string{"test.file1", "test.file2"}, nil).Once()
-		mocks.Store.On("DeleteObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name).Return(nil).Once()
-		mocks.Loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", []string{"test.file"}, nil).Once()
+		mocks.Loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.Loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"test.file"}}, nil).Once()
 		mocks.Loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.Store.On("PutObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name, "/tmp", []string{"test.file"}).Return(nil).Once()
+		mocks.Store.On("CopyObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name+"/.v/1/", asset.Name+"/.v/2/", nil).Return(nil).Once()
+		mocks.Store.On("SyncObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name+"/.v/2", "/tmp", []string{"test.file"}, store.ObjectMetadata{}).Return(store.SyncResult{Uploaded: []string{"test.file"}, Deleted: []string{"test.file1", "test.file2"}}, nil).Once()
+		mocks.Store.On("DeleteObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name+"/.v/1/").Return(nil).Once()
 
 		result, err = reconciler.Reconcile(request)
 		validateReconcilation(err, result)
 		asset = &assetstorev1beta1.ClusterAsset{}
 		Expect(k8sClient.Get(context.TODO(), request.NamespacedName, asset)).To(Succeed())
-		validateAsset(asset.Status.CommonAssetStatus, asset.ObjectMeta, baseURL, []string{"test.file"}, assetstorev1beta1.AssetReady, assetstorev1beta1.AssetUploaded)
+		validateAsset(asset.Status.CommonAssetStatus, asset.ObjectMeta, baseURL+"/.v/2", []string{"test.file"}, assetstorev1beta1.AssetReady, assetstorev1beta1.AssetUploaded)
 
 		By("deleting the ClusterAsset")
 		Expect(k8sClient.Delete(context.TODO(), asset)).To(Succeed())
 
 		// On delete
-		mocks.Store.On("ListObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name).Return([]string{"test.file"}, nil).Once()
-		mocks.Store.On("DeleteObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name).Return(nil).Once()
+		mocks.Store.On("ListObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name+"/").Return([]string{asset.Name + "/.v/2/test.file"}, nil).Once()
+		mocks.Store.On("DeleteObjects", mock.Anything, asset.Spec.BucketRef.Name, asset.Name+"/").Return(nil).Once()
 
 		result, err = reconciler.Reconcile(request)
 		validateReconcilation(err, result)
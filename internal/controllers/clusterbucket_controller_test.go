@@ -61,7 +61,7 @@ var _ = Describe("ClusterBucket", func() {
 		By("creating the ClusterBucket")
 		// given
 		mocks.Store.On("CreateBucket", bucket.Namespace, bucket.Name, string(bucket.Spec.Region)).Return("test", nil).Once()
-		mocks.Store.On("SetBucketPolicy", "test", bucket.Spec.Policy).Return(nil).Once()
+		mocks.Store.On("SetBucketPolicy", "test", bucket.Spec.Policy, bucket.Spec.AccessPolicy).Return(nil).Once()
 
 		// when
 		result, err := reconciler.Reconcile(request)
@@ -86,8 +86,9 @@ var _ = Describe("ClusterBucket", func() {
 
 		// given
 		mocks.Store.On("BucketExists", "test").Return(true, nil).Once()
-		mocks.Store.On("CompareBucketPolicy", "test", bucket.Spec.Policy).Return(false, nil).Once()
-		mocks.Store.On("SetBucketPolicy", "test", bucket.Spec.Policy).Return(nil).Once()
+		mocks.Store.On("CompareBucketPolicy", "test", bucket.Spec.Policy, bucket.Spec.AccessPolicy).Return(false, nil).Once()
+		mocks.Store.On("SetBucketPolicy", "test", bucket.Spec.Policy, bucket.Spec.AccessPolicy).Return(nil).Once()
+		mocks.Store.On("CompareBucketLifecycle", "test", bucket.Spec.Lifecycle).Return(true, nil).Once()
 
 		// when
 		result, err = reconciler.Reconcile(request)
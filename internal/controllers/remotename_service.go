@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// remoteNameService finds the Buckets and ClusterBuckets bound to the remote buckets
+type remoteNameService struct {
+	client client.Client
+}
+
+func newRemoteNameService(client client.Client) *remoteNameService {
+	return &remoteNameService{
+		client: client,
+	}
+}
+
+// FindOwner returns the kind and name of the Bucket or ClusterBucket, other than the given one, which has the remote
+// bucket in its status. ClusterBuckets are given without the namespace.
+func (s *remoteNameService) FindOwner(ctx context.Context, namespace, name, remoteName string) (string, bool, error) {
+	buckets := &v1beta1.BucketList{}
+	if err := s.client.List(ctx, buckets); err != nil {
+		return "", false, errors.Wrap(err, "while listing Buckets")
+	}
+	for _, instance := range buckets.Items {
+		if instance.Status.RemoteName != remoteName || (instance.Namespace == namespace && instance.Name == name) {
+			continue
+		}
+		return fmt.Sprintf("Bucket %s/%s", instance.Namespace, instance.Name), true, nil
+	}
+
+	clusterBuckets := &v1beta1.ClusterBucketList{}
+	if err := s.client.List(ctx, clusterBuckets); err != nil {
+		return "", false, errors.Wrap(err, "while listing ClusterBuckets")
+	}
+	for _, instance := range clusterBuckets.Items {
+		if instance.Status.RemoteName != remoteName || (namespace == "" && instance.Name == name) {
+			continue
+		}
+		return fmt.Sprintf("ClusterBucket %s", instance.Name), true, nil
+	}
+
+	return "", false, nil
+}
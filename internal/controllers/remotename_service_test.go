@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+var _ = Describe("RemoteNameService", func() {
+	var (
+		service       *remoteNameService
+		bucket        *assetstorev1beta1.Bucket
+		clusterBucket *assetstorev1beta1.ClusterBucket
+	)
+
+	BeforeEach(func() {
+		service = newRemoteNameService(k8sClient)
+
+		bucket = newFixBucket()
+		Expect(k8sClient.Create(context.TODO(), bucket)).To(Succeed())
+		bucket.Status.RemoteName = string(uuid.NewUUID())
+		Expect(k8sClient.Status().Update(context.TODO(), bucket)).To(Succeed())
+
+		clusterBucket = newFixClusterBucket()
+		Expect(k8sClient.Create(context.TODO(), clusterBucket)).To(Succeed())
+		clusterBucket.Status.RemoteName = string(uuid.NewUUID())
+		Expect(k8sClient.Status().Update(context.TODO(), clusterBucket)).To(Succeed())
+	})
+
+	It("should find the Bucket bound to the remote bucket", func() {
+		owner, found, err := service.FindOwner(context.TODO(), "other", bucket.Name, bucket.Status.RemoteName)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(owner).To(Equal(fmt.Sprintf("Bucket %s/%s", bucket.Namespace, bucket.Name)))
+	})
+
+	It("should find the ClusterBucket bound to the remote bucket", func() {
+		owner, found, err := service.FindOwner(context.TODO(), bucket.Namespace, clusterBucket.Name, clusterBucket.Status.RemoteName)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(owner).To(Equal(fmt.Sprintf("ClusterBucket %s", clusterBucket.Name)))
+	})
+
+	It("should skip the bucket bound to the given CR", func() {
+		_, found, err := service.FindOwner(context.TODO(), bucket.Namespace, bucket.Name, bucket.Status.RemoteName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		_, found, err = service.FindOwner(context.TODO(), "", clusterBucket.Name, clusterBucket.Status.RemoteName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("should not find the bucket without any CR", func() {
+		_, found, err := service.FindOwner(context.TODO(), bucket.Namespace, bucket.Name, string(uuid.NewUUID()))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
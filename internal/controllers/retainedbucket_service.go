@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// retainedBucketService keeps a RetainedBucket, named after the remote bucket, for every bucket retained in the storage
+type retainedBucketService struct {
+	client client.Client
+}
+
+func newRetainedBucketService(client client.Client) *retainedBucketService {
+	return &retainedBucketService{
+		client: client,
+	}
+}
+
+func (s *retainedBucketService) Record(ctx context.Context, spec v1beta1.RetainedBucketSpec) error {
+	instance := &v1beta1.RetainedBucket{
+		ObjectMeta: v1.ObjectMeta{
+			Name: spec.RemoteName,
+		},
+		Spec: spec,
+	}
+
+	err := s.client.Create(ctx, instance)
+	if err == nil {
+		return nil
+	}
+	if !apiErrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "while creating RetainedBucket %s", spec.RemoteName)
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current := &v1beta1.RetainedBucket{}
+		if err := s.client.Get(ctx, types.NamespacedName{Name: spec.RemoteName}, current); err != nil {
+			return err
+		}
+
+		copy := current.DeepCopy()
+		copy.Spec = spec
+
+		return s.client.Update(ctx, copy)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "while updating RetainedBucket %s", spec.RemoteName)
+	}
+
+	return nil
+}
+
+func (s *retainedBucketService) Forget(ctx context.Context, remoteName string) error {
+	instance := &v1beta1.RetainedBucket{
+		ObjectMeta: v1.ObjectMeta{
+			Name: remoteName,
+		},
+	}
+
+	if err := s.client.Delete(ctx, instance); err != nil && !apiErrors.IsNotFound(err) {
+		return errors.Wrapf(err, "while deleting RetainedBucket %s", remoteName)
+	}
+
+	return nil
+}
@@ -8,9 +8,11 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/internal/compressor"
 	"github.com/kyma-project/rafter/internal/loader"
 	"github.com/kyma-project/rafter/internal/store"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go/pkg/encrypt"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -40,6 +42,7 @@ type FindBucketStatus func(ctx context.Context, namespace, name string) (*v1beta
 type assetHandler struct {
 	recorder          record.EventRecorder
 	findBucketStatus  FindBucketStatus
+	findEncryptionKey FindEncryptionKey
 	store             store.Store
 	loader            loader.Loader
 	validator         assethook.Validator
@@ -47,19 +50,22 @@ type assetHandler struct {
 	metadataExtractor assethook.MetadataExtractor
 	log               logr.Logger
 	relistInterval    time.Duration
+	keptVersionCount  int
 }
 
-func New(log logr.Logger, recorder record.EventRecorder, store store.Store, loader loader.Loader, findBucketFnc FindBucketStatus, validator assethook.Validator, mutator assethook.Mutator, metadataExtractor assethook.MetadataExtractor, relistInterval time.Duration) Handler {
+func New(log logr.Logger, recorder record.EventRecorder, store store.Store, loader loader.Loader, findBucketFnc FindBucketStatus, findEncryptionKeyFnc FindEncryptionKey, validator assethook.Validator, mutator assethook.Mutator, metadataExtractor assethook.MetadataExtractor, relistInterval time.Duration, keptVersionCount int) Handler {
 	return &assetHandler{
 		recorder:          recorder,
 		store:             store,
 		loader:            loader,
 		findBucketStatus:  findBucketFnc,
+		findEncryptionKey: findEncryptionKeyFnc,
 		validator:         validator,
 		mutator:           mutator,
 		metadataExtractor: metadataExtractor,
 		log:               log,
 		relistInterval:    relistInterval,
+		keptVersionCount:  keptVersionCount,
 	}
 }
 
@@ -73,16 +79,19 @@ func (h *assetHandler) Do(ctx context.Context, now time.Time, instance MetaAcces
 		return h.onDelete(ctx, instance, spec)
 	case h.isOnAddOrUpdate(instance, status):
 		h.logInfof("On add or update")
-		return h.getStatus(instance, v1beta1.AssetPending, v1beta1.AssetScheduled), nil
+		return h.withPublishedContent(h.getStatus(instance, v1beta1.AssetPending, v1beta1.AssetScheduled), status), nil
 	case h.isOnReady(status, now):
 		h.logInfof("On ready")
-		return h.onReady(ctx, instance, spec, status)
+		result, err := h.onReady(ctx, instance, spec, status)
+		return h.withPublishedContent(result, status), err
 	case h.isOnPending(status, now):
 		h.logInfof("On pending")
-		return h.onPending(ctx, instance, spec, status)
+		result, err := h.onPending(ctx, instance, spec, status)
+		return h.withPublishedContent(result, status), err
 	case h.isOnFailed(status):
 		h.logInfof("On failed")
-		return h.onPending(ctx, instance, spec, status)
+		result, err := h.onPending(ctx, instance, spec, status)
+		return h.withPublishedContent(result, status), err
 	default:
 		h.logInfof("Action not taken")
 		return nil, nil
@@ -112,7 +121,10 @@ func (*assetHandler) isOnDelete(object MetaAccessor) bool {
 func (*assetHandler) isOnFailed(status v1beta1.CommonAssetStatus) bool {
 	return status.Phase == v1beta1.AssetFailed &&
 		status.Reason != v1beta1.AssetValidationFailed &&
-		status.Reason != v1beta1.AssetMutationFailed
+		status.Reason != v1beta1.AssetMutationFailed &&
+		status.Reason != v1beta1.AssetIntegrityCheckFailed &&
+		status.Reason != v1beta1.AssetArchiveRejected &&
+		status.Reason != v1beta1.AssetRollbackFailed
 }
 
 func (h *assetHandler) isOnReady(status v1beta1.CommonAssetStatus, now time.Time) bool {
@@ -140,7 +152,7 @@ func (h *assetHandler) onDelete(ctx context.Context, object MetaAccessor, spec v
 
 func (h *assetHandler) deleteRemoteContent(ctx context.Context, object MetaAccessor, bucketName string) error {
 	h.logInfof("Checking if bucket contains files for asset")
-	prefix := object.GetName()
+	prefix := store.AssetPrefix(object.GetName())
 	files, err := h.store.ListObjects(ctx, bucketName, prefix)
 	if err != nil {
 		return errors.Wrap(err, "while listing files in bucket")
@@ -176,7 +188,8 @@ func (h *assetHandler) onReady(ctx context.Context, object MetaAccessor, spec v1
 	h.logInfof("Bucket %s is ready", spec.BucketRef.Name)
 
 	h.logInfof("Checking if store contains all files")
-	exists, err := h.store.ContainsAllObjects(ctx, bucketStatus.RemoteName, object.GetName(), h.extractNames(status.AssetRef.Files))
+	prefix := versionPrefix(object.GetName(), status.AssetRef.Version)
+	exists, err := h.store.ContainsAllObjects(ctx, bucketStatus.RemoteName, prefix, h.extractNames(status.AssetRef.Files))
 	if err != nil {
 		h.recordWarningEventf(object, v1beta1.AssetRemoteContentVerificationError, err.Error())
 		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetRemoteContentVerificationError, err.Error()), err
@@ -186,9 +199,26 @@ func (h *assetHandler) onReady(ctx context.Context, object MetaAccessor, spec v1
 		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetMissingContent), err
 	}
 
+	if version := rollbackVersion(spec); version > 0 {
+		h.logInfof("Asset is rolled back to version %d", version)
+		return h.getReadyStatus(object, status.AssetRef, v1beta1.AssetRolledBack, version), nil
+	}
+
+	if spec.Source.SyncPolicy == v1beta1.AssetSyncPeriodic {
+		h.logInfof("Checking if source %s has changed", spec.Source.URL)
+		changed, err := h.loader.Changed(object.GetNamespace(), spec.Source, status.AssetRef)
+		switch {
+		case err != nil:
+			h.recordWarningEventf(object, v1beta1.AssetSourceCheckFailed, err.Error())
+		case changed:
+			h.recordNormalEventf(object, v1beta1.AssetSourceChanged)
+			return h.getStatus(object, v1beta1.AssetPending, v1beta1.AssetSourceChanged), nil
+		}
+	}
+
 	h.logInfof("Asset is up-to-date")
 
-	return h.getReadyStatus(object, status.AssetRef.BaseURL, status.AssetRef.Files, v1beta1.AssetUploaded), nil
+	return h.getReadyStatus(object, status.AssetRef, v1beta1.AssetUploaded), nil
 }
 
 func (h *assetHandler) extractNames(files []v1beta1.AssetFile) []string {
@@ -215,18 +245,38 @@ func (h *assetHandler) onPending(ctx context.Context, object MetaAccessor, spec
 	}
 	h.logInfof("Bucket %s is ready", spec.BucketRef.Name)
 
-	if err := h.deleteRemoteContent(ctx, object, bucketStatus.RemoteName); err != nil {
-		h.recordWarningEventf(object, v1beta1.AssetCleanupError, err.Error())
-		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetCleanupError, err.Error()), err
+	if version := rollbackVersion(spec); version > 0 {
+		return h.onRollback(ctx, object, status, bucketStatus, version)
+	}
+
+	metadata, err := store.NewObjectMetadata(spec.ObjectMetadata)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetInvalidObjectMetadata, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetInvalidObjectMetadata, err.Error()), nil
+	}
+	sse, err := h.bucketEncryption(ctx, object, bucketStatus)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetEncryptionFailed, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetEncryptionFailed, err.Error()), err
+	}
+	metadata = metadata.WithEncryption(sse)
+	if h.rulesUnchanged(object, status) {
+		metadata = metadata.WithUnchangedRules()
+	}
+
+	version := nextVersion(status)
+	prefix := versionPrefix(object.GetName(), version)
+	if h.isStreamable(spec) {
+		return h.onStream(ctx, object, spec, status, bucketStatus, version, metadata)
 	}
 
 	h.logInfof("Loading files from %s", spec.Source.URL)
-	basePath, filenames, err := h.loader.Load(spec.Source.URL, object.GetName(), spec.Source.Mode, spec.Source.Filter)
-	defer h.loader.Clean(basePath)
+	loaded, err := h.loader.Load(object.GetNamespace(), object.GetName(), spec.Source)
+	defer h.loader.Clean(loaded.BasePath)
 	if err != nil {
-		h.recordWarningEventf(object, v1beta1.AssetPullingFailed, err.Error())
-		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetPullingFailed, err.Error()), err
+		return h.onLoadError(object, err)
 	}
+	basePath, filenames := loaded.BasePath, loaded.Files
 	h.logInfof("Files loaded")
 	h.recordNormalEventf(object, v1beta1.AssetPulled)
 
@@ -275,15 +325,146 @@ func (h *assetHandler) onPending(ctx context.Context, object MetaAccessor, spec
 		h.recordNormalEventf(object, v1beta1.AssetMetadataExtracted)
 	}
 
-	h.logInfof("Uploading Asset content to Minio")
-	if err := h.store.PutObjects(ctx, bucketStatus.RemoteName, object.GetName(), basePath, filenames); err != nil {
+	uploaded := filenames
+	if spec.Compression != nil {
+		h.logInfof("Compressing Asset content")
+		result, err := compressor.Compress(basePath, filenames, *spec.Compression, metadata)
+		if err != nil {
+			h.recordWarningEventf(object, v1beta1.AssetCompressionFailed, err.Error())
+			return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetCompressionFailed, err.Error()), err
+		}
+		uploaded, metadata = result.Files, result.Metadata
+		h.logInfof("Asset content compressed, %d compressed files", result.Compressed)
+		h.recordNormalEventf(object, v1beta1.AssetCompressed)
+	}
+
+	h.seedVersion(ctx, bucketStatus.RemoteName, object.GetName(), status, prefix, metadata.Encryption())
+
+	h.logInfof("Uploading changed Asset content to Minio")
+	synced, err := h.store.SyncObjects(ctx, bucketStatus.RemoteName, prefix, basePath, uploaded, metadata)
+	if err != nil {
 		h.recordWarningEventf(object, v1beta1.AssetUploadFailed, err.Error())
 		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetUploadFailed, err.Error()), err
 	}
-	h.logInfof("Asset content uploaded")
+	h.logInfof("Asset content uploaded, %d of %d files changed", len(synced.Uploaded), len(uploaded))
 	h.recordNormalEventf(object, v1beta1.AssetUploaded)
+	if len(synced.Deleted) > 0 {
+		h.logInfof("Deleted %d files removed from the source", len(synced.Deleted))
+		h.recordNormalEventf(object, v1beta1.AssetCleaned)
+	}
 
-	return h.getReadyStatus(object, h.getBaseUrl(bucketStatus.URL, object.GetName()), files, v1beta1.AssetUploaded), nil
+	assetRef := h.getAssetRef(bucketStatus.URL, object.GetName(), version, files, loaded)
+	return h.publish(ctx, object, spec, status, bucketStatus.RemoteName, assetRef), nil
+}
+
+// isStreamable returns true if the content can be uploaded while it is downloaded. Webhooks and compression work
+// on local files, and the content cannot be published before it is validated.
+func (h *assetHandler) isStreamable(spec v1beta1.CommonAssetSpec) bool {
+	source := spec.Source
+	if len(source.MutationWebhookService) > 0 || len(source.ValidationWebhookService) > 0 || len(source.MetadataWebhookService) > 0 {
+		return false
+	}
+	if spec.Compression != nil {
+		return false
+	}
+
+	return h.loader.Streamable(source)
+}
+
+// bucketEncryption returns the encryption of the objects uploaded to the bucket, with the SSE-C key read from the Secret
+func (h *assetHandler) bucketEncryption(ctx context.Context, object MetaAccessor, bucketStatus *v1beta1.CommonBucketStatus) (encrypt.ServerSide, error) {
+	encryption := bucketStatus.Encryption
+	if encryption == nil {
+		return nil, nil
+	}
+
+	var key []byte
+	if encryption.Type == v1beta1.BucketEncryptionSSEC {
+		if encryption.SecretRef == nil {
+			return nil, errors.New("secretRef is required for SSE-C encryption")
+		}
+		secretKey, err := h.findEncryptionKey(ctx, object.GetNamespace(), *encryption.SecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "while reading SSE-C key")
+		}
+		key = secretKey
+	}
+
+	return store.NewServerSideEncryption(encryption.Type, key)
+}
+
+func (h *assetHandler) onStream(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus, bucketStatus *v1beta1.CommonBucketStatus, version int64, metadata store.ObjectMetadata) (*v1beta1.CommonAssetStatus, error) {
+	prefix := versionPrefix(object.GetName(), version)
+	h.seedVersion(ctx, bucketStatus.RemoteName, object.GetName(), status, prefix, metadata.Encryption())
+
+	h.logInfof("Streaming files from %s to Minio", spec.Source.URL)
+	sink := &objectSink{
+		ctx:        ctx,
+		store:      h.store,
+		bucketName: bucketStatus.RemoteName,
+		assetName:  prefix,
+		metadata:   metadata,
+	}
+	loaded, err := h.loader.Stream(object.GetNamespace(), object.GetName(), spec.Source, sink)
+	if uploadErr := sink.Err(); uploadErr != nil {
+		h.recordWarningEventf(object, v1beta1.AssetUploadFailed, uploadErr.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetUploadFailed, uploadErr.Error()), uploadErr
+	}
+	if err != nil {
+		return h.onLoadError(object, err)
+	}
+	h.logInfof("Asset content streamed")
+	h.recordNormalEventf(object, v1beta1.AssetPulled)
+	h.recordNormalEventf(object, v1beta1.AssetUploaded)
+
+	deleted, err := h.store.DeleteStaleObjects(ctx, bucketStatus.RemoteName, prefix, loaded.Files)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetCleanupError, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetCleanupError, err.Error()), err
+	}
+	if len(deleted) > 0 {
+		h.logInfof("Deleted %d files removed from the source", len(deleted))
+		h.recordNormalEventf(object, v1beta1.AssetCleaned)
+	}
+
+	assetRef := h.getAssetRef(bucketStatus.URL, object.GetName(), version, h.populateFiles(loaded.Files), loaded)
+	return h.publish(ctx, object, spec, status, bucketStatus.RemoteName, assetRef), nil
+}
+
+// onLoadError returns the status for the loading error. Errors caused by the content itself are not retried.
+// Denied access and sources rejected by the source policy are checked again after the relist interval,
+// as access can be granted and the policy can change later.
+func (h *assetHandler) onLoadError(object MetaAccessor, err error) (*v1beta1.CommonAssetStatus, error) {
+	switch {
+	case loader.IsIntegrityError(err):
+		h.recordWarningEventf(object, v1beta1.AssetIntegrityCheckFailed, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetIntegrityCheckFailed, err.Error()), nil
+	case loader.IsArchiveError(err):
+		h.recordWarningEventf(object, v1beta1.AssetArchiveRejected, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetArchiveRejected, err.Error()), nil
+	case loader.IsAccessDeniedError(err):
+		h.recordWarningEventf(object, v1beta1.AssetAccessDenied, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetAccessDenied, err.Error()), nil
+	case loader.IsSourcePolicyError(err):
+		h.recordWarningEventf(object, v1beta1.AssetSourceNotAllowed, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetSourceNotAllowed, err.Error()), nil
+	}
+
+	h.recordWarningEventf(object, v1beta1.AssetPullingFailed, err.Error())
+	return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetPullingFailed, err.Error()), err
+}
+
+func (h *assetHandler) getAssetRef(bucketUrl, assetName string, version int64, files []v1beta1.AssetFile, loaded loader.Result) v1beta1.AssetStatusRef {
+	return v1beta1.AssetStatusRef{
+		BaseURL:      h.getBaseUrl(bucketUrl, versionPrefix(assetName, version)),
+		Version:      version,
+		Files:        files,
+		Revision:     loaded.Revision,
+		ETag:         loaded.ETag,
+		LastModified: loaded.LastModified,
+		Digest:       loaded.Digest,
+		Entries:      loaded.Entries,
+	}
 }
 
 func (h *assetHandler) populateFiles(filenames []string) []v1beta1.AssetFile {
@@ -340,10 +521,9 @@ func (h *assetHandler) recordEventf(object MetaAccessor, eventType string, reaso
 	h.recorder.Eventf(object, eventType, reason.String(), reason.Message(), args...)
 }
 
-func (h *assetHandler) getReadyStatus(object MetaAccessor, baseUrl string, files []v1beta1.AssetFile, reason v1beta1.AssetReason, args ...interface{}) *v1beta1.CommonAssetStatus {
+func (h *assetHandler) getReadyStatus(object MetaAccessor, assetRef v1beta1.AssetStatusRef, reason v1beta1.AssetReason, args ...interface{}) *v1beta1.CommonAssetStatus {
 	status := h.getStatus(object, v1beta1.AssetReady, reason, args...)
-	status.AssetRef.BaseURL = baseUrl
-	status.AssetRef.Files = files
+	status.AssetRef = assetRef
 	return status
 }
 
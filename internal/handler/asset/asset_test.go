@@ -2,6 +2,9 @@ package asset_test
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -9,9 +12,12 @@ import (
 	engine "github.com/kyma-project/rafter/internal/assethook"
 	engineMock "github.com/kyma-project/rafter/internal/assethook/automock"
 	"github.com/kyma-project/rafter/internal/handler/asset"
+	"github.com/kyma-project/rafter/internal/loader"
 	loaderMock "github.com/kyma-project/rafter/internal/loader/automock"
+	"github.com/kyma-project/rafter/internal/store"
 	storeMock "github.com/kyma-project/rafter/internal/store/automock"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go/pkg/encrypt"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
@@ -162,40 +168,868 @@ func TestAssetHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
 	})
 
+	t.Run("SourceChanged", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Source.SyncPolicy = v1beta1.AssetSyncPeriodic
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset.Status.CommonAssetStatus.AssetRef.ETag = "\"v1\""
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(true, nil).Once()
+		mocks.loader.On("Changed", asset.Namespace, asset.Spec.Source, asset.Status.AssetRef).Return(true, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetSourceChanged))
+	})
+
+	t.Run("SourceNotChanged", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Source.SyncPolicy = v1beta1.AssetSyncPeriodic
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset.Status.CommonAssetStatus.AssetRef.ETag = "\"v1\""
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(true, nil).Once()
+		mocks.loader.On("Changed", asset.Namespace, asset.Spec.Source, asset.Status.AssetRef).Return(false, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("SourceCheckError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Source.SyncPolicy = v1beta1.AssetSyncPeriodic
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset.Status.CommonAssetStatus.AssetRef.ETag = "\"v1\""
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(true, nil).Once()
+		mocks.loader.On("Changed", asset.Namespace, asset.Spec.Source, asset.Status.AssetRef).Return(false, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
 	t.Run("BucketNotReady", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "notReady", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
-		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
-		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset := testData("test-asset", "notReady", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketNotReady))
+	})
+
+	t.Run("BucketError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "error", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketError))
+	})
+
+	t.Run("MissingFiles", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(false, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetMissingContent))
+	})
+
+	t.Run("ContainsError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(false, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetRemoteContentVerificationError))
+	})
+}
+
+func TestAssetHandler_Handle_OnPending(t *testing.T) {
+	t.Run("WithWebhooks", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, asset.Name+"/.v/1", "/tmp", mock.AnythingOfType("[]string"), store.ObjectMetadata{}).Return(store.SyncResult{}, nil).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("WithoutWebhooks", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, asset.Name+"/.v/1", "/tmp", mock.AnythingOfType("[]string"), store.ObjectMetadata{}).Return(store.SyncResult{}, nil).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("WithRevision", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/repo.git")
+		asset.Spec.Source.Mode = v1beta1.AssetGit
+		asset.Spec.Source.Git = &v1beta1.AssetGitSource{Ref: "master", Path: "docs"}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, asset.Name+"/.v/1", "/tmp", []string{"README.md"}, store.ObjectMetadata{}).Return(store.SyncResult{}, nil).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"README.md"}, Revision: "8a1f0c2"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.AssetRef.Revision).To(Equal("8a1f0c2"))
+		g.Expect(status.AssetRef.Files).To(ConsistOf(v1beta1.AssetFile{Name: "README.md"}))
+	})
+
+	t.Run("Streamed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.tar.gz")
+		asset.Spec.Source.Mode = v1beta1.AssetPackage
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("PutObject", ctx, remoteBucketName, asset.Name+"/.v/1", "README.md", mock.Anything, int64(6), store.ObjectMetadata{}).Return(nil).Once()
+		mocks.store.On("DeleteStaleObjects", ctx, remoteBucketName, asset.Name+"/.v/1", []string{"README.md"}).Return(nil, nil).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(true).Once()
+		mocks.loader.On("Stream", asset.Namespace, asset.Name, asset.Spec.Source, mock.Anything).Return(func(namespace, name string, source v1beta1.AssetSource, sink loader.Sink) loader.Result {
+			g.Expect(sink.Put("README.md", strings.NewReader("# Test"), 6)).To(Succeed())
+			return loader.Result{Files: []string{"README.md"}, Digest: "sha256:abc"}
+		}, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(status.AssetRef.Digest).To(Equal("sha256:abc"))
+		g.Expect(status.AssetRef.Files).To(ConsistOf(v1beta1.AssetFile{Name: "README.md"}))
+	})
+
+	t.Run("StreamUploadError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("PutObject", ctx, remoteBucketName, asset.Name+"/.v/1", "test.md", mock.Anything, int64(6), store.ObjectMetadata{}).Return(errors.New("nope")).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(true).Once()
+		mocks.loader.On("Stream", asset.Namespace, asset.Name, asset.Spec.Source, mock.Anything).Return(loader.Result{}, func(namespace, name string, source v1beta1.AssetSource, sink loader.Sink) error {
+			return errors.Wrap(sink.Put("test.md", strings.NewReader("# Test"), 6), "while uploading test.md")
+		}).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploadFailed))
+	})
+
+	t.Run("StreamArchiveRejected", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.zip")
+		asset.Spec.Source.Mode = v1beta1.AssetPackage
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(true).Once()
+		mocks.loader.On("Stream", asset.Namespace, asset.Name, asset.Spec.Source, mock.Anything).Return(loader.Result{}, errors.Wrap(&loader.ArchiveError{}, "while handling ZIP entry")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetArchiveRejected))
+	})
+
+	t.Run("WithObjectMetadata", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.ObjectMetadata = []v1beta1.AssetObjectMetadata{{Pattern: "*.md", CacheControl: "max-age=60"}}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		metadata, err := store.NewObjectMetadata(asset.Spec.ObjectMetadata)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, asset.Name+"/.v/1", "/tmp", []string{"test.md"}, metadata).Return(store.SyncResult{}, nil).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"test.md"}}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+	})
+
+	t.Run("WithCompression", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/index.html")
+		asset.Spec.Compression = &v1beta1.AssetCompression{Encodings: []v1beta1.AssetEncoding{v1beta1.AssetEncodingGzip}}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		basePath, err := ioutil.TempDir("", "asset")
+		g.Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(basePath)
+		err = ioutil.WriteFile(filepath.Join(basePath, "index.html"), []byte(strings.Repeat("<p>Test</p>\n", 200)), 0644)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, asset.Name+"/.v/1", basePath, []string{"index.html", "index.html.gz"}, mock.MatchedBy(func(metadata store.ObjectMetadata) bool {
+			return metadata.Options("index.html.gz").ContentEncoding == "gzip"
+		})).Return(store.SyncResult{}, nil).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: basePath, Files: []string{"index.html"}}, nil).Once()
+		mocks.loader.On("Clean", basePath).Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.AssetRef.Files).To(ConsistOf(v1beta1.AssetFile{Name: "index.html"}))
+	})
+
+	t.Run("WithEncryption", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "encrypted-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, asset.Name+"/.v/1", "/tmp", []string{"test.md"}, mock.MatchedBy(func(metadata store.ObjectMetadata) bool {
+			sse := metadata.Options("test.md").ServerSideEncryption
+			return sse != nil && sse.Type() == encrypt.SSEC
+		})).Return(store.SyncResult{}, nil).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"test.md"}}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+	})
+
+	t.Run("EncryptionKeyError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "encrypted-unreadable-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetEncryptionFailed))
+	})
+
+	t.Run("InvalidObjectMetadata", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.ObjectMetadata = []v1beta1.AssetObjectMetadata{{Pattern: "[a-"}}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetInvalidObjectMetadata))
+	})
+
+	t.Run("LoadError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, errors.New("nope")).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetPullingFailed))
+	})
+
+	t.Run("IntegrityCheckFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Source.Checksum = "sha256:0000"
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, errors.Wrap(&loader.IntegrityError{}, "while verifying checksum")).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetIntegrityCheckFailed))
+	})
+
+	t.Run("ArchiveRejected", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.zip")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, errors.Wrap(&loader.ArchiveError{}, "while handling ZIP entry")).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetArchiveRejected))
+	})
+
+	t.Run("RollbackFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Versioning = &v1beta1.AssetVersioning{RollbackTo: 5}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetRollbackFailed
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("AccessDenied", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "docs/readme")
+		asset.Spec.Source.Mode = v1beta1.AssetConfigMap
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{}, &loader.AccessDeniedError{}).Once()
+		mocks.loader.On("Clean", "").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetAccessDenied))
+	})
+
+	t.Run("SourceNotAllowed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "http://169.254.169.254/latest/meta-data")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{}, &loader.SourcePolicyError{}).Once()
+		mocks.loader.On("Clean", "").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetSourceNotAllowed))
+	})
+
+	t.Run("MutationFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: false}, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetMutationFailed))
+	})
+
+	t.Run("MutationError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: false}, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetMutationError))
+	})
+
+	t.Run("MetadataExtractionFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetMetadataExtractionFailed))
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetValidationError))
+	})
+
+	t.Run("ValidationFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetValidationFailed))
+	})
+
+	t.Run("UploadError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, asset.Name+"/.v/1", "/tmp", mock.AnythingOfType("[]string"), store.ObjectMetadata{}).Return(store.SyncResult{}, errors.New("nope")).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil).Once()
+
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketNotReady))
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploadFailed))
 	})
 
-	t.Run("BucketError", func(t *testing.T) {
+	t.Run("BucketNotReady", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "error", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
-		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
-		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset := testData("test-asset", "notReady", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
@@ -204,80 +1038,76 @@ func TestAssetHandler_Handle_OnReady(t *testing.T) {
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
+		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketError))
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketNotReady))
 	})
 
-	t.Run("MissingFiles", func(t *testing.T) {
+	t.Run("BucketStatusError", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
-		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
-		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset := testData("test-asset", "error", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(false, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetMissingContent))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketError))
 	})
 
-	t.Run("ContainsError", func(t *testing.T) {
+	t.Run("OnBucketNotReadyBeforeTime", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
-		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
-		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset := testData("test-asset", "error", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketNotReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.Now()
+		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(false, errors.New("nope")).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetRemoteContentVerificationError))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
 	})
 }
 
-func TestAssetHandler_Handle_OnPending(t *testing.T) {
-	t.Run("WithWebhooks", func(t *testing.T) {
+func TestAssetHandler_Handle_OnFailed(t *testing.T) {
+	t.Run("ShouldHandle", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketError
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string")).Return(nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, asset.Name+"/.v/1", "/tmp", mock.AnythingOfType("[]string"), store.ObjectMetadata{}).Return(store.SyncResult{}, nil).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp"}, nil).Once()
 		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
 		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
 		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
@@ -293,197 +1123,179 @@ func TestAssetHandler_Handle_OnPending(t *testing.T) {
 		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
 	})
 
-	t.Run("WithoutWebhooks", func(t *testing.T) {
+	t.Run("ValidationFailed", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetValidationFailed
 		asset.Status.ObservedGeneration = asset.Generation
-		asset.Spec.Source.ValidationWebhookService = nil
-		asset.Spec.Source.MutationWebhookService = nil
-		asset.Spec.Source.MetadataWebhookService = nil
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string")).Return(nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(status).To(BeZero())
 	})
 
-	t.Run("LoadError", func(t *testing.T) {
+	t.Run("MutationFailed", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetMutationFailed
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, errors.New("nope")).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetPullingFailed))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
 	})
 
-	t.Run("MutationFailed", func(t *testing.T) {
+	t.Run("IntegrityCheckFailed", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetIntegrityCheckFailed
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: false}, nil).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetMutationFailed))
+		g.Expect(status).To(BeZero())
 	})
 
-	t.Run("MutationError", func(t *testing.T) {
+	t.Run("ArchiveRejected", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetArchiveRejected
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: false}, errors.New("nope")).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetMutationError))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
 	})
 
-	t.Run("MetadataExtractionFailed", func(t *testing.T) {
+	t.Run("AccessDenied", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset := testData("test-asset", "test-bucket", "docs/readme")
+		asset.Spec.Source.Mode = v1beta1.AssetConfigMap
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetAccessDenied
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, errors.New("nope")).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{}, &loader.AccessDeniedError{}).Once()
+		mocks.loader.On("Clean", "").Return(nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
+		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetMetadataExtractionFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetAccessDenied))
 	})
+}
 
-	t.Run("ValidationError", func(t *testing.T) {
+func TestAssetHandler_Handle_Versioning(t *testing.T) {
+	t.Run("NextVersion", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		keep := 1
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Versioning = &v1beta1.AssetVersioning{Keep: &keep}
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"test.md"}}, nil).Once()
 		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, errors.New("nope")).Once()
+		mocks.store.On("CopyObjects", ctx, remoteBucketName, "test-asset/.v/2/", "test-asset/.v/3/", nil).Return(nil).Once()
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, "test-asset/.v/3", "/tmp", []string{"test.md"}, store.ObjectMetadata{}.WithUnchangedRules()).Return(store.SyncResult{}, nil).Once()
+		mocks.store.On("DeleteObjects", ctx, remoteBucketName, "test-asset/.v/1/").Return(nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
+		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetValidationError))
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.AssetRef.Version).To(Equal(int64(3)))
+		g.Expect(status.AssetRef.BaseURL).To(Equal("http://test-url.com/bucket-name/test-asset/.v/3"))
+		g.Expect(status.Versions).To(HaveLen(2))
+		g.Expect(status.Versions[0].Version).To(Equal(int64(2)))
+		g.Expect(status.Versions[1].Version).To(Equal(int64(3)))
 	})
 
-	t.Run("ValidationFailed", func(t *testing.T) {
+	t.Run("StreamedNextVersion", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		keep := 1
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Versioning = &v1beta1.AssetVersioning{Keep: &keep}
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, nil).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(true).Once()
+		mocks.loader.On("Stream", asset.Namespace, asset.Name, asset.Spec.Source, mock.Anything).Return(func(namespace, name string, source v1beta1.AssetSource, sink loader.Sink) loader.Result {
+			g.Expect(sink.Put("test.md", strings.NewReader("# Test"), 6)).To(Succeed())
+			return loader.Result{Files: []string{"test.md"}, Revision: "v3"}
+		}, nil).Once()
+		mocks.store.On("CopyObjects", ctx, remoteBucketName, "test-asset/.v/2/", "test-asset/.v/3/", nil).Return(nil).Once()
+		mocks.store.On("PutObject", ctx, remoteBucketName, "test-asset/.v/3", "test.md", mock.Anything, int64(6), store.ObjectMetadata{}.WithUnchangedRules()).Return(nil).Once()
+		mocks.store.On("DeleteStaleObjects", ctx, remoteBucketName, "test-asset/.v/3", []string{"test.md"}).Return(nil, nil).Once()
+		mocks.store.On("DeleteObjects", ctx, remoteBucketName, "test-asset/.v/1/").Return(nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -491,77 +1303,91 @@ func TestAssetHandler_Handle_OnPending(t *testing.T) {
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetValidationFailed))
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.AssetRef.Version).To(Equal(int64(3)))
+		g.Expect(status.AssetRef.BaseURL).To(Equal("http://test-url.com/bucket-name/test-asset/.v/3"))
+		g.Expect(status.AssetRef.Files).To(ConsistOf(v1beta1.AssetFile{Name: "test.md"}))
+		g.Expect(status.Versions).To(HaveLen(2))
+		g.Expect(status.Versions[1].Revision).To(Equal("v3"))
 	})
 
-	t.Run("UploadError", func(t *testing.T) {
+	t.Run("NextVersionOfChangedSpec", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Generation = 2
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string")).Return(errors.New("nope")).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"test.md"}}, nil).Once()
 		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil).Once()
+		mocks.store.On("CopyObjects", ctx, remoteBucketName, "test-asset/.v/2/", "test-asset/.v/3/", nil).Return(nil).Once()
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, "test-asset/.v/3", "/tmp", []string{"test.md"}, store.ObjectMetadata{}).Return(store.SyncResult{}, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
+		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploadFailed))
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.AssetRef.Version).To(Equal(int64(3)))
 	})
 
-	t.Run("BucketNotReady", func(t *testing.T) {
+	t.Run("UnversionedContent", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "notReady", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.AssetRef = v1beta1.AssetStatusRef{BaseURL: "http://test-url.com/bucket-name/test-asset", Files: []v1beta1.AssetFile{{Name: "test.md"}}}
+		asset.Status.Versions = nil
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"test.md"}}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, "test-asset/.v/1", "/tmp", []string{"test.md"}, store.ObjectMetadata{}).Return(store.SyncResult{}, nil).Once()
+		mocks.store.On("ListObjects", ctx, remoteBucketName, "test-asset/").Return([]string{"test-asset/test.md", "test-asset/.v/1/test.md"}, nil).Once()
+		mocks.store.On("DeleteStaleObjects", ctx, remoteBucketName, "test-asset", []string{".v/1/test.md"}).Return([]string{"test-asset/test.md"}, nil).Once()
+
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketNotReady))
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.AssetRef.BaseURL).To(Equal("http://test-url.com/bucket-name/test-asset/.v/1"))
+		g.Expect(status.Versions).To(HaveLen(1))
 	})
 
-	t.Run("BucketStatusError", func(t *testing.T) {
+	t.Run("UploadErrorKeepsPublishedContent", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "error", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
+		mocks.loader.On("Streamable", asset.Spec.Source).Return(false).Once()
+		mocks.loader.On("Load", asset.Namespace, asset.Name, asset.Spec.Source).Return(loader.Result{BasePath: "/tmp", Files: []string{"test.md"}}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.store.On("CopyObjects", ctx, remoteBucketName, "test-asset/.v/2/", "test-asset/.v/3/", nil).Return(nil).Once()
+		mocks.store.On("SyncObjects", ctx, remoteBucketName, "test-asset/.v/3", "/tmp", []string{"test.md"}, store.ObjectMetadata{}.WithUnchangedRules()).Return(store.SyncResult{}, errors.New("nope")).Once()
+
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
@@ -569,20 +1395,19 @@ func TestAssetHandler_Handle_OnPending(t *testing.T) {
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketError))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploadFailed))
+		g.Expect(status.AssetRef).To(Equal(asset.Status.AssetRef))
+		g.Expect(status.Versions).To(Equal(asset.Status.Versions))
 	})
 
-	t.Run("OnBucketNotReadyBeforeTime", func(t *testing.T) {
+	t.Run("ScheduledKeepsPublishedContent", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "error", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketNotReady
-		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.Now()
-		asset.Status.ObservedGeneration = asset.Generation
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Generation = 2
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
@@ -592,32 +1417,24 @@ func TestAssetHandler_Handle_OnPending(t *testing.T) {
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).To(BeZero())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetScheduled))
+		g.Expect(status.AssetRef).To(Equal(asset.Status.AssetRef))
 	})
-}
 
-func TestAssetHandler_Handle_OnFailed(t *testing.T) {
-	t.Run("ShouldHandle", func(t *testing.T) {
+	t.Run("Rollback", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
-		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketError
-		asset.Status.ObservedGeneration = asset.Generation
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Versioning = &v1beta1.AssetVersioning{RollbackTo: 1}
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string")).Return(nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil).Once()
+		mocks.store.On("ListObjects", ctx, remoteBucketName, "test-asset/.v/1/").Return([]string{"test-asset/.v/1/test.md", "test-asset/.v/1/docs/index.md"}, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -626,19 +1443,22 @@ func TestAssetHandler_Handle_OnFailed(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetRolledBack))
+		g.Expect(status.AssetRef.Version).To(Equal(int64(1)))
+		g.Expect(status.AssetRef.BaseURL).To(Equal("http://test-url.com/bucket-name/test-asset/.v/1"))
+		g.Expect(status.AssetRef.Revision).To(Equal("v1"))
+		g.Expect(status.AssetRef.Files).To(ConsistOf(v1beta1.AssetFile{Name: "test.md"}, v1beta1.AssetFile{Name: "docs/index.md"}))
+		g.Expect(status.Versions).To(Equal(asset.Status.Versions))
 	})
 
-	t.Run("ValidationFailed", func(t *testing.T) {
+	t.Run("RollbackNotKept", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
-		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetValidationFailed
-		asset.Status.ObservedGeneration = asset.Generation
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Versioning = &v1beta1.AssetVersioning{RollbackTo: 5}
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
@@ -648,29 +1468,62 @@ func TestAssetHandler_Handle_OnFailed(t *testing.T) {
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).To(BeZero())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetRollbackFailed))
+		g.Expect(status.AssetRef).To(Equal(asset.Status.AssetRef))
 	})
 
-	t.Run("MutationFailed", func(t *testing.T) {
+	t.Run("RollbackListError", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
-		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetMutationFailed
-		asset.Status.ObservedGeneration = asset.Generation
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Versioning = &v1beta1.AssetVersioning{RollbackTo: 1}
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, "test-asset/.v/1/").Return(nil, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetRemoteContentVerificationError))
+		g.Expect(status.AssetRef).To(Equal(asset.Status.AssetRef))
+	})
+
+	t.Run("RolledBackNotChecked", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testVersionedData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Source.SyncPolicy = v1beta1.AssetSyncPeriodic
+		asset.Spec.Versioning = &v1beta1.AssetVersioning{RollbackTo: 2}
+		asset.Status.Phase = v1beta1.AssetReady
+		asset.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
+		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, "test-asset/.v/2", []string{"test.md"}).Return(true, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).To(BeZero())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetRolledBack))
+		g.Expect(status.Versions).To(Equal(asset.Status.Versions))
 	})
 }
 
@@ -687,7 +1540,7 @@ func TestAssetHandler_Handle_OnDelete(t *testing.T) {
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name+"/").Return(nil, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -710,8 +1563,8 @@ func TestAssetHandler_Handle_OnDelete(t *testing.T) {
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(files, nil).Once()
-		mocks.store.On("DeleteObjects", ctx, remoteBucketName, asset.Name).Return(nil).Once()
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name+"/").Return(files, nil).Once()
+		mocks.store.On("DeleteObjects", ctx, remoteBucketName, asset.Name+"/").Return(nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -733,7 +1586,7 @@ func TestAssetHandler_Handle_OnDelete(t *testing.T) {
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, errors.New("nope")).Once()
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name+"/").Return(nil, errors.New("nope")).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -756,8 +1609,8 @@ func TestAssetHandler_Handle_OnDelete(t *testing.T) {
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(files, nil).Once()
-		mocks.store.On("DeleteObjects", ctx, remoteBucketName, asset.Name).Return(errors.New("nope")).Once()
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name+"/").Return(files, nil).Once()
+		mocks.store.On("DeleteObjects", ctx, remoteBucketName, asset.Name+"/").Return(errors.New("nope")).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -816,6 +1669,20 @@ func bucketStatusFinder(ctx context.Context, namespace, name string) (*v1beta1.C
 		return nil, false, nil
 	case strings.Contains(name, "error"):
 		return nil, false, errors.New("test-error")
+	case strings.Contains(name, "encrypted"):
+		secretName := "encryption-key"
+		if strings.Contains(name, "unreadable") {
+			secretName = "missing-key"
+		}
+		return &v1beta1.CommonBucketStatus{
+			Phase:      v1beta1.BucketReady,
+			URL:        "http://test-url.com/bucket-name",
+			RemoteName: remoteBucketName,
+			Encryption: &v1beta1.BucketEncryption{
+				Type:      v1beta1.BucketEncryptionSSEC,
+				SecretRef: &v1beta1.BucketEncryptionSecretRef{Name: secretName},
+			},
+		}, true, nil
 	default:
 		return &v1beta1.CommonBucketStatus{
 			Phase:      v1beta1.BucketReady,
@@ -825,6 +1692,14 @@ func bucketStatusFinder(ctx context.Context, namespace, name string) (*v1beta1.C
 	}
 }
 
+func encryptionKeyFinder(ctx context.Context, namespace string, ref v1beta1.BucketEncryptionSecretRef) ([]byte, error) {
+	if ref.Name != "encryption-key" {
+		return nil, errors.New("test-error")
+	}
+
+	return []byte(strings.Repeat("k", 32)), nil
+}
+
 type mocks struct {
 	store             *storeMock.Store
 	loader            *loaderMock.Loader
@@ -850,7 +1725,7 @@ func newHandler(relistInterval time.Duration) (asset.Handler, mocks) {
 		metadataExtractor: new(engineMock.MetadataExtractor),
 	}
 
-	handler := asset.New(log, fakeRecorder(), mocks.store, mocks.loader, bucketStatusFinder, mocks.validator, mocks.mutator, mocks.metadataExtractor, relistInterval)
+	handler := asset.New(log, fakeRecorder(), mocks.store, mocks.loader, bucketStatusFinder, encryptionKeyFinder, mocks.validator, mocks.mutator, mocks.metadataExtractor, relistInterval, 2)
 
 	return handler, mocks
 }
@@ -901,3 +1776,26 @@ func testDataWithDisplayName(assetName, bucketName, url string, displayName stri
 		},
 	}
 }
+
+func testVersionedData(assetName, bucketName, url string) *v1beta1.Asset {
+	asset := testData(assetName, bucketName, url)
+	asset.Spec.Source.ValidationWebhookService = nil
+	asset.Spec.Source.MutationWebhookService = nil
+	asset.Spec.Source.MetadataWebhookService = nil
+	asset.Status.CommonAssetStatus = v1beta1.CommonAssetStatus{
+		Phase:              v1beta1.AssetPending,
+		ObservedGeneration: asset.Generation,
+		AssetRef: v1beta1.AssetStatusRef{
+			BaseURL:  "http://test-url.com/bucket-name/test-asset/.v/2",
+			Files:    []v1beta1.AssetFile{{Name: "test.md"}},
+			Revision: "v2",
+			Version:  2,
+		},
+		Versions: []v1beta1.AssetVersion{
+			{Version: 1, Generation: 1, Revision: "v1"},
+			{Version: 2, Generation: 1, Revision: "v2"},
+		},
+	}
+
+	return asset
+}
@@ -0,0 +1,50 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const secretEncryptionKey = "key"
+
+// FindEncryptionKey returns the SSE-C key of the bucket used by the asset from the given namespace
+type FindEncryptionKey func(ctx context.Context, namespace string, ref v1beta1.BucketEncryptionSecretRef) ([]byte, error)
+
+// NewSecretEncryptionKeyFinder returns the function reading the SSE-C keys stored in the referenced Secrets, under
+// the "key" key by default. Namespaced assets can use only Secrets from their own namespace, cluster-wide assets
+// have to specify the namespace of the Secret explicitly.
+func NewSecretEncryptionKeyFinder(reader client.Reader) FindEncryptionKey {
+	return func(ctx context.Context, namespace string, ref v1beta1.BucketEncryptionSecretRef) ([]byte, error) {
+		secretNamespace := namespace
+		switch {
+		case namespace == "" && ref.Namespace == "":
+			return nil, fmt.Errorf("namespace of Secret %s is required", ref.Name)
+		case namespace == "":
+			secretNamespace = ref.Namespace
+		case ref.Namespace != "" && ref.Namespace != namespace:
+			return nil, fmt.Errorf("Secret %s has to be in %s namespace", ref.Name, namespace)
+		}
+
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: secretNamespace, Name: ref.Name}, secret); err != nil {
+			return nil, errors.Wrapf(err, "while getting Secret %s from %s namespace", ref.Name, secretNamespace)
+		}
+
+		key := ref.Key
+		if len(key) == 0 {
+			key = secretEncryptionKey
+		}
+		value, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("Secret %s from %s namespace does not contain %s key", ref.Name, secretNamespace, key)
+		}
+
+		return value, nil
+	}
+}
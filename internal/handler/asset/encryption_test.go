@@ -0,0 +1,89 @@
+package asset_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/handler/asset"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewSecretEncryptionKeyFinder(t *testing.T) {
+	reader := fake.NewFakeClientWithScheme(scheme.Scheme,
+		fixSecret("encryption", "default", map[string][]byte{"key": []byte("default-key"), "other": []byte("other-key")}),
+		fixSecret("encryption", "other", map[string][]byte{"key": []byte("cluster-key")}),
+	)
+	findEncryptionKey := asset.NewSecretEncryptionKeyFinder(reader)
+
+	for testName, testCase := range map[string]struct {
+		namespace string
+		ref       v1beta1.BucketEncryptionSecretRef
+		expected  []byte
+		fail      bool
+	}{
+		"DefaultKey": {
+			namespace: "default",
+			ref:       v1beta1.BucketEncryptionSecretRef{Name: "encryption"},
+			expected:  []byte("default-key"),
+		},
+		"Key": {
+			namespace: "default",
+			ref:       v1beta1.BucketEncryptionSecretRef{Name: "encryption", Key: "other"},
+			expected:  []byte("other-key"),
+		},
+		"ClusterWide": {
+			ref:      v1beta1.BucketEncryptionSecretRef{Name: "encryption", Namespace: "other"},
+			expected: []byte("cluster-key"),
+		},
+		"FailClusterWideWithoutNamespace": {
+			ref:  v1beta1.BucketEncryptionSecretRef{Name: "encryption"},
+			fail: true,
+		},
+		"FailOtherNamespace": {
+			namespace: "default",
+			ref:       v1beta1.BucketEncryptionSecretRef{Name: "encryption", Namespace: "other"},
+			fail:      true,
+		},
+		"FailMissingKey": {
+			namespace: "default",
+			ref:       v1beta1.BucketEncryptionSecretRef{Name: "encryption", Key: "missing"},
+			fail:      true,
+		},
+		"FailNotFound": {
+			namespace: "default",
+			ref:       v1beta1.BucketEncryptionSecretRef{Name: "notFound"},
+			fail:      true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := NewGomegaWithT(t)
+
+			// When
+			key, err := findEncryptionKey(context.TODO(), testCase.namespace, testCase.ref)
+
+			// Then
+			if testCase.fail {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(key).To(Equal(testCase.expected))
+		})
+	}
+}
+
+func fixSecret(name, namespace string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: data,
+	}
+}
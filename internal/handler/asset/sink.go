@@ -0,0 +1,53 @@
+package asset
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/kyma-project/rafter/internal/loader"
+	"github.com/kyma-project/rafter/internal/store"
+)
+
+var _ loader.Sink = &objectSink{}
+
+// objectSink uploads streamed files to the Asset directory in the bucket. It keeps the first upload error,
+// so that it can be told apart from the errors of the source.
+type objectSink struct {
+	ctx                   context.Context
+	store                 store.Store
+	bucketName, assetName string
+	metadata              store.ObjectMetadata
+
+	mu  sync.Mutex
+	err error
+}
+
+func (s *objectSink) Put(name string, reader io.Reader, size int64) error {
+	return s.record(s.store.PutObject(s.ctx, s.bucketName, s.assetName, name, reader, size, s.metadata))
+}
+
+func (s *objectSink) Copy(source, name string) error {
+	return s.record(s.store.CopyObject(s.ctx, s.bucketName, s.assetName, source, name, s.metadata))
+}
+
+func (s *objectSink) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+func (s *objectSink) record(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+
+	return err
+}
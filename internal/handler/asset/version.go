@@ -0,0 +1,225 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go/pkg/encrypt"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// versionsDirectory is the directory of the asset with the published versions of its content
+const versionsDirectory = ".v"
+
+// versionPrefix returns the directory of the version in the bucket. Version 0 is the content published directly
+// in the asset directory, before the content was versioned.
+func versionPrefix(assetName string, version int64) string {
+	if version == 0 {
+		return assetName
+	}
+
+	return fmt.Sprintf("%s/%s/%d", assetName, versionsDirectory, version)
+}
+
+// nextVersion returns the version for the next processing run. Runs which failed don't publish their version,
+// so it is reused by the next run.
+func nextVersion(status v1beta1.CommonAssetStatus) int64 {
+	latest := status.AssetRef.Version
+	for _, version := range status.Versions {
+		if version.Version > latest {
+			latest = version.Version
+		}
+	}
+
+	return latest + 1
+}
+
+func rollbackVersion(spec v1beta1.CommonAssetSpec) int64 {
+	if spec.Versioning == nil {
+		return 0
+	}
+
+	return spec.Versioning.RollbackTo
+}
+
+func (h *assetHandler) keptVersions(spec v1beta1.CommonAssetSpec) int {
+	if spec.Versioning == nil || spec.Versioning.Keep == nil {
+		return h.keptVersionCount
+	}
+
+	return *spec.Versioning.Keep
+}
+
+// withPublishedContent keeps the published content in the status until another version replaces it, so consumers
+// are not affected by pending or failed processing runs. The reference is dropped if the content is gone.
+func (h *assetHandler) withPublishedContent(result *v1beta1.CommonAssetStatus, status v1beta1.CommonAssetStatus) *v1beta1.CommonAssetStatus {
+	if result == nil {
+		return nil
+	}
+	if result.Versions == nil {
+		result.Versions = status.Versions
+	}
+
+	switch {
+	case result.Phase == v1beta1.AssetReady:
+	case result.Reason == v1beta1.AssetBucketNotReady, result.Reason == v1beta1.AssetBucketError, result.Reason == v1beta1.AssetMissingContent:
+	default:
+		result.AssetRef = status.AssetRef
+	}
+
+	return result
+}
+
+// seedVersion copies the published content to the directory of the new version. Loaded content is synchronized with
+// the copies, so only changed files are uploaded, and streamed content replaces them.
+// Content which can't be copied, for example because the encryption of the bucket has changed, is uploaded instead.
+func (h *assetHandler) seedVersion(ctx context.Context, bucketName, assetName string, status v1beta1.CommonAssetStatus, prefix string, sse encrypt.ServerSide) {
+	if status.AssetRef.Version == 0 {
+		return
+	}
+
+	h.logInfof("Copying version %d of Asset content", status.AssetRef.Version)
+	err := h.store.CopyObjects(ctx, bucketName, store.AssetPrefix(versionPrefix(assetName, status.AssetRef.Version)), store.AssetPrefix(prefix), sse)
+	if err != nil {
+		h.logInfof("Copying version %d failed, the whole content is uploaded: %s", status.AssetRef.Version, err)
+	}
+}
+
+// rulesUnchanged returns true if the published version was processed with the current generation of the asset,
+// so its objects, which seed the next version, have the headers resolved from the current metadata rules
+func (h *assetHandler) rulesUnchanged(object MetaAccessor, status v1beta1.CommonAssetStatus) bool {
+	for _, version := range status.Versions {
+		if version.Version == status.AssetRef.Version {
+			return version.Generation == object.GetGeneration()
+		}
+	}
+
+	return false
+}
+
+// publish switches the asset to the uploaded version, and deletes the versions which are no longer kept
+func (h *assetHandler) publish(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus, bucketName string, assetRef v1beta1.AssetStatusRef) *v1beta1.CommonAssetStatus {
+	versions := append([]v1beta1.AssetVersion{}, status.Versions...)
+	versions = append(versions, v1beta1.AssetVersion{
+		Version:     assetRef.Version,
+		Generation:  object.GetGeneration(),
+		PublishedAt: v1.Now(),
+		Revision:    assetRef.Revision,
+		Digest:      assetRef.Digest,
+	})
+	h.logInfof("Version %d of Asset content published", assetRef.Version)
+
+	if status.AssetRef.Version == 0 && len(status.AssetRef.Files) > 0 {
+		h.deleteUnversionedContent(ctx, object, bucketName)
+	}
+
+	result := h.getReadyStatus(object, assetRef, v1beta1.AssetUploaded)
+	result.Versions = h.pruneVersions(ctx, object, bucketName, versions, assetRef.Version, h.keptVersions(spec))
+	return result
+}
+
+// pruneVersions deletes the oldest versions except for the current one and the kept ones, and returns the remaining
+// versions. Versions which couldn't be deleted stay in the list, so they are deleted with the next version.
+func (h *assetHandler) pruneVersions(ctx context.Context, object MetaAccessor, bucketName string, versions []v1beta1.AssetVersion, current int64, keep int) []v1beta1.AssetVersion {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+
+	result := make([]v1beta1.AssetVersion, 0, keep+1)
+	for _, version := range versions {
+		switch {
+		case version.Version == current:
+		case keep > 0:
+			keep--
+		default:
+			h.logInfof("Deleting version %d of Asset content", version.Version)
+			err := h.store.DeleteObjects(ctx, bucketName, store.AssetPrefix(versionPrefix(object.GetName(), version.Version)))
+			if err == nil {
+				continue
+			}
+			h.recordWarningEventf(object, v1beta1.AssetCleanupError, err.Error())
+		}
+		result = append(result, version)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result
+}
+
+// deleteUnversionedContent deletes the content published directly in the asset directory. Failures are only reported,
+// as the content is not referenced any more.
+func (h *assetHandler) deleteUnversionedContent(ctx context.Context, object MetaAccessor, bucketName string) {
+	h.logInfof("Deleting unversioned Asset content")
+	assetDirectory := store.AssetPrefix(object.GetName())
+	objects, err := h.store.ListObjects(ctx, bucketName, assetDirectory)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetCleanupError, err.Error())
+		return
+	}
+
+	var versioned []string
+	for _, key := range objects {
+		if name := strings.TrimPrefix(key, assetDirectory); strings.HasPrefix(name, versionsDirectory+"/") {
+			versioned = append(versioned, name)
+		}
+	}
+
+	if _, err := h.store.DeleteStaleObjects(ctx, bucketName, object.GetName(), versioned); err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetCleanupError, err.Error())
+		return
+	}
+	h.recordNormalEventf(object, v1beta1.AssetCleaned)
+}
+
+// onRollback switches the asset to the kept version. Its files are listed in the bucket, so metadata extracted
+// by webhooks is not restored. The missing version is not retried until the spec changes, as it can't come back.
+func (h *assetHandler) onRollback(ctx context.Context, object MetaAccessor, status v1beta1.CommonAssetStatus, bucketStatus *v1beta1.CommonBucketStatus, version int64) (*v1beta1.CommonAssetStatus, error) {
+	h.logInfof("Rolling back to version %d of Asset content", version)
+	var published *v1beta1.AssetVersion
+	for i := range status.Versions {
+		if status.Versions[i].Version == version {
+			published = &status.Versions[i]
+		}
+	}
+	if published == nil {
+		message := fmt.Sprintf("version %d is not kept", version)
+		h.recordWarningEventf(object, v1beta1.AssetRollbackFailed, message)
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetRollbackFailed, message), nil
+	}
+
+	prefix := versionPrefix(object.GetName(), version)
+	objects, err := h.store.ListObjects(ctx, bucketStatus.RemoteName, store.AssetPrefix(prefix))
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetRemoteContentVerificationError, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetRemoteContentVerificationError, err.Error()), err
+	}
+	if len(objects) == 0 {
+		message := fmt.Sprintf("content of version %d has been removed from the bucket", version)
+		h.recordWarningEventf(object, v1beta1.AssetRollbackFailed, message)
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetRollbackFailed, message), nil
+	}
+
+	filenames := make([]string, 0, len(objects))
+	for _, key := range objects {
+		filenames = append(filenames, strings.TrimPrefix(key, store.AssetPrefix(prefix)))
+	}
+	sort.Strings(filenames)
+
+	assetRef := v1beta1.AssetStatusRef{
+		BaseURL:  h.getBaseUrl(bucketStatus.URL, prefix),
+		Files:    h.populateFiles(filenames),
+		Revision: published.Revision,
+		Digest:   published.Digest,
+		Version:  version,
+	}
+	h.recordNormalEventf(object, v1beta1.AssetRolledBack, version)
+
+	return h.getReadyStatus(object, assetRef, v1beta1.AssetRolledBack, version), nil
+}
@@ -352,6 +352,15 @@ func (h *assetgroupHandler) convertToCommonAssetSpec(spec v1beta1.Source, bucket
 			Mode:                     h.convertToAssetMode(spec.Mode),
 			URL:                      spec.URL,
 			Filter:                   spec.Filter,
+			ArchiveFormat:            spec.ArchiveFormat,
+			StripComponents:          spec.StripComponents,
+			TargetPrefix:             spec.TargetPrefix,
+			Git:                      spec.Git,
+			SecretRef:                spec.SecretRef,
+			Checksum:                 spec.Checksum,
+			Signature:                spec.Signature,
+			SyncPolicy:               spec.SyncPolicy,
+			Download:                 spec.Download,
 			ValidationWebhookService: convertToAssetWebhookServices(cfg.Validations),
 			MutationWebhookService:   convertToAssetWebhookServices(cfg.Mutations),
 			MetadataWebhookService:   convertToWebhookService(cfg.MetadataExtractors),
@@ -425,6 +434,10 @@ func (h *assetgroupHandler) convertToAssetMode(mode v1beta1.AssetGroupSourceMode
 		return v1beta1.AssetIndex
 	case v1beta1.AssetGroupPackage:
 		return v1beta1.AssetPackage
+	case v1beta1.AssetGroupSecret:
+		return v1beta1.AssetSecret
+	case v1beta1.AssetGroupGit:
+		return v1beta1.AssetGit
 	default:
 		return v1beta1.AssetSingle
 	}
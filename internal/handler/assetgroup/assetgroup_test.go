@@ -129,6 +129,71 @@ func TestAssetGroupHandler_Handle_AddOrUpdate(t *testing.T) {
 		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupWaitingForAssets))
 	})
 
+	t.Run("CreateGit", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		source := testSource(sourceName, assetType, "https://dummy.url/repo.git", v1beta1.AssetGroupGit, nil)
+		source.Git = &v1beta1.AssetGitSource{Ref: "v1.0.0", Path: "docs"}
+		testData := testData("halo", "", []v1beta1.Source{source})
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		bucketSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/access": "public"}).Return([]string{"test-bucket"}, nil).Once()
+		assetSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/asset-group": testData.Name}).Return(nil, nil).Once()
+		assetSvc.On("Create", ctx, testData, mock.MatchedBy(func(asset assetgroup.CommonAsset) bool {
+			return asset.Spec.Source.Mode == v1beta1.AssetGit && *asset.Spec.Source.Git == *source.Git
+		})).Return(nil).Once()
+		webhookConfSvc.On("Get", ctx).Return(webhookconfig.AssetWebhookConfigMap{}, nil).Once()
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupPending))
+	})
+
+	t.Run("CreateSecret", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		source := testSource(sourceName, assetType, "certs", v1beta1.AssetGroupSecret, nil)
+		testData := testData("halo", "", []v1beta1.Source{source})
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		bucketSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/access": "public"}).Return([]string{"test-bucket"}, nil).Once()
+		assetSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/asset-group": testData.Name}).Return(nil, nil).Once()
+		assetSvc.On("Create", ctx, testData, mock.MatchedBy(func(asset assetgroup.CommonAsset) bool {
+			return asset.Spec.Source.Mode == v1beta1.AssetSecret && asset.Spec.Source.URL == "certs"
+		})).Return(nil).Once()
+		webhookConfSvc.On("Get", ctx).Return(webhookconfig.AssetWebhookConfigMap{}, nil).Once()
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupPending))
+	})
+
 	t.Run("CreateWithMetadata", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
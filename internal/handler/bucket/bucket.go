@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kyma-project/rafter/internal/store"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -32,21 +33,36 @@ type MetaAccessor interface {
 
 var _ Handler = &bucketHandler{}
 
+// RecordRetainedBucket saves the record of the remote bucket kept in the storage after the CR was deleted
+type RecordRetainedBucket func(ctx context.Context, spec v1beta1.RetainedBucketSpec) error
+
+// ForgetRetainedBucket removes the record of the retained remote bucket, if there is any
+type ForgetRetainedBucket func(ctx context.Context, remoteName string) error
+
+// FindRemoteNameOwner returns another Bucket or ClusterBucket bound to the remote bucket, if there is any
+type FindRemoteNameOwner func(ctx context.Context, namespace, name, remoteName string) (string, bool, error)
+
 type bucketHandler struct {
-	recorder         record.EventRecorder
-	store            store.Store
-	externalEndpoint string
-	log              logr.Logger
-	relistInterval   time.Duration
+	recorder             record.EventRecorder
+	store                store.Store
+	recordRetainedBucket RecordRetainedBucket
+	forgetRetainedBucket ForgetRetainedBucket
+	findRemoteNameOwner  FindRemoteNameOwner
+	externalEndpoint     string
+	log                  logr.Logger
+	relistInterval       time.Duration
 }
 
-func New(log logr.Logger, recorder record.EventRecorder, store store.Store, externalEndpoint string, relistInterval time.Duration) Handler {
+func New(log logr.Logger, recorder record.EventRecorder, store store.Store, recordRetainedFnc RecordRetainedBucket, forgetRetainedFnc ForgetRetainedBucket, findRemoteNameOwnerFnc FindRemoteNameOwner, externalEndpoint string, relistInterval time.Duration) Handler {
 	return &bucketHandler{
-		recorder:         recorder,
-		store:            store,
-		externalEndpoint: externalEndpoint,
-		log:              log,
-		relistInterval:   relistInterval,
+		recorder:             recorder,
+		store:                store,
+		recordRetainedBucket: recordRetainedFnc,
+		forgetRetainedBucket: forgetRetainedFnc,
+		findRemoteNameOwner:  findRemoteNameOwnerFnc,
+		externalEndpoint:     externalEndpoint,
+		log:                  log,
+		relistInterval:       relistInterval,
 	}
 }
 
@@ -56,13 +72,16 @@ func (h *bucketHandler) Do(ctx context.Context, now time.Time, instance MetaAcce
 
 	switch {
 	case h.isOnDelete(instance):
-		return h.onDelete(ctx, instance, status)
+		return h.onDelete(ctx, instance, spec, status)
 	case h.isOnAddOrUpdate(instance, status):
-		return h.onAddOrUpdate(instance, spec, status)
+		result, err := h.onAddOrUpdate(ctx, instance, spec, status)
+		return h.withEncryption(result, spec, status), err
 	case h.isOnReady(status, now):
-		return h.onReady(instance, spec, status)
+		result, err := h.onReady(instance, spec, status)
+		return h.withEncryption(result, spec, status), err
 	case h.isOnFailed(status):
-		return h.onFailed(instance, spec, status)
+		result, err := h.onFailed(ctx, instance, spec, status)
+		return h.withEncryption(result, spec, status), err
 	default:
 		h.logInfof("Action not taken")
 		return nil, nil
@@ -85,16 +104,26 @@ func (*bucketHandler) isOnDelete(object MetaAccessor) bool {
 	return !object.GetDeletionTimestamp().IsZero()
 }
 
-func (h *bucketHandler) onFailed(object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
+func (h *bucketHandler) onFailed(ctx context.Context, object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
 	switch status.Reason {
 	case v1beta1.BucketNotFound:
-		return h.onAddOrUpdate(object, spec, status)
+		return h.onAddOrUpdate(ctx, object, spec, status)
 	case v1beta1.BucketCreationFailure:
-		return h.onAddOrUpdate(object, spec, status)
+		return h.onAddOrUpdate(ctx, object, spec, status)
+	case v1beta1.BucketRemoteNameInUse:
+		return h.onAddOrUpdate(ctx, object, spec, status)
 	case v1beta1.BucketVerificationFailure:
 		return h.onReady(object, spec, status)
 	case v1beta1.BucketPolicyUpdateFailed:
 		return h.onReady(object, spec, status)
+	case v1beta1.BucketLifecycleUpdateFailed:
+		return h.onReady(object, spec, status)
+	case v1beta1.BucketLifecycleVerificationFailed:
+		return h.onReady(object, spec, status)
+	case v1beta1.BucketEncryptionUpdateFailed:
+		return h.onReady(object, spec, status)
+	case v1beta1.BucketEncryptionVerificationFailed:
+		return h.onReady(object, spec, status)
 	}
 
 	return nil, nil
@@ -114,64 +143,158 @@ func (h *bucketHandler) onReady(object MetaAccessor, spec v1beta1.CommonBucketSp
 	h.logInfof("Bucket exists")
 
 	h.logInfof("Comparing bucket policy")
-	equal, err := h.store.CompareBucketPolicy(status.RemoteName, spec.Policy)
+	equal, err := h.store.CompareBucketPolicy(status.RemoteName, spec.Policy, spec.AccessPolicy)
 	if err != nil {
 		h.recordWarningEventf(object, v1beta1.BucketPolicyVerificationFailed, err.Error())
 		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketPolicyVerificationFailed, status.RemoteName), err
 	}
-	if equal {
-		h.logInfof("Bucket is up-to-date")
-		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketReady, v1beta1.BucketPolicyUpdated), nil
+	if !equal {
+		h.logInfof("Updating bucket policy")
+		h.recordWarningEventf(object, v1beta1.BucketPolicyHasBeenChanged)
+		if err := h.store.SetBucketPolicy(status.RemoteName, spec.Policy, spec.AccessPolicy); err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketPolicyUpdateFailed, err.Error())
+			return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketPolicyUpdateFailed, err.Error()), err
+		}
+		h.recordNormalEventf(object, v1beta1.BucketPolicyUpdated)
+		h.logInfof("Bucket policy updated")
 	}
 
-	h.logInfof("Updating bucket policy")
-	h.recordWarningEventf(object, v1beta1.BucketPolicyHasBeenChanged)
-	if err := h.store.SetBucketPolicy(status.RemoteName, spec.Policy); err != nil {
-		h.recordWarningEventf(object, v1beta1.BucketPolicyUpdateFailed, err.Error())
-		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketPolicyUpdateFailed, err.Error()), err
+	h.logInfof("Comparing bucket lifecycle")
+	equal, err = h.store.CompareBucketLifecycle(status.RemoteName, spec.Lifecycle)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketLifecycleVerificationFailed, err.Error())
+		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketLifecycleVerificationFailed, err.Error()), err
+	}
+	if !equal {
+		h.logInfof("Updating bucket lifecycle")
+		h.recordWarningEventf(object, v1beta1.BucketLifecycleHasBeenChanged)
+		if err := h.store.SetBucketLifecycle(status.RemoteName, spec.Lifecycle); err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketLifecycleUpdateFailed, err.Error())
+			return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketLifecycleUpdateFailed, err.Error()), err
+		}
+		h.recordNormalEventf(object, v1beta1.BucketLifecycleUpdated)
+		h.logInfof("Bucket lifecycle updated")
+	}
+
+	if spec.Encryption != nil || status.Encryption != nil {
+		h.logInfof("Comparing bucket encryption")
+		equal, err = h.store.CompareBucketEncryption(status.RemoteName, spec.Encryption)
+		if err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketEncryptionVerificationFailed, err.Error())
+			return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketEncryptionVerificationFailed, err.Error()), err
+		}
+		if !equal {
+			h.logInfof("Updating bucket encryption")
+			h.recordWarningEventf(object, v1beta1.BucketEncryptionHasBeenChanged)
+			if err := h.store.SetBucketEncryption(status.RemoteName, spec.Encryption); err != nil {
+				h.recordWarningEventf(object, v1beta1.BucketEncryptionUpdateFailed, err.Error())
+				return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketEncryptionUpdateFailed, err.Error()), err
+			}
+			h.recordNormalEventf(object, v1beta1.BucketEncryptionUpdated)
+			h.logInfof("Bucket encryption updated")
+		}
 	}
-	h.recordNormalEventf(object, v1beta1.BucketPolicyUpdated)
-	h.logInfof("Bucket policy updated")
 
+	h.logInfof("Bucket is up-to-date")
 	return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketReady, v1beta1.BucketPolicyUpdated), nil
 }
 
-func (h *bucketHandler) onAddOrUpdate(object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
+func (h *bucketHandler) onAddOrUpdate(ctx context.Context, object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
+	if err := h.validateEncryption(object, spec, status); err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketInvalidEncryption, err.Error())
+		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketInvalidEncryption, err.Error()), nil
+	}
+	if err := store.ValidateBucketPolicy(spec.Policy, spec.AccessPolicy); err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketInvalidPolicy, err.Error())
+		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketInvalidPolicy, err.Error()), nil
+	}
+	if _, err := store.BucketLifecycle(spec.Lifecycle); err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketInvalidLifecycle, err.Error())
+		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketInvalidLifecycle, err.Error()), nil
+	}
+	if err := h.validateRemoteName(spec, status); err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketInvalidRemoteName, err.Error())
+		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketInvalidRemoteName, err.Error()), nil
+	}
+
 	h.logInfof("Checking if bucket was previously created")
 	if status.RemoteName != "" {
 		h.logInfof("Bucket was created")
 		return h.onReady(object, spec, status)
 	}
 
-	h.logInfof("Creating bucket")
-	remoteName, err := h.store.CreateBucket(object.GetNamespace(), object.GetName(), string(spec.Region))
-	if err != nil {
-		h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
-		return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+	remoteName := spec.RemoteName
+	if remoteName != "" {
+		h.logInfof("Adopting bucket %s", remoteName)
+		if result, err := h.adoptBucket(ctx, object, spec); result != nil {
+			return result, err
+		}
+	} else {
+		h.logInfof("Creating bucket")
+		name, err := h.store.CreateBucket(object.GetNamespace(), object.GetName(), string(spec.Region))
+		if err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
+			return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+		}
+		remoteName = name
+		h.recordNormalEventf(object, v1beta1.BucketCreated)
+		h.logInfof("Bucket created")
 	}
-	h.recordNormalEventf(object, v1beta1.BucketCreated)
-	h.logInfof("Bucket created")
 
 	externalUrl := h.getBucketUrl(remoteName)
 
 	h.logInfof("Updating bucket policy")
-	if err := h.store.SetBucketPolicy(remoteName, spec.Policy); err != nil {
+	if err := h.store.SetBucketPolicy(remoteName, spec.Policy, spec.AccessPolicy); err != nil {
 		h.recordWarningEventf(object, v1beta1.BucketPolicyUpdateFailed, err.Error())
 		return h.getStatus(object, remoteName, externalUrl, v1beta1.BucketFailed, v1beta1.BucketPolicyUpdateFailed, err.Error()), err
 	}
 	h.recordNormalEventf(object, v1beta1.BucketPolicyUpdated)
 	h.logInfof("Bucket policy updated")
 
+	if len(spec.Lifecycle) > 0 {
+		h.logInfof("Updating bucket lifecycle")
+		if err := h.store.SetBucketLifecycle(remoteName, spec.Lifecycle); err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketLifecycleUpdateFailed, err.Error())
+			return h.getStatus(object, remoteName, externalUrl, v1beta1.BucketFailed, v1beta1.BucketLifecycleUpdateFailed, err.Error()), err
+		}
+		h.recordNormalEventf(object, v1beta1.BucketLifecycleUpdated)
+		h.logInfof("Bucket lifecycle updated")
+	}
+
+	if spec.Encryption != nil {
+		h.logInfof("Updating bucket encryption")
+		if err := h.store.SetBucketEncryption(remoteName, spec.Encryption); err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketEncryptionUpdateFailed, err.Error())
+			return h.getStatus(object, remoteName, externalUrl, v1beta1.BucketFailed, v1beta1.BucketEncryptionUpdateFailed, err.Error()), err
+		}
+		h.recordNormalEventf(object, v1beta1.BucketEncryptionUpdated)
+		h.logInfof("Bucket encryption updated")
+	}
+
 	return h.getStatus(object, remoteName, externalUrl, v1beta1.BucketReady, v1beta1.BucketPolicyUpdated), nil
 }
 
-func (h *bucketHandler) onDelete(ctx context.Context, object MetaAccessor, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
+func (h *bucketHandler) onDelete(ctx context.Context, object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
 	h.logInfof("Deleting Bucket")
 	if status.RemoteName == "" || status.Reason == v1beta1.BucketNotFound {
 		h.logInfof("Nothing to delete, there is no remote bucket")
 		return nil, nil
 	}
 
+	switch h.reclaimPolicy(spec) {
+	case v1beta1.BucketReclaimOrphan:
+		h.recordNormalEventf(object, v1beta1.BucketOrphaned, status.RemoteName)
+		h.logInfof("Remote bucket %s orphaned", status.RemoteName)
+		return nil, nil
+	case v1beta1.BucketReclaimRetain:
+		if err := h.recordRetainedBucket(ctx, h.retainedBucketSpec(object, spec, status)); err != nil {
+			return nil, errors.Wrapf(err, "while recording retained bucket %s", status.RemoteName)
+		}
+		h.recordNormalEventf(object, v1beta1.BucketRetained, status.RemoteName)
+		h.logInfof("Remote bucket %s retained", status.RemoteName)
+		return nil, nil
+	}
+
 	if err := h.store.DeleteBucket(ctx, status.RemoteName); err != nil {
 		return nil, errors.Wrap(err, "while deleting remote bucket")
 	}
@@ -180,6 +303,160 @@ func (h *bucketHandler) onDelete(ctx context.Context, object MetaAccessor, statu
 	return nil, nil
 }
 
+// adoptBucket binds to the existing bucket with the name given in the spec, or creates it if the adoption policy
+// allows it. Buckets bound to other CRs are not adopted, as the CRs would overwrite the settings of each other.
+// It returns the status only if the bucket can't be used.
+func (h *bucketHandler) adoptBucket(ctx context.Context, object MetaAccessor, spec v1beta1.CommonBucketSpec) (*v1beta1.CommonBucketStatus, error) {
+	owner, inUse, err := h.findRemoteNameOwner(ctx, object.GetNamespace(), object.GetName(), spec.RemoteName)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
+		return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+	}
+	if inUse {
+		h.recordWarningEventf(object, v1beta1.BucketRemoteNameInUse, spec.RemoteName, owner)
+		return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketRemoteNameInUse, spec.RemoteName, owner), nil
+	}
+
+	exists, err := h.store.BucketExists(spec.RemoteName)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
+		return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+	}
+
+	switch {
+	case exists:
+		h.recordNormalEventf(object, v1beta1.BucketAdopted, spec.RemoteName)
+		h.logInfof("Bucket %s adopted", spec.RemoteName)
+	case spec.AdoptionPolicy == v1beta1.BucketAdoptionCreateIfMissing:
+		if err := h.store.CreateNamedBucket(spec.RemoteName, string(spec.Region)); err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
+			return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+		}
+		h.recordNormalEventf(object, v1beta1.BucketCreated)
+		h.logInfof("Bucket created")
+	default:
+		h.recordWarningEventf(object, v1beta1.BucketNotFound, spec.RemoteName)
+		return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketNotFound, spec.RemoteName), errors.Errorf(v1beta1.BucketNotFound.String(), spec.RemoteName)
+	}
+
+	// The record is only informative, so the bucket is used even if it can't be removed
+	if err := h.forgetRetainedBucket(ctx, spec.RemoteName); err != nil {
+		h.logInfof("Record of retained bucket %s couldn't be removed: %s", spec.RemoteName, err.Error())
+	}
+
+	return nil, nil
+}
+
+// validateRemoteName makes sure that the bound bucket is not replaced with another one, as its content would be lost
+func (*bucketHandler) validateRemoteName(spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) error {
+	if spec.RemoteName == "" {
+		return nil
+	}
+	if err := store.ValidateBucketName(spec.RemoteName); err != nil {
+		return err
+	}
+	if status.RemoteName != "" && status.RemoteName != spec.RemoteName {
+		return fmt.Errorf("remoteName can't be changed from %s to %s", status.RemoteName, spec.RemoteName)
+	}
+
+	return nil
+}
+
+// reclaimPolicy returns the reclaim policy of the bucket. Adopted buckets are retained by default, as their content
+// was not created by the controller.
+func (*bucketHandler) reclaimPolicy(spec v1beta1.CommonBucketSpec) v1beta1.BucketReclaimPolicy {
+	if spec.ReclaimPolicy != "" {
+		return spec.ReclaimPolicy
+	}
+	if spec.RemoteName != "" {
+		return v1beta1.BucketReclaimRetain
+	}
+
+	return v1beta1.BucketReclaimDelete
+}
+
+// retainedBucketSpec describes the retained bucket, together with the encryption needed to read its objects
+// and the deleted CR, which is cluster-wide if it has no namespace
+func (*bucketHandler) retainedBucketSpec(object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) v1beta1.RetainedBucketSpec {
+	kind := "Bucket"
+	if object.GetNamespace() == "" {
+		kind = "ClusterBucket"
+	}
+
+	return v1beta1.RetainedBucketSpec{
+		RemoteName: status.RemoteName,
+		Region:     spec.Region,
+		Encryption: status.Encryption,
+		Source: v1beta1.RetainedBucketSource{
+			Kind:      kind,
+			Namespace: object.GetNamespace(),
+			Name:      object.GetName(),
+		},
+		RetainedAt: v1.Now(),
+	}
+}
+
+// validateEncryption makes sure that the SSE-C key can be found, as it is read with every upload, and that the objects
+// encrypted with the previous encryption stay readable. The SSE-C objects can't be read without the key, so they can't
+// be shared with the policy.
+func (h *bucketHandler) validateEncryption(object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) error {
+	if status.Encryption != nil && !equality.Semantic.DeepEqual(status.Encryption, spec.Encryption) {
+		return errors.New("encryption can't be changed once it is applied to the bucket")
+	}
+
+	encryption := spec.Encryption
+	if encryption == nil || encryption.Type != v1beta1.BucketEncryptionSSEC {
+		return nil
+	}
+
+	switch {
+	case encryption.SecretRef == nil:
+		return errors.New("secretRef is required for SSE-C encryption")
+	case object.GetNamespace() == "" && encryption.SecretRef.Namespace == "":
+		return errors.New("namespace of the Secret with SSE-C key is required for cluster-wide buckets")
+	case h.isGrantingPolicy(spec.Policy, spec.AccessPolicy):
+		return errors.New("SSE-C encryption can't be combined with the policy granting access to the objects")
+	}
+
+	return nil
+}
+
+// isGrantingPolicy checks if the policy grants any access to the bucket. The raw policy documents are treated
+// as granting, as they are not interpreted by the controller.
+func (*bucketHandler) isGrantingPolicy(bucketPolicy v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) bool {
+	if bucketPolicy != "" && bucketPolicy != v1beta1.BucketPolicyNone {
+		return true
+	}
+	if accessPolicy == nil {
+		return false
+	}
+	if accessPolicy.Raw != "" || len(accessPolicy.Rules) > 0 {
+		return true
+	}
+	for _, statement := range accessPolicy.Statements {
+		if statement.Effect == v1beta1.BucketPolicyEffectAllow {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withEncryption publishes the encryption of the bucket, which is applied to the objects by the asset controllers.
+// The encryption is published once the bucket is ready, so it is applied to the bucket, and the previous one is kept
+// until then.
+func (*bucketHandler) withEncryption(result *v1beta1.CommonBucketStatus, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) *v1beta1.CommonBucketStatus {
+	if result == nil {
+		return nil
+	}
+
+	result.Encryption = status.Encryption
+	if result.Phase == v1beta1.BucketReady {
+		result.Encryption = spec.Encryption
+	}
+	return result
+}
+
 func (h *bucketHandler) getBucketUrl(name string) string {
 	return fmt.Sprintf("%s/%s", h.externalEndpoint, name)
 }
@@ -31,7 +31,7 @@ func TestBucketHandler_Handle_Default(t *testing.T) {
 	store := new(automock.Store)
 	defer store.AssertExpectations(t)
 
-	handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+	handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 	// When
 	status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -58,10 +58,11 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 		defer store.AssertExpectations(t)
 
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
-		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(false, nil).Once()
-		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(false, nil).Once()
+		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -89,9 +90,9 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 		defer store.AssertExpectations(t)
 
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
-		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, url, relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -121,7 +122,7 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return("", errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, url, relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -149,9 +150,9 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 		defer store.AssertExpectations(t)
 
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
-		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(errors.New("nope")).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, url, relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -164,10 +165,823 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 		g.Expect(status.RemoteName).To(Equal(remoteName))
 		g.Expect(status.URL).To(Equal(fmt.Sprintf("%s/%s", url, remoteName)))
 	})
+
+	t.Run("WithEncryption", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
+		remoteName := fmt.Sprintf("%s-123", data.Name)
+		url := "http://localhost"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+		store.On("SetBucketEncryption", remoteName, data.Spec.Encryption).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, url, relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Encryption).To(Equal(data.Spec.Encryption))
+	})
+
+	t.Run("CustomerKeyWithDenyingStatements", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyNone)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSEC, SecretRef: &v1beta1.BucketEncryptionSecretRef{Name: "key"}}
+		data.Spec.AccessPolicy = &v1beta1.BucketAccessPolicy{Statements: []v1beta1.BucketPolicyStatement{{
+			Effect:    v1beta1.BucketPolicyEffectDeny,
+			Actions:   []string{"s3:GetObject"},
+			Resources: []string{"*"},
+		}}}
+		remoteName := fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+		store.On("SetBucketEncryption", remoteName, data.Spec.Encryption).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Encryption).To(Equal(data.Spec.Encryption))
+	})
+
+	t.Run("BucketEncryptionUpdateFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
+		remoteName := fmt.Sprintf("%s-123", data.Name)
+		url := "http://localhost"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+		store.On("SetBucketEncryption", remoteName, data.Spec.Encryption).Return(errors.New("nope")).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, url, relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketEncryptionUpdateFailed))
+		g.Expect(status.RemoteName).To(Equal(remoteName))
+		g.Expect(status.Encryption).To(BeNil())
+	})
+
+	t.Run("InvalidEncryption", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSEC}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketInvalidEncryption))
+	})
+
+	t.Run("CustomerKeyWithGrantingPolicy", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSEC, SecretRef: &v1beta1.BucketEncryptionSecretRef{Name: "key"}}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketInvalidEncryption))
+	})
+
+	t.Run("CustomerKeyWithGrantingAccessPolicy", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyNone)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSEC, SecretRef: &v1beta1.BucketEncryptionSecretRef{Name: "key"}}
+		data.Spec.AccessPolicy = &v1beta1.BucketAccessPolicy{Rules: []v1beta1.BucketPolicyRule{{Prefix: "public/", Policy: v1beta1.BucketPolicyReadOnly}}}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketInvalidEncryption))
+	})
+
+	t.Run("EncryptionChanged", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Status.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketInvalidEncryption))
+		g.Expect(status.RemoteName).To(Equal(data.Status.RemoteName))
+		g.Expect(status.Encryption).To(Equal(data.Status.Encryption))
+	})
+
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.AccessPolicy = &v1beta1.BucketAccessPolicy{Raw: `{"Statement":[]}`}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketInvalidPolicy))
+	})
+
+	t.Run("WithLifecycle", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		expirationDays := int64(7)
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Lifecycle = []v1beta1.BucketLifecycleRule{{Prefix: "tmp/", ExpirationDays: &expirationDays}}
+		remoteName := fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+		store.On("SetBucketLifecycle", remoteName, data.Spec.Lifecycle).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdated))
+	})
+
+	t.Run("BucketLifecycleUpdateFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		expirationDays := int64(7)
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Lifecycle = []v1beta1.BucketLifecycleRule{{ExpirationDays: &expirationDays}}
+		remoteName := fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+		store.On("SetBucketLifecycle", remoteName, data.Spec.Lifecycle).Return(errors.New("nope")).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketLifecycleUpdateFailed))
+		g.Expect(status.RemoteName).To(Equal(remoteName))
+	})
+
+	t.Run("InvalidLifecycle", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Lifecycle = []v1beta1.BucketLifecycleRule{{ID: "empty"}}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketInvalidLifecycle))
+	})
+
+	t.Run("AdoptExisting", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		url := "http://localhost"
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "restored-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Spec.RemoteName).Return(true, nil).Once()
+		store.On("SetBucketPolicy", data.Spec.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+
+		retained := newRetainedBuckets(v1beta1.RetainedBucketSpec{RemoteName: data.Spec.RemoteName})
+		handler := bucket.New(log, fakeRecorder(), store, retained.record, retained.forget, noOwner, url, relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.RemoteName).To(Equal(data.Spec.RemoteName))
+		g.Expect(status.URL).To(Equal(fmt.Sprintf("%s/%s", url, data.Spec.RemoteName)))
+		g.Expect(retained.records).To(BeEmpty())
+	})
+
+	t.Run("AdoptExistingWithEncryption", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "restored-bucket"
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Spec.RemoteName).Return(true, nil).Once()
+		store.On("SetBucketPolicy", data.Spec.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+		store.On("SetBucketEncryption", data.Spec.RemoteName, data.Spec.Encryption).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.RemoteName).To(Equal(data.Spec.RemoteName))
+		g.Expect(status.Encryption).To(Equal(data.Spec.Encryption))
+	})
+
+	t.Run("AdoptForgetError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "restored-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Spec.RemoteName).Return(true, nil).Once()
+		store.On("SetBucketPolicy", data.Spec.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+
+		retained := newRetainedBuckets()
+		retained.err = errors.New("Nope")
+		handler := bucket.New(log, fakeRecorder(), store, retained.record, retained.forget, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+	})
+
+	t.Run("AdoptMissing", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "restored-bucket"
+		data.Spec.AdoptionPolicy = v1beta1.BucketAdoptionExisting
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Spec.RemoteName).Return(false, nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketNotFound))
+		g.Expect(status.RemoteName).To(BeEmpty())
+	})
+
+	t.Run("AdoptCreateIfMissing", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "restored-bucket"
+		data.Spec.AdoptionPolicy = v1beta1.BucketAdoptionCreateIfMissing
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Spec.RemoteName).Return(false, nil).Once()
+		store.On("CreateNamedBucket", data.Spec.RemoteName, string(data.Spec.Region)).Return(nil).Once()
+		store.On("SetBucketPolicy", data.Spec.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.RemoteName).To(Equal(data.Spec.RemoteName))
+	})
+
+	t.Run("AdoptionError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "restored-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Spec.RemoteName).Return(false, errors.New("nope")).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketCreationFailure))
+	})
+
+	t.Run("AdoptInUse", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "restored-bucket"
+		data.Spec.AdoptionPolicy = v1beta1.BucketAdoptionCreateIfMissing
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		findOwner := func(ctx context.Context, namespace, name, remoteName string) (string, bool, error) {
+			g.Expect(namespace).To(Equal(data.Namespace))
+			g.Expect(name).To(Equal(data.Name))
+			g.Expect(remoteName).To(Equal(data.Spec.RemoteName))
+			return "ClusterBucket other-bucket", true, nil
+		}
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, findOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketRemoteNameInUse))
+		g.Expect(status.Message).To(ContainSubstring("ClusterBucket other-bucket"))
+		g.Expect(status.RemoteName).To(BeEmpty())
+	})
+
+	t.Run("AdoptInUseError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "restored-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		findOwner := func(context.Context, string, string, string) (string, bool, error) {
+			return "", false, errors.New("nope")
+		}
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, findOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketCreationFailure))
+	})
+
+	t.Run("InvalidRemoteName", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "Restored_Bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketInvalidRemoteName))
+	})
+
+	t.Run("RemoteNameChanged", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.RemoteName = "restored-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketInvalidRemoteName))
+		g.Expect(status.RemoteName).To(Equal(data.Status.RemoteName))
+	})
 }
 
-func TestBucketHandler_Handle_OnReady(t *testing.T) {
-	t.Run("NotTaken", func(t *testing.T) {
+func TestBucketHandler_Handle_OnReady(t *testing.T) {
+	t.Run("NotTaken", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.LastHeartbeatTime = v1.Now()
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("NotChanged", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdated))
+	})
+
+	t.Run("MissingBucket", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Status.RemoteName).Return(false, nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketNotFound))
+	})
+
+	t.Run("BucketVerificationFailure", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Status.RemoteName).Return(false, errors.New("nope")).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketVerificationFailure))
+	})
+
+	t.Run("PolicyModified", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(false, nil).Once()
+		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdated))
+	})
+
+	t.Run("PolicyModificationError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(false, nil).Once()
+		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(errors.New("nope")).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdateFailed))
+	})
+
+	t.Run("PolicyCompareError", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
@@ -177,41 +991,51 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		data.ObjectMeta.Generation = int64(1)
 		data.Status.ObservedGeneration = int64(1)
 		data.Status.Phase = v1beta1.BucketReady
-		data.Status.LastHeartbeatTime = v1.Now()
+		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
 
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(false, errors.New("nope")).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
 
 		// Then
-		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).To(BeZero())
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyVerificationFailed))
 	})
 
-	t.Run("NotChanged", func(t *testing.T) {
+	t.Run("LifecycleModified", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
+		expirationDays := int64(7)
 		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
 		data.ObjectMeta.Generation = int64(1)
 		data.Status.ObservedGeneration = int64(1)
 		data.Status.Phase = v1beta1.BucketReady
 		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
 		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.Lifecycle = []v1beta1.BucketLifecycleRule{{ExpirationDays: &expirationDays}}
 
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
-		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(false, nil).Once()
+		store.On("SetBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -223,7 +1047,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdated))
 	})
 
-	t.Run("MissingBucket", func(t *testing.T) {
+	t.Run("LifecycleCompareError", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
@@ -239,9 +1063,11 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
-		store.On("BucketExists", data.Status.RemoteName).Return(false, nil).Once()
+		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(false, errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -250,10 +1076,10 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.BucketNotFound))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketLifecycleVerificationFailed))
 	})
 
-	t.Run("BucketVerificationFailure", func(t *testing.T) {
+	t.Run("EncryptionModified", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
@@ -265,25 +1091,32 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		data.Status.Phase = v1beta1.BucketReady
 		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
 		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
+		data.Status.Encryption = data.Spec.Encryption
 
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
-		store.On("BucketExists", data.Status.RemoteName).Return(false, errors.New("nope")).Once()
+		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
+		store.On("CompareBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(false, nil).Once()
+		store.On("SetBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
+		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.BucketVerificationFailure))
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdated))
+		g.Expect(status.Encryption).To(Equal(data.Spec.Encryption))
 	})
 
-	t.Run("PolicyModified", func(t *testing.T) {
+	t.Run("EncryptionRemoved", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
@@ -295,15 +1128,18 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		data.Status.Phase = v1beta1.BucketReady
 		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
 		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Status.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
 
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
-		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(false, nil).Once()
-		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
+		store.On("CompareBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(false, nil).Once()
+		store.On("SetBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -313,9 +1149,10 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
 		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdated))
+		g.Expect(status.Encryption).To(Equal(data.Spec.Encryption))
 	})
 
-	t.Run("PolicyModificationError", func(t *testing.T) {
+	t.Run("EncryptionModificationError", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
@@ -327,15 +1164,18 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		data.Status.Phase = v1beta1.BucketReady
 		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
 		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
 
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
-		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(false, nil).Once()
-		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(errors.New("nope")).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
+		store.On("CompareBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(false, nil).Once()
+		store.On("SetBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -344,10 +1184,11 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdateFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketEncryptionUpdateFailed))
+		g.Expect(status.Encryption).To(BeNil())
 	})
 
-	t.Run("PolicyCompareError", func(t *testing.T) {
+	t.Run("EncryptionCompareError", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
@@ -359,14 +1200,17 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		data.Status.Phase = v1beta1.BucketReady
 		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
 		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
 
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
-		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(false, errors.New("nope")).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
+		store.On("CompareBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(false, errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -375,7 +1219,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyVerificationFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketEncryptionVerificationFailed))
 	})
 }
 
@@ -398,9 +1242,9 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		defer store.AssertExpectations(t)
 
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
-		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, url, relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -414,6 +1258,37 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		g.Expect(status.URL).To(Equal(fmt.Sprintf("%s/%s", url, remoteName)))
 	})
 
+	t.Run("BucketRemoteNameInUse", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketFailed
+		data.Status.Reason = v1beta1.BucketRemoteNameInUse
+		data.Spec.RemoteName = "restored-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Spec.RemoteName).Return(true, nil).Once()
+		store.On("SetBucketPolicy", data.Spec.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "http://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.RemoteName).To(Equal(data.Spec.RemoteName))
+	})
+
 	t.Run("BucketVerificationFailure", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
@@ -431,9 +1306,10 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		defer store.AssertExpectations(t)
 
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
-		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -462,9 +1338,45 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		defer store.AssertExpectations(t)
 
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
-		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdated))
+	})
+
+	t.Run("BucketEncryptionUpdateFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketFailed
+		data.Status.Reason = v1beta1.BucketEncryptionUpdateFailed
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
+		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(true, nil).Once()
+		store.On("CompareBucketLifecycle", data.Status.RemoteName, data.Spec.Lifecycle).Return(true, nil).Once()
+		store.On("CompareBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(false, nil).Once()
+		store.On("SetBucketEncryption", data.Status.RemoteName, data.Spec.Encryption).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -474,6 +1386,7 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
 		g.Expect(status.Reason).To(Equal(v1beta1.BucketPolicyUpdated))
+		g.Expect(status.Encryption).To(Equal(data.Spec.Encryption))
 	})
 
 	t.Run("BucketNotFound", func(t *testing.T) {
@@ -494,9 +1407,9 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		defer store.AssertExpectations(t)
 
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
-		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy, data.Spec.AccessPolicy).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, url, relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -528,7 +1441,178 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 
 		store.On("DeleteBucket", ctx, data.Status.RemoteName).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("Retain", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		deletionTimestamp := v1.Now()
+		data.ObjectMeta.DeletionTimestamp = &deletionTimestamp
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Status.Encryption = &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
+		data.Spec.ReclaimPolicy = v1beta1.BucketReclaimRetain
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		retained := newRetainedBuckets()
+		handler := bucket.New(log, fakeRecorder(), store, retained.record, retained.forget, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+		g.Expect(retained.records).To(HaveKey(data.Status.RemoteName))
+		retainedBucket := retained.records[data.Status.RemoteName]
+		g.Expect(retainedBucket.Region).To(Equal(data.Spec.Region))
+		g.Expect(retainedBucket.Encryption).To(Equal(data.Status.Encryption))
+		g.Expect(retainedBucket.Source).To(Equal(v1beta1.RetainedBucketSource{Kind: "Bucket", Namespace: data.Namespace, Name: data.Name}))
+		g.Expect(retainedBucket.RetainedAt.IsZero()).To(BeFalse())
+	})
+
+	t.Run("RetainClusterBucket", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.Namespace = ""
+		deletionTimestamp := v1.Now()
+		data.ObjectMeta.DeletionTimestamp = &deletionTimestamp
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.ReclaimPolicy = v1beta1.BucketReclaimRetain
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		retained := newRetainedBuckets()
+		handler := bucket.New(log, fakeRecorder(), store, retained.record, retained.forget, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+		g.Expect(retained.records).To(HaveKey(data.Status.RemoteName))
+		g.Expect(retained.records[data.Status.RemoteName].Source).To(Equal(v1beta1.RetainedBucketSource{Kind: "ClusterBucket", Name: data.Name}))
+	})
+
+	t.Run("RetainRecordError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		deletionTimestamp := v1.Now()
+		data.ObjectMeta.DeletionTimestamp = &deletionTimestamp
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.ReclaimPolicy = v1beta1.BucketReclaimRetain
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		retained := newRetainedBuckets()
+		retained.err = errors.New("Nope")
+		handler := bucket.New(log, fakeRecorder(), store, retained.record, retained.forget, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("Orphan", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		deletionTimestamp := v1.Now()
+		data.ObjectMeta.DeletionTimestamp = &deletionTimestamp
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.ReclaimPolicy = v1beta1.BucketReclaimOrphan
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		retained := newRetainedBuckets()
+		handler := bucket.New(log, fakeRecorder(), store, retained.record, retained.forget, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+		g.Expect(retained.records).To(BeEmpty())
+	})
+
+	t.Run("AdoptedRetainedByDefault", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		deletionTimestamp := v1.Now()
+		data.ObjectMeta.DeletionTimestamp = &deletionTimestamp
+		data.Spec.RemoteName = "restored-bucket"
+		data.Status.RemoteName = data.Spec.RemoteName
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		retained := newRetainedBuckets()
+		handler := bucket.New(log, fakeRecorder(), store, retained.record, retained.forget, noOwner, "https://localhost", relistInterval)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+		g.Expect(retained.records).To(HaveKey(data.Status.RemoteName))
+	})
+
+	t.Run("AdoptedWithDeletePolicy", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		deletionTimestamp := v1.Now()
+		data.ObjectMeta.DeletionTimestamp = &deletionTimestamp
+		data.Spec.RemoteName = "restored-bucket"
+		data.Spec.ReclaimPolicy = v1beta1.BucketReclaimDelete
+		data.Status.RemoteName = data.Spec.RemoteName
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("DeleteBucket", ctx, data.Status.RemoteName).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -551,7 +1635,7 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -576,7 +1660,7 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -602,7 +1686,7 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 
 		store.On("DeleteBucket", ctx, data.Status.RemoteName).Return(errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, recordNothing, forgetNothing, noOwner, "https://localhost", relistInterval)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -617,6 +1701,50 @@ func fakeRecorder() record.EventRecorder {
 	return record.NewFakeRecorder(20)
 }
 
+type retainedBuckets struct {
+	records map[string]v1beta1.RetainedBucketSpec
+	err     error
+}
+
+func newRetainedBuckets(specs ...v1beta1.RetainedBucketSpec) *retainedBuckets {
+	result := &retainedBuckets{records: make(map[string]v1beta1.RetainedBucketSpec)}
+	for _, spec := range specs {
+		result.records[spec.RemoteName] = spec
+	}
+
+	return result
+}
+
+func (r *retainedBuckets) record(_ context.Context, spec v1beta1.RetainedBucketSpec) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.records[spec.RemoteName] = spec
+
+	return nil
+}
+
+func (r *retainedBuckets) forget(_ context.Context, remoteName string) error {
+	if r.err != nil {
+		return r.err
+	}
+	delete(r.records, remoteName)
+
+	return nil
+}
+
+func recordNothing(context.Context, v1beta1.RetainedBucketSpec) error {
+	return nil
+}
+
+func forgetNothing(context.Context, string) error {
+	return nil
+}
+
+func noOwner(context.Context, string, string, string) (string, bool, error) {
+	return "", false, nil
+}
+
 func testData(name string, policy v1beta1.BucketPolicy) *v1beta1.Bucket {
 	return &v1beta1.Bucket{
 		ObjectMeta: v1.ObjectMeta{
@@ -0,0 +1,274 @@
+package loader
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	SymlinkSkip   = "skip"
+	SymlinkReject = "reject"
+	SymlinkFollow = "follow"
+)
+
+// ArchiveError means that the package violates the extraction policy
+type ArchiveError struct {
+	message string
+}
+
+func (e *ArchiveError) Error() string {
+	return e.message
+}
+
+func IsArchiveError(err error) bool {
+	_, ok := errors.Cause(err).(*ArchiveError)
+	return ok
+}
+
+// streamRoot is the virtual destination of streamed packages, used only to validate entry paths
+var streamRoot = filepath.FromSlash("/stream")
+
+// extraction enforces the extraction policy while unpacking a single package. Limits are checked against
+// the number of bytes actually written, as sizes declared in archive headers cannot be trusted. Streamed
+// entries are checked against their declared size, as the sink reads exactly that many bytes.
+type extraction struct {
+	dst                 string
+	sink                Sink
+	stripComponents     int
+	targetPrefix        string
+	maxUncompressedSize int64
+	maxFileSize         int64
+	maxFileCount        int
+	symlinkPolicy       string
+
+	fileCount        int
+	uncompressedSize int64
+	extracted        map[string]int64
+}
+
+func (l *loader) newExtraction(dst string, stripComponents int, targetPrefix string) *extraction {
+	return &extraction{
+		dst:                 filepath.Clean(dst),
+		stripComponents:     stripComponents,
+		targetPrefix:        targetPrefix,
+		maxUncompressedSize: l.maxUncompressedSize,
+		maxFileSize:         l.maxFileSize,
+		maxFileCount:        l.maxFileCount,
+		symlinkPolicy:       l.symlinkPolicy,
+	}
+}
+
+func (l *loader) newStreamExtraction(sink Sink, stripComponents int, targetPrefix string) *extraction {
+	extraction := l.newExtraction(streamRoot, stripComponents, targetPrefix)
+	extraction.sink = sink
+	extraction.extracted = make(map[string]int64)
+
+	return extraction
+}
+
+// checkName rejects absolute entry names, which are not extracted under the destination by other tools
+func (e *extraction) checkName(name string) error {
+	if path.IsAbs(name) {
+		return &ArchiveError{message: fmt.Sprintf("%s: illegal file path", name)}
+	}
+
+	return nil
+}
+
+// rename removes leading path components from the archive entry name and places it under the target prefix.
+// It returns false if nothing is left from the name after stripping.
+func (e *extraction) rename(name string) (string, bool) {
+	if e.stripComponents <= 0 && len(e.targetPrefix) == 0 {
+		return name, true
+	}
+
+	var components []string
+	for _, component := range strings.Split(name, "/") {
+		if len(component) == 0 || component == "." {
+			continue
+		}
+		components = append(components, component)
+	}
+	if len(components) <= e.stripComponents {
+		return "", false
+	}
+	if e.stripComponents > 0 {
+		components = components[e.stripComponents:]
+	}
+
+	return path.Join(append([]string{e.targetPrefix}, components...)...), true
+}
+
+func (e *extraction) target(name string) (string, error) {
+	target := filepath.Join(e.dst, filepath.FromSlash(name))
+	if target != e.dst && !strings.HasPrefix(target, e.dst+string(os.PathSeparator)) {
+		return "", &ArchiveError{message: fmt.Sprintf("%s: illegal file path", name)}
+	}
+
+	return target, nil
+}
+
+func (e *extraction) createDir(target string) error {
+	if e.sink != nil {
+		return nil
+	}
+
+	return os.MkdirAll(target, os.ModePerm)
+}
+
+func (e *extraction) createFile(src io.Reader, target string, mode, size int64) error {
+	if err := e.countFile(); err != nil {
+		return err
+	}
+
+	if e.sink != nil {
+		return e.put(src, target, size)
+	}
+
+	limit := int64(-1)
+	if e.maxFileSize > 0 {
+		limit = e.maxFileSize
+	}
+	if remaining := e.maxUncompressedSize - e.uncompressedSize; e.maxUncompressedSize > 0 && (limit < 0 || remaining < limit) {
+		limit = remaining
+	}
+	if limit >= 0 {
+		src = io.LimitReader(src, limit+1)
+	}
+
+	if err := e.createDir(filepath.Dir(target)); err != nil {
+		return errors.Wrap(err, "while creating directory")
+	}
+
+	outFile, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(mode))
+	if err != nil {
+		return errors.Wrap(err, "while opening file")
+	}
+	defer outFile.Close()
+
+	written, err := io.Copy(outFile, src)
+	e.uncompressedSize += written
+	if err != nil {
+		return errors.Wrap(err, "while copying data to file")
+	}
+
+	return e.checkSize(target, written)
+}
+
+func (e *extraction) countFile() error {
+	e.fileCount++
+	if e.maxFileCount > 0 && e.fileCount > e.maxFileCount {
+		return &ArchiveError{message: fmt.Sprintf("archive contains more than %d files", e.maxFileCount)}
+	}
+
+	return nil
+}
+
+func (e *extraction) put(src io.Reader, target string, size int64) error {
+	e.uncompressedSize += size
+	if err := e.checkSize(target, size); err != nil {
+		return err
+	}
+
+	name := e.relative(target)
+	if err := e.sink.Put(name, src, size); err != nil {
+		return errors.Wrapf(err, "while uploading %s", name)
+	}
+	e.extracted[name] = size
+
+	return nil
+}
+
+func (e *extraction) checkSize(target string, size int64) error {
+	switch {
+	case e.maxFileSize > 0 && size > e.maxFileSize:
+		return &ArchiveError{message: fmt.Sprintf("%s: file exceeds %d bytes", e.relative(target), e.maxFileSize)}
+	case e.maxUncompressedSize > 0 && e.uncompressedSize > e.maxUncompressedSize:
+		return &ArchiveError{message: fmt.Sprintf("archive exceeds %d bytes of uncompressed content", e.maxUncompressedSize)}
+	}
+
+	return nil
+}
+
+func (e *extraction) relative(target string) string {
+	return filepath.ToSlash(strings.TrimPrefix(target, e.dst+string(os.PathSeparator)))
+}
+
+// link handles symbolic and hard links according to the policy. Followed links are replaced with a copy of the
+// linked file, which has to be extracted before the link. It returns true if a file has been created.
+func (e *extraction) link(name, linkName, target string, hard bool) (bool, error) {
+	switch e.symlinkPolicy {
+	case SymlinkReject:
+		return false, &ArchiveError{message: fmt.Sprintf("%s: links are not allowed", name)}
+	case SymlinkFollow:
+	default:
+		return false, nil
+	}
+
+	if path.IsAbs(linkName) {
+		return false, &ArchiveError{message: fmt.Sprintf("%s: illegal link target %s", name, linkName)}
+	}
+
+	linked := path.Join(path.Dir(name), linkName)
+	if hard {
+		renamed, ok := e.rename(linkName)
+		if !ok {
+			return false, &ArchiveError{message: fmt.Sprintf("%s: link target %s is not an extracted file", name, linkName)}
+		}
+		linked = renamed
+	}
+	source, err := e.target(linked)
+	if err != nil {
+		return false, &ArchiveError{message: fmt.Sprintf("%s: illegal link target %s", name, linkName)}
+	}
+
+	if e.sink != nil {
+		return e.copy(name, linkName, source, target)
+	}
+
+	info, err := os.Lstat(source)
+	if err != nil || !info.Mode().IsRegular() {
+		return false, &ArchiveError{message: fmt.Sprintf("%s: link target %s is not an extracted file", name, linkName)}
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return false, errors.Wrap(err, "while opening link target")
+	}
+	defer file.Close()
+
+	if err := e.createFile(file, target, int64(info.Mode().Perm()), info.Size()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// copy replaces a followed link with a copy of an already streamed file
+func (e *extraction) copy(name, linkName, source, target string) (bool, error) {
+	size, ok := e.extracted[e.relative(source)]
+	if !ok {
+		return false, &ArchiveError{message: fmt.Sprintf("%s: link target %s is not an extracted file", name, linkName)}
+	}
+
+	if err := e.countFile(); err != nil {
+		return false, err
+	}
+	e.uncompressedSize += size
+	if err := e.checkSize(target, size); err != nil {
+		return false, err
+	}
+
+	if err := e.sink.Copy(e.relative(source), e.relative(target)); err != nil {
+		return false, errors.Wrapf(err, "while copying %s", e.relative(source))
+	}
+	e.extracted[e.relative(target)] = size
+
+	return true, nil
+}
@@ -0,0 +1,146 @@
+package loader
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	"github.com/ulikunitz/xz"
+)
+
+const archiveHeaderSize = 512
+
+var (
+	archiveExtensions = []struct {
+		extension string
+		format    v1beta1.AssetArchiveFormat
+	}{
+		{".zip", v1beta1.AssetArchiveZIP},
+		{".tar", v1beta1.AssetArchiveTAR},
+		{".tar.gz", v1beta1.AssetArchiveTARGZ},
+		{".tgz", v1beta1.AssetArchiveTARGZ},
+		{".tar.bz2", v1beta1.AssetArchiveTARBZ2},
+		{".tbz2", v1beta1.AssetArchiveTARBZ2},
+		{".tbz", v1beta1.AssetArchiveTARBZ2},
+		{".tar.xz", v1beta1.AssetArchiveTARXZ},
+		{".txz", v1beta1.AssetArchiveTARXZ},
+		{".tar.zst", v1beta1.AssetArchiveTARZST},
+		{".tzst", v1beta1.AssetArchiveTARZST},
+	}
+
+	archiveContentTypes = map[string]v1beta1.AssetArchiveFormat{
+		"application/zip":              v1beta1.AssetArchiveZIP,
+		"application/x-zip-compressed": v1beta1.AssetArchiveZIP,
+		"application/x-tar":            v1beta1.AssetArchiveTAR,
+		"application/gzip":             v1beta1.AssetArchiveTARGZ,
+		"application/x-gzip":           v1beta1.AssetArchiveTARGZ,
+		"application/x-compressed-tar": v1beta1.AssetArchiveTARGZ,
+		"application/x-bzip2":          v1beta1.AssetArchiveTARBZ2,
+		"application/x-bzip":           v1beta1.AssetArchiveTARBZ2,
+		"application/x-xz":             v1beta1.AssetArchiveTARXZ,
+		"application/zstd":             v1beta1.AssetArchiveTARZST,
+	}
+
+	archiveMagicBytes = []struct {
+		offset int
+		magic  []byte
+		format v1beta1.AssetArchiveFormat
+	}{
+		{0, []byte("PK\x03\x04"), v1beta1.AssetArchiveZIP},
+		{0, []byte("PK\x05\x06"), v1beta1.AssetArchiveZIP},
+		{0, []byte{0x1f, 0x8b}, v1beta1.AssetArchiveTARGZ},
+		{0, []byte("BZh"), v1beta1.AssetArchiveTARBZ2},
+		{0, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, v1beta1.AssetArchiveTARXZ},
+		{0, []byte{0x28, 0xb5, 0x2f, 0xfd}, v1beta1.AssetArchiveTARZST},
+		{257, []byte("ustar"), v1beta1.AssetArchiveTAR},
+	}
+)
+
+// archiveFormat detects the format of the downloaded package. The explicit format takes precedence
+// over the file name extension, which takes precedence over the response Content-Type and magic bytes.
+// The package header is read only if the magic bytes are needed.
+func (l *loader) archiveFormat(format v1beta1.AssetArchiveFormat, fileName, contentType string, readHeader func() ([]byte, error)) (v1beta1.AssetArchiveFormat, error) {
+	if len(format) > 0 {
+		return format, nil
+	}
+
+	lowerFileName := strings.ToLower(fileName)
+	for _, item := range archiveExtensions {
+		if strings.HasSuffix(lowerFileName, item.extension) {
+			return item.format, nil
+		}
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if format, ok := archiveContentTypes[mediaType]; ok {
+			return format, nil
+		}
+	}
+
+	header, err := readHeader()
+	if err != nil {
+		return "", err
+	}
+	for _, item := range archiveMagicBytes {
+		if len(header) >= item.offset+len(item.magic) && bytes.Equal(header[item.offset:item.offset+len(item.magic)], item.magic) {
+			return item.format, nil
+		}
+	}
+
+	return "", fmt.Errorf("not supported file type of %s", fileName)
+}
+
+func (l *loader) readHeader(path string, size int) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while opening archive")
+	}
+	defer file.Close()
+
+	header, err := ioutil.ReadAll(io.LimitReader(file, int64(size)))
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading archive header")
+	}
+
+	return header, nil
+}
+
+func decompressGZIP(src io.Reader) (io.ReadCloser, error) {
+	reader, err := gzip.NewReader(src)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating GZIP reader")
+	}
+
+	return reader, nil
+}
+
+func decompressBZIP2(src io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bzip2.NewReader(src)), nil
+}
+
+func decompressXZ(src io.Reader) (io.ReadCloser, error) {
+	reader, err := xz.NewReader(src)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating XZ reader")
+	}
+
+	return ioutil.NopCloser(reader), nil
+}
+
+func decompressZSTD(src io.Reader) (io.ReadCloser, error) {
+	reader, err := zstd.NewReader(src)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating ZSTD reader")
+	}
+
+	return reader.IOReadCloser(), nil
+}
@@ -0,0 +1,357 @@
+package loader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+type archiveEntry struct {
+	name     string
+	content  string
+	linkName string
+	typeflag byte
+}
+
+func TestLoader_Load_ArchivePolicy(t *testing.T) {
+	regular := func(name, content string) archiveEntry {
+		return archiveEntry{name: name, content: content, typeflag: tar.TypeReg}
+	}
+	symlink := func(name, linkName string) archiveEntry {
+		return archiveEntry{name: name, linkName: linkName, typeflag: tar.TypeSymlink}
+	}
+	hardlink := func(name, linkName string) archiveEntry {
+		return archiveEntry{name: name, linkName: linkName, typeflag: tar.TypeLink}
+	}
+
+	for testName, testCase := range map[string]struct {
+		entries             []archiveEntry
+		symlinkPolicy       string
+		maxUncompressedSize int64
+		maxFileSize         int64
+		maxFileCount        int
+		expected            map[string]string
+		rejected            bool
+	}{
+		"Regular": {
+			entries:  []archiveEntry{regular("README.md", "readme"), regular("docs/guide.md", "guide")},
+			expected: map[string]string{"README.md": "readme", "docs/guide.md": "guide"},
+		},
+		"SkipLinks": {
+			entries:  []archiveEntry{regular("README.md", "readme"), symlink("index.md", "README.md")},
+			expected: map[string]string{"README.md": "readme"},
+		},
+		"FollowSymlink": {
+			entries:       []archiveEntry{regular("docs/guide.md", "guide"), symlink("docs/index.md", "guide.md")},
+			symlinkPolicy: SymlinkFollow,
+			expected:      map[string]string{"docs/guide.md": "guide", "docs/index.md": "guide"},
+		},
+		"FollowHardlink": {
+			entries:       []archiveEntry{regular("docs/guide.md", "guide"), hardlink("index.md", "docs/guide.md")},
+			symlinkPolicy: SymlinkFollow,
+			expected:      map[string]string{"docs/guide.md": "guide", "index.md": "guide"},
+		},
+		"WithinLimits": {
+			entries:             []archiveEntry{regular("a.md", "12345"), regular("b.md", "12345")},
+			maxUncompressedSize: 10,
+			maxFileSize:         5,
+			maxFileCount:        2,
+			expected:            map[string]string{"a.md": "12345", "b.md": "12345"},
+		},
+		"RejectPathTraversal": {
+			entries:  []archiveEntry{regular("../evil.sh", "evil")},
+			rejected: true,
+		},
+		"RejectAbsolutePath": {
+			entries:  []archiveEntry{regular("/etc/profile.d/evil.sh", "evil")},
+			rejected: true,
+		},
+		"RejectLinks": {
+			entries:       []archiveEntry{regular("README.md", "readme"), symlink("index.md", "README.md")},
+			symlinkPolicy: SymlinkReject,
+			rejected:      true,
+		},
+		"RejectEscapingSymlink": {
+			entries:       []archiveEntry{symlink("passwd", "../../../etc/passwd")},
+			symlinkPolicy: SymlinkFollow,
+			rejected:      true,
+		},
+		"RejectAbsoluteSymlink": {
+			entries:       []archiveEntry{symlink("passwd", "/etc/passwd")},
+			symlinkPolicy: SymlinkFollow,
+			rejected:      true,
+		},
+		"RejectLinkToMissingFile": {
+			entries:       []archiveEntry{symlink("index.md", "README.md"), regular("README.md", "readme")},
+			symlinkPolicy: SymlinkFollow,
+			rejected:      true,
+		},
+		"RejectTooManyFiles": {
+			entries:      []archiveEntry{regular("a.md", "a"), regular("b.md", "b"), regular("c.md", "c")},
+			maxFileCount: 2,
+			rejected:     true,
+		},
+		"RejectTooBigFile": {
+			entries:     []archiveEntry{regular("a.md", "123456")},
+			maxFileSize: 5,
+			rejected:    true,
+		},
+		"RejectTooBigArchive": {
+			entries:             []archiveEntry{regular("a.md", "12345"), regular("b.md", "123456")},
+			maxUncompressedSize: 10,
+			rejected:            true,
+		},
+	} {
+		for format, archive := range map[string]func(t *testing.T, entries []archiveEntry) []byte{
+			"package.tar": fixTAR,
+			"package.zip": fixZIP,
+		} {
+			t.Run(testName+"/"+format, func(t *testing.T) {
+				// Given
+				g := gomega.NewGomegaWithT(t)
+				content := archive(t, testCase.entries)
+				loader := &loader{
+					temporaryDir:        "/tmp",
+					osRemoveAllFunc:     os.RemoveAll,
+					osCreateFunc:        os.Create,
+					httpDoFunc:          getBytes(content),
+					ioutilTempDir:       ioutil.TempDir,
+					maxUncompressedSize: testCase.maxUncompressedSize,
+					maxFileSize:         testCase.maxFileSize,
+					maxFileCount:        testCase.maxFileCount,
+					symlinkPolicy:       testCase.symlinkPolicy,
+				}
+				source := v1beta1.AssetSource{URL: "https://example.com/" + format, Mode: v1beta1.AssetPackage}
+
+				// When
+				result, err := loader.Load("", "asset", source)
+				defer loader.Clean(result.BasePath)
+
+				// Then
+				if testCase.rejected {
+					g.Expect(err).To(gomega.HaveOccurred())
+					g.Expect(IsArchiveError(err)).To(gomega.BeTrue(), err.Error())
+					return
+				}
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(result.Files).To(gomega.HaveLen(len(testCase.expected)))
+				for _, file := range result.Files {
+					content, err := ioutil.ReadFile(filepath.Join(result.BasePath, file))
+					g.Expect(err).NotTo(gomega.HaveOccurred())
+					g.Expect(string(content)).To(gomega.Equal(testCase.expected[file]))
+				}
+			})
+		}
+	}
+}
+
+func TestLoader_Load_ArchiveLayout(t *testing.T) {
+	entries := []archiveEntry{
+		{name: "repo-8a1f0c2/", typeflag: tar.TypeDir},
+		{name: "repo-8a1f0c2/README.md", content: "readme", typeflag: tar.TypeReg},
+		{name: "repo-8a1f0c2/docs/", typeflag: tar.TypeDir},
+		{name: "repo-8a1f0c2/docs/guide.md", content: "guide", typeflag: tar.TypeReg},
+	}
+	links := map[string]archiveEntry{
+		"package.tar": {name: "repo-8a1f0c2/index.md", linkName: "repo-8a1f0c2/docs/guide.md", typeflag: tar.TypeLink},
+		"package.zip": {name: "repo-8a1f0c2/index.md", linkName: "docs/guide.md", typeflag: tar.TypeSymlink},
+	}
+
+	for testName, testCase := range map[string]struct {
+		stripComponents int
+		targetPrefix    string
+		filter          string
+		expected        map[string]string
+		rejected        bool
+	}{
+		"Unchanged": {
+			expected: map[string]string{"repo-8a1f0c2/README.md": "readme", "repo-8a1f0c2/docs/guide.md": "guide", "repo-8a1f0c2/index.md": "guide"},
+		},
+		"StripComponents": {
+			stripComponents: 1,
+			expected:        map[string]string{"README.md": "readme", "docs/guide.md": "guide", "index.md": "guide"},
+		},
+		"TargetPrefix": {
+			targetPrefix: "v1",
+			expected:     map[string]string{"v1/repo-8a1f0c2/README.md": "readme", "v1/repo-8a1f0c2/docs/guide.md": "guide", "v1/repo-8a1f0c2/index.md": "guide"},
+		},
+		"StripComponentsAndTargetPrefix": {
+			stripComponents: 1,
+			targetPrefix:    "api/v1/",
+			expected:        map[string]string{"api/v1/README.md": "readme", "api/v1/docs/guide.md": "guide", "api/v1/index.md": "guide"},
+		},
+		"FilterAfterRewriting": {
+			stripComponents: 1,
+			filter:          "^docs/",
+			expected:        map[string]string{"docs/guide.md": "guide"},
+		},
+		"StripAllComponents": {
+			stripComponents: 3,
+			expected:        map[string]string{},
+		},
+	} {
+		for format, archive := range map[string]func(t *testing.T, entries []archiveEntry) []byte{
+			"package.tar": fixTAR,
+			"package.zip": fixZIP,
+		} {
+			t.Run(testName+"/"+format, func(t *testing.T) {
+				// Given
+				g := gomega.NewGomegaWithT(t)
+				content := archive(t, append(entries, links[format]))
+				loader := &loader{
+					temporaryDir:    "/tmp",
+					osRemoveAllFunc: os.RemoveAll,
+					osCreateFunc:    os.Create,
+					httpDoFunc:      getBytes(content),
+					ioutilTempDir:   ioutil.TempDir,
+					symlinkPolicy:   SymlinkFollow,
+				}
+				source := v1beta1.AssetSource{
+					URL:             "https://example.com/" + format,
+					Mode:            v1beta1.AssetPackage,
+					Filter:          testCase.filter,
+					StripComponents: testCase.stripComponents,
+					TargetPrefix:    testCase.targetPrefix,
+				}
+
+				// When
+				result, err := loader.Load("", "asset", source)
+				defer loader.Clean(result.BasePath)
+
+				// Then
+				if testCase.rejected {
+					g.Expect(err).To(gomega.HaveOccurred())
+					g.Expect(IsArchiveError(err)).To(gomega.BeTrue(), err.Error())
+					return
+				}
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(result.Files).To(gomega.HaveLen(len(testCase.expected)))
+				for _, file := range result.Files {
+					content, err := ioutil.ReadFile(filepath.Join(result.BasePath, file))
+					g.Expect(err).NotTo(gomega.HaveOccurred())
+					g.Expect(string(content)).To(gomega.Equal(testCase.expected[file]))
+				}
+			})
+		}
+	}
+}
+
+func TestLoader_Load_ArchiveLayoutNotSupportedMode(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	loader := &loader{
+		temporaryDir:    "/tmp",
+		osRemoveAllFunc: os.RemoveAll,
+		osCreateFunc:    os.Create,
+		httpDoFunc:      get,
+		ioutilTempDir:   ioutil.TempDir,
+	}
+	source := v1beta1.AssetSource{URL: "https://example.com/README.md", Mode: v1beta1.AssetSingle, StripComponents: 1}
+
+	// When
+	_, err := loader.Load("", "asset", source)
+
+	// Then
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestLoader_Load_ArchiveLayoutIllegalTargetPrefix(t *testing.T) {
+	for testName, targetPrefix := range map[string]string{
+		"Escaping":       "../outside",
+		"EscapingNested": "docs/../../outside",
+		"Absolute":       "/etc",
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{
+				temporaryDir:    "/tmp",
+				osRemoveAllFunc: os.RemoveAll,
+				osCreateFunc:    os.Create,
+				httpDoFunc:      getBytes(fixTAR(t, []archiveEntry{{name: "README.md", content: "readme", typeflag: tar.TypeReg}})),
+				ioutilTempDir:   ioutil.TempDir,
+			}
+			source := v1beta1.AssetSource{URL: "https://example.com/package.tar", Mode: v1beta1.AssetPackage, TargetPrefix: targetPrefix}
+
+			// When
+			result, err := loader.Load("", "asset", source)
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			g.Expect(err).To(gomega.HaveOccurred())
+			g.Expect(result.Files).To(gomega.BeEmpty())
+		})
+	}
+}
+
+func fixTAR(t *testing.T, entries []archiveEntry) []byte {
+	g := gomega.NewGomegaWithT(t)
+	buffer := &bytes.Buffer{}
+	writer := tar.NewWriter(buffer)
+
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.name,
+			Linkname: entry.linkName,
+			Typeflag: entry.typeflag,
+			Mode:     0644,
+			Size:     int64(len(entry.content)),
+		}
+		g.Expect(writer.WriteHeader(header)).To(gomega.Succeed())
+		_, err := writer.Write([]byte(entry.content))
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+	g.Expect(writer.Close()).To(gomega.Succeed())
+
+	return buffer.Bytes()
+}
+
+// fixZIP stores links in the same way as the zip tool, hard links are not supported by the format
+func fixZIP(t *testing.T, entries []archiveEntry) []byte {
+	g := gomega.NewGomegaWithT(t)
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		content := entry.content
+		switch entry.typeflag {
+		case tar.TypeSymlink:
+			header.SetMode(0777 | os.ModeSymlink)
+			content = entry.linkName
+		case tar.TypeLink:
+			header.SetMode(0777 | os.ModeSymlink)
+			content = strings.Repeat("../", strings.Count(entry.name, "/")) + entry.linkName
+		case tar.TypeDir:
+			header.SetMode(0755 | os.ModeDir)
+		default:
+			header.SetMode(0644)
+		}
+
+		fileWriter, err := writer.CreateHeader(header)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = fileWriter.Write([]byte(content))
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+	g.Expect(writer.Close()).To(gomega.Succeed())
+
+	return buffer.Bytes()
+}
+
+func getBytes(content []byte) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(content)),
+		}, nil
+	}
+}
@@ -3,8 +3,10 @@
 package automock
 
 import (
-	v1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	loader "github.com/kyma-project/rafter/internal/loader"
 	mock "github.com/stretchr/testify/mock"
+
+	v1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 )
 
 // Loader is an autogenerated mock type for the Loader type
@@ -12,6 +14,27 @@ type Loader struct {
 	mock.Mock
 }
 
+// Changed provides a mock function with given fields: namespace, source, ref
+func (_m *Loader) Changed(namespace string, source v1beta1.AssetSource, ref v1beta1.AssetStatusRef) (bool, error) {
+	ret := _m.Called(namespace, source, ref)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, v1beta1.AssetSource, v1beta1.AssetStatusRef) bool); ok {
+		r0 = rf(namespace, source, ref)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, v1beta1.AssetSource, v1beta1.AssetStatusRef) error); ok {
+		r1 = rf(namespace, source, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Clean provides a mock function with given fields: path
 func (_m *Loader) Clean(path string) error {
 	ret := _m.Called(path)
@@ -26,32 +49,58 @@ func (_m *Loader) Clean(path string) error {
 	return r0
 }
 
-// Load provides a mock function with given fields: src, assetName, mode, filter
-func (_m *Loader) Load(src string, assetName string, mode v1beta1.AssetMode, filter string) (string, []string, error) {
-	ret := _m.Called(src, assetName, mode, filter)
+// Load provides a mock function with given fields: namespace, assetName, source
+func (_m *Loader) Load(namespace string, assetName string, source v1beta1.AssetSource) (loader.Result, error) {
+	ret := _m.Called(namespace, assetName, source)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string, v1beta1.AssetMode, string) string); ok {
-		r0 = rf(src, assetName, mode, filter)
+	var r0 loader.Result
+	if rf, ok := ret.Get(0).(func(string, string, v1beta1.AssetSource) loader.Result); ok {
+		r0 = rf(namespace, assetName, source)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(loader.Result)
 	}
 
-	var r1 []string
-	if rf, ok := ret.Get(1).(func(string, string, v1beta1.AssetMode, string) []string); ok {
-		r1 = rf(src, assetName, mode, filter)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, v1beta1.AssetSource) error); ok {
+		r1 = rf(namespace, assetName, source)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]string)
-		}
+		r1 = ret.Error(1)
 	}
 
-	var r2 error
-	if rf, ok := ret.Get(2).(func(string, string, v1beta1.AssetMode, string) error); ok {
-		r2 = rf(src, assetName, mode, filter)
+	return r0, r1
+}
+
+// Stream provides a mock function with given fields: namespace, assetName, source, sink
+func (_m *Loader) Stream(namespace string, assetName string, source v1beta1.AssetSource, sink loader.Sink) (loader.Result, error) {
+	ret := _m.Called(namespace, assetName, source, sink)
+
+	var r0 loader.Result
+	if rf, ok := ret.Get(0).(func(string, string, v1beta1.AssetSource, loader.Sink) loader.Result); ok {
+		r0 = rf(namespace, assetName, source, sink)
+	} else {
+		r0 = ret.Get(0).(loader.Result)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, v1beta1.AssetSource, loader.Sink) error); ok {
+		r1 = rf(namespace, assetName, source, sink)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Streamable provides a mock function with given fields: source
+func (_m *Loader) Streamable(source v1beta1.AssetSource) bool {
+	ret := _m.Called(source)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(v1beta1.AssetSource) bool); ok {
+		r0 = rf(source)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0, r1, r2
+	return r0
 }
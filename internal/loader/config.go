@@ -1,6 +1,24 @@
 package loader
 
+import "time"
+
 type Config struct {
-	TemporaryDirectory string `envconfig:"default=/tmp"`
-	VerifySSL          bool   `envconfig:"default=true"`
+	TemporaryDirectory   string        `envconfig:"default=/tmp"`
+	VerifySSL            bool          `envconfig:"default=true"`
+	IndexWorkersCount    int           `envconfig:"default=10"`
+	IndexEntriesInterval time.Duration `envconfig:"default=1h"`
+	MaxIndexEntryRefs    int           `envconfig:"default=100"`
+	GitAllowProtocol     string        `envconfig:"default=https"`
+	MaxUncompressedSize  int64         `envconfig:"default=1073741824"`
+	MaxFileSize          int64         `envconfig:"default=104857600"`
+	MaxFileCount         int           `envconfig:"default=10000"`
+	SymlinkPolicy        string        `envconfig:"default=skip"`
+	AllowedSchemes       []string      `envconfig:"default=http;https"`
+	AllowedHosts         []string      `envconfig:"optional"`
+	DeniedHosts          []string      `envconfig:"optional"`
+	BlockPrivateNetworks bool          `envconfig:"default=true"`
+	MaxRedirects         int           `envconfig:"default=10"`
+	RequestTimeout       time.Duration `envconfig:"default=10m"`
+	MaxRetries           int           `envconfig:"default=3"`
+	RetryBackoff         time.Duration `envconfig:"default=1s"`
 }
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -16,41 +15,90 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-func (l *loader) loadConfigMap(src string, name string, filter string) (string, []string, error) {
-	basePath, err := ioutil.TempDir(l.temporaryDir, name)
+const configMapAllowedNamespacesAnnotation = "rafter.kyma-project.io/allowed-namespaces"
+
+// AccessDeniedError means that the asset is not allowed to read the referenced object
+type AccessDeniedError struct {
+	message string
+}
+
+func (e *AccessDeniedError) Error() string {
+	return e.message
+}
+
+func IsAccessDeniedError(err error) bool {
+	_, ok := errors.Cause(err).(*AccessDeniedError)
+	return ok
+}
+
+func (l *loader) loadConfigMap(namespace, src, name, filter string) (Result, error) {
+	basePath, err := l.ioutilTempDir(l.temporaryDir, name)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	filterRegexp, err := regexp.Compile(filter)
 	if err != nil {
-		return "", nil, errors.Wrap(err, "while compiling filter")
+		return Result{}, errors.Wrap(err, "while compiling filter")
 	}
 
-	srcs := strings.Split(src, "/")
-	if len(srcs) != 2 {
-		return "", nil, fmt.Errorf("%s: invalid source format", src)
-	}
-
-	configMap, err := l.getConfigMap(srcs[0], srcs[1])
+	configMap, err := l.sourceConfigMap(namespace, src)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	var fileList []string
 	for key, value := range configMap.Data {
 		if fileList, err = l.copyBytesToFile([]byte(value), key, basePath, filterRegexp, fileList); err != nil {
-			return "", nil, errors.Wrap(err, "while copying data to file")
+			return Result{}, errors.Wrap(err, "while copying data to file")
 		}
 	}
 
 	for key, value := range configMap.BinaryData {
 		if fileList, err = l.copyBytesToFile(value, key, basePath, filterRegexp, fileList); err != nil {
-			return "", nil, errors.Wrap(err, "while copying binary data to file")
+			return Result{}, errors.Wrap(err, "while copying binary data to file")
 		}
 	}
 
-	return basePath, fileList, nil
+	return Result{BasePath: basePath, Files: fileList, Digest: l.configMapDigest(configMap)}, nil
+}
+
+// sourceConfigMap returns the ConfigMap the asset is loaded from. Namespaced assets can read ConfigMaps from other
+// namespaces only if their namespace is listed in the allowed namespaces annotation of the ConfigMap. A missing
+// ConfigMap from another namespace is reported the same way as a not shared one, so that assets can't probe it.
+func (l *loader) sourceConfigMap(namespace, src string) (*corev1.ConfigMap, error) {
+	srcs := strings.Split(src, "/")
+	if len(srcs) != 2 {
+		return nil, fmt.Errorf("%s: invalid source format", src)
+	}
+
+	foreign := namespace != "" && srcs[0] != namespace
+	accessDenied := &AccessDeniedError{message: fmt.Sprintf("ConfigMap %s from %s namespace is not shared with %s namespace", srcs[1], srcs[0], namespace)}
+
+	configMap, err := l.getConfigMap(srcs[0], srcs[1])
+	if err != nil {
+		if foreign {
+			return nil, accessDenied
+		}
+		return nil, err
+	}
+
+	if foreign && !l.namespaceAllowed(configMap.Annotations[configMapAllowedNamespacesAnnotation], namespace) {
+		return nil, accessDenied
+	}
+
+	return configMap, nil
+}
+
+func (l *loader) namespaceAllowed(allowed, namespace string) bool {
+	for _, item := range strings.Split(allowed, ",") {
+		item = strings.TrimSpace(item)
+		if item == "*" || item == namespace {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (l *loader) getConfigMap(namespace, name string) (*corev1.ConfigMap, error) {
@@ -83,6 +131,9 @@ func (l *loader) copyBytesToFile(value []byte, name string, path string, regexp
 	}
 
 	_, err = io.Copy(file, bytes.NewReader(value))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package loader
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/onsi/gomega"
@@ -45,7 +46,7 @@ func TestLoader_Load_ConfigMap(t *testing.T) {
 		dynamicClient:   fakedc,
 		osRemoveAllFunc: os.RemoveAll,
 		osCreateFunc:    os.Create,
-		httpGetFunc:     get,
+		httpDoFunc:      get,
 		ioutilTempDir:   ioutil.TempDir,
 	}
 
@@ -111,7 +112,8 @@ func TestLoader_Load_ConfigMap(t *testing.T) {
 			g := gomega.NewGomegaWithT(t)
 
 			// When
-			_, files, err := loader.Load(testData.src, testData.name, testData.mode, testData.filter)
+			result, err := loader.Load("", testData.name, v1beta1.AssetSource{URL: testData.src, Mode: testData.mode, Filter: testData.filter})
+			files := result.Files
 
 			// Then
 			g.Expect(err).To(testData.errMatcher)
@@ -120,6 +122,117 @@ func TestLoader_Load_ConfigMap(t *testing.T) {
 	}
 }
 
+func TestLoader_Load_ConfigMapFailTemp(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	fakedc, err := newFakeDynamicClient(fixConfigMap("text", "default", map[string]string{"example.json": exampleDataBody}, nil))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	loader := &loader{
+		temporaryDir:    "/tmp",
+		dynamicClient:   fakedc,
+		osRemoveAllFunc: os.RemoveAll,
+		ioutilTempDir:   tempDirError,
+	}
+
+	// When
+	_, err = loader.Load("default", "asset", v1beta1.AssetSource{URL: "default/text", Mode: v1beta1.AssetConfigMap})
+
+	// Then
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestLoader_Load_ConfigMapAccess(t *testing.T) {
+	shared := fixConfigMap("shared", "docs", map[string]string{"README.md": "readme"}, nil)
+	shared.Annotations = map[string]string{configMapAllowedNamespacesAnnotation: "team-a, team-b"}
+	public := fixConfigMap("public", "docs", map[string]string{"README.md": "readme"}, nil)
+	public.Annotations = map[string]string{configMapAllowedNamespacesAnnotation: "*"}
+	private := fixConfigMap("private", "docs", map[string]string{"README.md": "readme"}, nil)
+
+	for testName, testCase := range map[string]struct {
+		namespace string
+		src       string
+		denied    bool
+	}{
+		"SameNamespace": {
+			namespace: "docs",
+			src:       "docs/private",
+		},
+		"ClusterAsset": {
+			namespace: "",
+			src:       "docs/private",
+		},
+		"AllowedNamespace": {
+			namespace: "team-b",
+			src:       "docs/shared",
+		},
+		"AllNamespacesAllowed": {
+			namespace: "team-c",
+			src:       "docs/public",
+		},
+		"NamespaceNotAllowed": {
+			namespace: "team-c",
+			src:       "docs/shared",
+			denied:    true,
+		},
+		"NotShared": {
+			namespace: "team-a",
+			src:       "docs/private",
+			denied:    true,
+		},
+		"NotExistingInOtherNamespace": {
+			namespace: "team-a",
+			src:       "docs/missing",
+			denied:    true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			fakedc, err := newFakeDynamicClient(shared, public, private)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			loader := &loader{
+				temporaryDir:    "/tmp",
+				dynamicClient:   fakedc,
+				osRemoveAllFunc: os.RemoveAll,
+				osCreateFunc:    os.Create,
+				ioutilTempDir:   ioutil.TempDir,
+			}
+
+			// When
+			result, err := loader.Load(testCase.namespace, "asset", v1beta1.AssetSource{URL: testCase.src, Mode: v1beta1.AssetConfigMap})
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			if testCase.denied {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(IsAccessDeniedError(err)).To(gomega.BeTrue())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.Files).To(gomega.ConsistOf("README.md"))
+		})
+	}
+
+	t.Run("SameErrorForMissingAndNotShared", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		fakedc, err := newFakeDynamicClient(private)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		loader := &loader{
+			dynamicClient: fakedc,
+		}
+
+		// When
+		_, notSharedErr := loader.sourceConfigMap("team-a", "docs/private")
+		_, missingErr := loader.sourceConfigMap("team-a", "docs/public")
+
+		// Then
+		g.Expect(notSharedErr).To(gomega.HaveOccurred())
+		g.Expect(missingErr).To(gomega.HaveOccurred())
+		g.Expect(missingErr.Error()).To(gomega.Equal(strings.Replace(notSharedErr.Error(), "private", "public", 1)))
+	})
+}
+
 func fixConfigMap(name string, namespace string, data map[string]string, binaryData map[string][]byte) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		TypeMeta: v1.TypeMeta{
@@ -0,0 +1,93 @@
+package loader
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	secretUsernameKey     = "username"
+	secretPasswordKey     = "password"
+	secretTokenKey        = "token"
+	secretHeaderKeyPrefix = "header."
+)
+
+func (l *loader) credentials(namespace string, ref *v1beta1.AssetSecretRef) (http.Header, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	secretNamespace, err := l.referenceNamespace(namespace, "Secret", ref.Name, ref.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := l.getSecret(secretNamespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	username, hasUsername := secret.Data[secretUsernameKey]
+	password, hasPassword := secret.Data[secretPasswordKey]
+	if hasUsername || hasPassword {
+		credentials := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password)))
+		header.Set("Authorization", fmt.Sprintf("Basic %s", credentials))
+	}
+	if token, ok := secret.Data[secretTokenKey]; ok {
+		header.Set("Authorization", fmt.Sprintf("Bearer %s", strings.TrimSpace(string(token))))
+	}
+	for key, value := range secret.Data {
+		if !strings.HasPrefix(key, secretHeaderKeyPrefix) {
+			continue
+		}
+		header.Set(strings.TrimPrefix(key, secretHeaderKeyPrefix), strings.TrimSpace(string(value)))
+	}
+
+	if len(header) == 0 {
+		return nil, fmt.Errorf("Secret %s from %s namespace does not contain any credentials", ref.Name, secretNamespace)
+	}
+
+	return header, nil
+}
+
+// referenceNamespace returns the namespace of the referenced object. Namespaced assets can use only
+// objects from their own namespace, cluster-wide assets have to specify the namespace explicitly.
+func (l *loader) referenceNamespace(namespace, kind, name, refNamespace string) (string, error) {
+	switch {
+	case namespace == "" && refNamespace == "":
+		return "", fmt.Errorf("namespace of %s %s is required", kind, name)
+	case namespace == "":
+		return refNamespace, nil
+	case refNamespace != "" && refNamespace != namespace:
+		return "", &AccessDeniedError{message: fmt.Sprintf("%s %s has to be in %s namespace", kind, name, namespace)}
+	default:
+		return namespace, nil
+	}
+}
+
+func (l *loader) getSecret(namespace, name string) (*corev1.Secret, error) {
+	secretsResource := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+
+	item, err := l.dynamicClient.Resource(secretsResource).Namespace(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting Secret %s from %s namespace", name, namespace)
+	}
+
+	var secret corev1.Secret
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting Unstructured to Secret %s from %s", name, namespace)
+	}
+
+	return &secret, nil
+}
@@ -0,0 +1,208 @@
+package loader
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoader_credentials(t *testing.T) {
+	fakedc, err := newFakeDynamicClient(
+		fixSecret("basic", "default", map[string][]byte{"username": []byte("admin"), "password": []byte("secret")}),
+		fixSecret("token", "default", map[string][]byte{"token": []byte("abc\n")}),
+		fixSecret("headers", "default", map[string][]byte{"header.X-Api-Key": []byte("key"), "header.X-Tenant": []byte("tenant")}),
+		fixSecret("empty", "default", map[string][]byte{"other": []byte("value")}),
+		fixSecret("token", "other", map[string][]byte{"token": []byte("other")}),
+	)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+
+	loader := &loader{
+		dynamicClient: fakedc,
+	}
+
+	for testName, testCase := range map[string]struct {
+		namespace string
+		ref       *v1beta1.AssetSecretRef
+		expected  http.Header
+		fail      bool
+	}{
+		"NoReference": {
+			namespace: "default",
+		},
+		"BasicAuth": {
+			namespace: "default",
+			ref:       &v1beta1.AssetSecretRef{Name: "basic"},
+			expected:  http.Header{"Authorization": []string{"Basic YWRtaW46c2VjcmV0"}},
+		},
+		"BearerToken": {
+			namespace: "default",
+			ref:       &v1beta1.AssetSecretRef{Name: "token"},
+			expected:  http.Header{"Authorization": []string{"Bearer abc"}},
+		},
+		"CustomHeaders": {
+			namespace: "default",
+			ref:       &v1beta1.AssetSecretRef{Name: "headers"},
+			expected:  http.Header{"X-Api-Key": []string{"key"}, "X-Tenant": []string{"tenant"}},
+		},
+		"SameNamespace": {
+			namespace: "default",
+			ref:       &v1beta1.AssetSecretRef{Name: "token", Namespace: "default"},
+			expected:  http.Header{"Authorization": []string{"Bearer abc"}},
+		},
+		"ClusterWide": {
+			ref:      &v1beta1.AssetSecretRef{Name: "token", Namespace: "other"},
+			expected: http.Header{"Authorization": []string{"Bearer other"}},
+		},
+		"FailOtherNamespace": {
+			namespace: "default",
+			ref:       &v1beta1.AssetSecretRef{Name: "token", Namespace: "other"},
+			fail:      true,
+		},
+		"FailClusterWideWithoutNamespace": {
+			ref:  &v1beta1.AssetSecretRef{Name: "token"},
+			fail: true,
+		},
+		"FailNoCredentials": {
+			namespace: "default",
+			ref:       &v1beta1.AssetSecretRef{Name: "empty"},
+			fail:      true,
+		},
+		"FailNotFound": {
+			namespace: "default",
+			ref:       &v1beta1.AssetSecretRef{Name: "notFound"},
+			fail:      true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			header, err := loader.credentials(testCase.namespace, testCase.ref)
+
+			// Then
+			if testCase.fail {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(header).To(gomega.Equal(testCase.expected))
+		})
+	}
+}
+
+func TestLoader_Load_WithCredentials(t *testing.T) {
+	fakedc, err := newFakeDynamicClient(
+		fixSecret("token", "default", map[string][]byte{"token": []byte("abc")}),
+	)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+
+	t.Run("Single", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		requests := make(map[string]string)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			dynamicClient:   fakedc,
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc:      recordAuthorization(requests),
+			ioutilTempDir:   ioutil.TempDir,
+		}
+		source := v1beta1.AssetSource{URL: "https://private.example.com/spec.json", Mode: v1beta1.AssetSingle, SecretRef: &v1beta1.AssetSecretRef{Name: "token"}}
+
+		// When
+		result, err := loader.Load("default", "asset", source)
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(requests).To(gomega.Equal(map[string]string{
+			"https://private.example.com/spec.json": "Bearer abc",
+		}))
+	})
+
+	t.Run("IndexSendsCredentialsOnlyToIndexHost", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		requests := make(map[string]string)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			dynamicClient:   fakedc,
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc:      recordAuthorization(requests),
+			ioutilTempDir:   ioutil.TempDir,
+		}
+		source := v1beta1.AssetSource{URL: "https://private.example.com/index.yaml", Mode: v1beta1.AssetIndex, SecretRef: &v1beta1.AssetSecretRef{Name: "token"}}
+
+		// When
+		result, err := loader.Load("default", "asset", source)
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(requests).To(gomega.Equal(map[string]string{
+			"https://private.example.com/index.yaml": "Bearer abc",
+			"https://private.example.com/README.md":  "Bearer abc",
+			"https://public.example.com/logo.svg":    "",
+		}))
+	})
+
+	t.Run("FailMissingSecret", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			dynamicClient:   fakedc,
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc:      get,
+			ioutilTempDir:   ioutil.TempDir,
+		}
+		source := v1beta1.AssetSource{URL: "https://private.example.com/spec.json", Mode: v1beta1.AssetSingle, SecretRef: &v1beta1.AssetSecretRef{Name: "missing"}}
+
+		// When
+		_, err := loader.Load("default", "asset", source)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func recordAuthorization(requests map[string]string) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		requests[req.URL.String()] = req.Header.Get("Authorization")
+
+		body := "content"
+		if req.URL.Path == "/index.yaml" {
+			body = "- README.md\n- https://public.example.com/logo.svg\n"
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+		}, nil
+	}
+}
+
+func fixSecret(name, namespace string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: data,
+	}
+}
@@ -0,0 +1,352 @@
+package loader
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+func (l *loader) loadGit(src, name string, git *v1beta1.AssetGitSource, filter string, header http.Header, transport transportOptions) (Result, error) {
+	var ref, subPath string
+	if git != nil {
+		ref, subPath = git.Ref, git.Path
+	}
+	if strings.HasPrefix(ref, "-") {
+		return Result{}, fmt.Errorf("%s: invalid git reference", ref)
+	}
+
+	options, protocolEnv, err := l.checkRepository(src, transport)
+	if err != nil {
+		return Result{}, err
+	}
+
+	env, cleanup, err := l.gitEnvironment(header, transport)
+	if err != nil {
+		return Result{}, err
+	}
+	defer cleanup()
+	env = append(env, protocolEnv...)
+
+	repositoryDir, err := l.ioutilTempDir(l.temporaryDir, name)
+	if err != nil {
+		return Result{}, err
+	}
+	defer l.Clean(repositoryDir)
+
+	filterRegexp, err := regexp.Compile(filter)
+	if err != nil {
+		return Result{}, errors.Wrapf(err, "while compiling filter")
+	}
+
+	if err := l.cloneRepository(src, ref, repositoryDir, env, options); err != nil {
+		return Result{}, err
+	}
+
+	revision, err := l.runGit(repositoryDir, nil, "rev-parse", "HEAD")
+	if err != nil {
+		return Result{}, errors.Wrap(err, "while resolving revision")
+	}
+
+	root, err := l.repositoryRoot(repositoryDir, subPath)
+	if err != nil {
+		return Result{}, err
+	}
+
+	basePath, err := l.ioutilTempDir(l.temporaryDir, name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	files, err := l.copyRepositoryFiles(root, basePath, filterRegexp)
+	if err != nil {
+		l.Clean(basePath)
+		return Result{}, err
+	}
+
+	return Result{BasePath: basePath, Files: files, Revision: revision}, nil
+}
+
+func (l *loader) cloneRepository(src, ref, dst string, env []string, options []string) error {
+	if ref == "" {
+		if _, err := l.runGit("", env, append(options, "clone", "--quiet", "--depth", "1", "--", src, dst)...); err != nil {
+			return errors.Wrap(err, "while cloning repository")
+		}
+		return nil
+	}
+
+	// Branches and tags can be cloned shallowly, commits require the full history
+	named, err := l.isNamedRef(src, ref, env, options)
+	if err != nil {
+		return errors.Wrap(err, "while listing references")
+	}
+	if named {
+		if _, err := l.runGit("", env, append(options, "clone", "--quiet", "--depth", "1", "--branch", ref, "--", src, dst)...); err != nil {
+			return errors.Wrap(err, "while cloning repository")
+		}
+		return nil
+	}
+
+	if err := l.createDir(dst); err != nil {
+		return errors.Wrap(err, "while creating directory")
+	}
+	if _, err := l.runGit("", env, append(options, "clone", "--quiet", "--no-checkout", "--", src, dst)...); err != nil {
+		return errors.Wrap(err, "while cloning repository")
+	}
+	if _, err := l.runGit(dst, nil, "checkout", "--quiet", "--detach", ref); err != nil {
+		return errors.Wrapf(err, "while checking out %s", ref)
+	}
+
+	return nil
+}
+
+// isNamedRef checks if the reference is a branch or a tag of the remote repository
+func (l *loader) isNamedRef(src, ref string, env []string, options []string) (bool, error) {
+	output, err := l.runGit("", env, append(options, "ls-remote", "--heads", "--tags", "--", src, ref)...)
+	if err != nil {
+		return false, err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimSuffix(fields[1], "^{}")
+		if name == "refs/heads/"+ref || name == "refs/tags/"+ref {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (l *loader) runGit(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		fmt.Sprintf("GIT_ALLOW_PROTOCOL=%s", l.gitAllowProtocol),
+	)
+	cmd.Env = append(cmd.Env, env...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// gitEnvironment returns the variables passing the headers and the connection settings of the source to git. They are
+// passed through the environment so they do not show up in the process list. The returned function removes the CA bundle
+// file, which replaces the system certificates in git.
+func (l *loader) gitEnvironment(header http.Header, transport transportOptions) ([]string, func(), error) {
+	var config [][2]string
+	for key, values := range header {
+		for _, value := range values {
+			config = append(config, [2]string{"http.extraHeader", fmt.Sprintf("%s: %s", key, value)})
+		}
+	}
+	if len(transport.proxy) > 0 {
+		config = append(config, [2]string{"http.proxy", transport.proxy})
+	}
+
+	var env []string
+	for i, entry := range config {
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, entry[0]),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, entry[1]),
+		)
+	}
+	if len(config) > 0 {
+		env = append(env, fmt.Sprintf("GIT_CONFIG_COUNT=%d", len(config)))
+	}
+	if transport.insecureSkipVerify {
+		env = append(env, "GIT_SSL_NO_VERIFY=true")
+	}
+
+	cleanup := func() {}
+	if len(transport.caBundle) > 0 {
+		file, err := ioutil.TempFile(l.temporaryDir, "ca-bundle")
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "while creating CA bundle file")
+		}
+		cleanup = func() { l.osRemoveAllFunc(file.Name()) }
+
+		_, err = file.WriteString(transport.caBundle)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			cleanup()
+			return nil, nil, errors.Wrap(err, "while writing CA bundle file")
+		}
+		env = append(env, fmt.Sprintf("GIT_SSL_CAINFO=%s", file.Name()))
+	}
+
+	return env, cleanup, nil
+}
+
+// checkRepository applies the source policy to the repository scheme and host, and returns the git options and the
+// environment enforcing it. Redirects are followed by git itself, so they are disabled if the policy restricts hosts
+// or networks, and the protocols git can use, also for submodules, are limited to the allowed schemes. Git resolves
+// the host again on its own, so checking its addresses is only a pre-check, which doesn't prevent DNS rebinding.
+func (l *loader) checkRepository(src string, transport transportOptions) ([]string, []string, error) {
+	policy, err := l.sourcePolicy()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "while reading source policy")
+	}
+
+	if err := policy.checkScheme(l.repositoryScheme(src)); err != nil {
+		return nil, nil, err
+	}
+	host, err := l.repositoryHost(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(host) > 0 {
+		if err := policy.checkHost(host); err != nil {
+			return nil, nil, err
+		}
+		if policy.blockPrivateNetworks {
+			if _, err := policy.resolve(context.Background(), host); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if len(transport.proxy) > 0 {
+		proxyURL, err := url.Parse(transport.proxy)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "while parsing proxy URL")
+		}
+		if err := policy.checkProxy(context.Background(), proxyURL); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// The variable overrides the one set in runGit, as the last value of a duplicated variable is used
+	env := []string{fmt.Sprintf("GIT_ALLOW_PROTOCOL=%s", policy.gitProtocols(l.gitAllowProtocol))}
+	if policy.restricted() || policy.maxRedirects == 0 {
+		return []string{"-c", "http.followRedirects=false"}, env, nil
+	}
+
+	return nil, env, nil
+}
+
+// repositoryScheme returns the protocol git uses for the repository: the URL scheme, the transport of
+// "<transport>::<address>" addresses, ssh for scp-like addresses, and file for local repositories
+func (l *loader) repositoryScheme(src string) string {
+	if separator := strings.Index(src, "::"); separator > 0 && !strings.ContainsAny(src[:separator], "/:") {
+		return strings.ToLower(src[:separator])
+	}
+	if separator := strings.Index(src, "://"); separator >= 0 {
+		switch scheme := strings.ToLower(src[:separator]); scheme {
+		case "git+ssh", "ssh+git":
+			return "ssh"
+		default:
+			return scheme
+		}
+	}
+
+	colon := strings.Index(src, ":")
+	if colon < 0 || strings.Contains(src[:colon], "/") {
+		return "file"
+	}
+
+	return "ssh"
+}
+
+// repositoryHost returns the host of URLs and scp-like addresses, and nothing for local repositories
+func (l *loader) repositoryHost(src string) (string, error) {
+	if strings.Contains(src, "://") {
+		parsed, err := url.Parse(src)
+		if err != nil {
+			return "", errors.Wrap(err, "while parsing repository URL")
+		}
+		return parsed.Hostname(), nil
+	}
+
+	colon := strings.Index(src, ":")
+	if colon < 0 || strings.Contains(src[:colon], "/") {
+		return "", nil
+	}
+
+	host := src[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+
+	return host, nil
+}
+
+// repositoryRoot returns the directory of the path in the repository. Symlinks are resolved before the path is checked,
+// as the repository can contain symlinks to directories outside of it.
+func (l *loader) repositoryRoot(repositoryDir, subPath string) (string, error) {
+	repositoryDir, err := filepath.EvalSymlinks(repositoryDir)
+	if err != nil {
+		return "", errors.Wrap(err, "while resolving repository directory")
+	}
+
+	root, err := filepath.EvalSymlinks(filepath.Join(repositoryDir, filepath.FromSlash(subPath)))
+	if err != nil {
+		return "", errors.Wrapf(err, "while resolving repository path %s", subPath)
+	}
+	if root != repositoryDir && !strings.HasPrefix(root, repositoryDir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("%s: illegal repository path", subPath)
+	}
+
+	return root, nil
+}
+
+func (l *loader) copyRepositoryFiles(src, dst string, filter matcher) ([]string, error) {
+	var filenames []string
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(relative)
+		if !filter.MatchString(name) {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return errors.Wrap(err, "while opening file")
+		}
+		defer file.Close()
+
+		if err := l.createFile(file, filepath.Join(dst, relative), int64(info.Mode().Perm())); err != nil {
+			return err
+		}
+		filenames = append(filenames, name)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while copying repository files")
+	}
+
+	return filenames, nil
+}
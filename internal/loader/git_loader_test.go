@@ -0,0 +1,270 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestLoader_Load_Git(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	repository, commits := createRepository(t)
+
+	for testName, testCase := range map[string]struct {
+		git      *v1beta1.AssetGitSource
+		filter   string
+		revision string
+		expected []string
+	}{
+		"DefaultBranch": {
+			revision: commits[1],
+			expected: []string{"README.md", "docs/guide.md", "docs/v2.md"},
+		},
+		"Branch": {
+			git:      &v1beta1.AssetGitSource{Ref: "master"},
+			revision: commits[1],
+			expected: []string{"README.md", "docs/guide.md", "docs/v2.md"},
+		},
+		"Tag": {
+			git:      &v1beta1.AssetGitSource{Ref: "v1"},
+			revision: commits[0],
+			expected: []string{"README.md", "docs/guide.md"},
+		},
+		"Commit": {
+			git:      &v1beta1.AssetGitSource{Ref: commits[0]},
+			revision: commits[0],
+			expected: []string{"README.md", "docs/guide.md"},
+		},
+		"Path": {
+			git:      &v1beta1.AssetGitSource{Path: "docs"},
+			revision: commits[1],
+			expected: []string{"guide.md", "v2.md"},
+		},
+		"Filter": {
+			filter:   "^docs/",
+			revision: commits[1],
+			expected: []string{"docs/guide.md", "docs/v2.md"},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{
+				temporaryDir:     os.TempDir(),
+				gitAllowProtocol: "file",
+				osRemoveAllFunc:  os.RemoveAll,
+				ioutilTempDir:    ioutil.TempDir,
+			}
+			source := v1beta1.AssetSource{URL: repository, Mode: v1beta1.AssetGit, Git: testCase.git, Filter: testCase.filter}
+
+			// When
+			result, err := loader.Load("", "asset", source)
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.Files).To(gomega.ConsistOf(testCase.expected))
+			g.Expect(result.Revision).To(gomega.Equal(testCase.revision))
+		})
+	}
+
+	t.Run("FailNotAllowedProtocol", func(t *testing.T) {
+		// Given
+		loader := &loader{
+			temporaryDir:     os.TempDir(),
+			gitAllowProtocol: "https",
+			osRemoveAllFunc:  os.RemoveAll,
+			ioutilTempDir:    ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: repository, Mode: v1beta1.AssetGit})
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("FailUnknownRef", func(t *testing.T) {
+		// Given
+		loader := &loader{
+			temporaryDir:     os.TempDir(),
+			gitAllowProtocol: "file",
+			osRemoveAllFunc:  os.RemoveAll,
+			ioutilTempDir:    ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: repository, Mode: v1beta1.AssetGit, Git: &v1beta1.AssetGitSource{Ref: "unknown"}})
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("FailIllegalPath", func(t *testing.T) {
+		// Given
+		loader := &loader{
+			temporaryDir:     os.TempDir(),
+			gitAllowProtocol: "file",
+			osRemoveAllFunc:  os.RemoveAll,
+			ioutilTempDir:    ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: repository, Mode: v1beta1.AssetGit, Git: &v1beta1.AssetGitSource{Path: "../"}})
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("FailSymlinkedPath", func(t *testing.T) {
+		// Given
+		loader := &loader{
+			temporaryDir:     os.TempDir(),
+			gitAllowProtocol: "file",
+			osRemoveAllFunc:  os.RemoveAll,
+			ioutilTempDir:    ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: repository, Mode: v1beta1.AssetGit, Git: &v1beta1.AssetGitSource{Path: "link/sa"}})
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(result.Files).To(gomega.BeEmpty())
+	})
+
+	t.Run("CleanOnError", func(t *testing.T) {
+		// Given
+		var dirs []string
+		loader := &loader{
+			temporaryDir:     os.TempDir(),
+			gitAllowProtocol: "file",
+			osRemoveAllFunc:  os.RemoveAll,
+			ioutilTempDir: func(dir, pattern string) (string, error) {
+				name, err := ioutil.TempDir(dir, pattern)
+				dirs = append(dirs, name)
+				return name, err
+			},
+		}
+
+		// When
+		_, err := loader.Load("", "asset", v1beta1.AssetSource{URL: repository, Mode: v1beta1.AssetGit, Git: &v1beta1.AssetGitSource{Path: "../"}})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(dirs).NotTo(gomega.BeEmpty())
+		for _, dir := range dirs {
+			g.Expect(dir).NotTo(gomega.BeADirectory())
+		}
+	})
+}
+
+func TestLoader_IsNamedRef(t *testing.T) {
+	repository, commits := createRepository(t)
+
+	for testName, testCase := range map[string]struct {
+		ref      string
+		expected bool
+	}{
+		"Branch": {
+			ref:      "master",
+			expected: true,
+		},
+		"NestedBranch": {
+			ref:      "feature/next",
+			expected: true,
+		},
+		"Tag": {
+			ref:      "v1",
+			expected: true,
+		},
+		"BranchSuffix": {
+			ref:      "next",
+			expected: false,
+		},
+		"Commit": {
+			ref:      commits[0],
+			expected: false,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{gitAllowProtocol: "file"}
+
+			// When
+			named, err := loader.isNamedRef(repository, testCase.ref, nil, nil)
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(named).To(gomega.Equal(testCase.expected))
+		})
+	}
+}
+
+func createRepository(t *testing.T) (string, []string) {
+	g := gomega.NewGomegaWithT(t)
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir, err := ioutil.TempDir("", "repository")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	workDir := filepath.Join(dir, "work")
+	bareDir := filepath.Join(dir, "bare.git")
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		output, err := cmd.CombinedOutput()
+		g.Expect(err).NotTo(gomega.HaveOccurred(), string(output))
+		return strings.TrimSpace(string(output))
+	}
+	write := func(name, content string) {
+		path := filepath.Join(workDir, name)
+		g.Expect(os.MkdirAll(filepath.Dir(path), os.ModePerm)).To(gomega.Succeed())
+		g.Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(gomega.Succeed())
+	}
+
+	g.Expect(os.MkdirAll(workDir, os.ModePerm)).To(gomega.Succeed())
+	git(workDir, "init", "--quiet")
+	git(workDir, "checkout", "--quiet", "-b", "master")
+
+	write("README.md", "readme")
+	write("docs/guide.md", "guide")
+	git(workDir, "add", "--all")
+	git(workDir, "commit", "--quiet", "-m", "first")
+	git(workDir, "tag", "v1")
+	git(workDir, "branch", "feature/next")
+	first := git(workDir, "rev-parse", "HEAD")
+
+	write("docs/v2.md", "v2")
+	// The symlink points outside of the repository, so the files under it can't be loaded
+	outsideDir := filepath.Join(dir, "outside")
+	g.Expect(os.MkdirAll(filepath.Join(outsideDir, "sa"), os.ModePerm)).To(gomega.Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(outsideDir, "sa", "token"), []byte("token"), 0644)).To(gomega.Succeed())
+	g.Expect(os.Symlink(outsideDir, filepath.Join(workDir, "link"))).To(gomega.Succeed())
+	git(workDir, "add", "--all")
+	git(workDir, "commit", "--quiet", "-m", "second")
+	second := git(workDir, "rev-parse", "HEAD")
+
+	git(dir, "clone", "--quiet", "--bare", workDir, bareDir)
+
+	return "file://" + bareDir, []string{first, second}
+}
@@ -0,0 +1,372 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+type index struct {
+	entries            []indexEntry
+	etag, lastModified string
+	digest             string
+}
+
+type indexEntry struct {
+	name, url string
+	options   requestOptions
+}
+
+// entryCheckCache records when the entries of the indexes were last found unchanged, as every entry takes a request
+type entryCheckCache struct {
+	mu      sync.Mutex
+	checked map[string]time.Time
+}
+
+func (c *entryCheckCache) due(key string, interval time.Duration, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	checked, ok := c.checked[key]
+	return !ok || now.Sub(checked) >= interval
+}
+
+func (c *entryCheckCache) record(key string, interval time.Duration, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.checked == nil {
+		c.checked = make(map[string]time.Time)
+	}
+	for other, checked := range c.checked {
+		if now.Sub(checked) >= interval {
+			delete(c.checked, other)
+		}
+	}
+	c.checked[key] = now
+}
+
+func (l *loader) loadIndex(src, name, filter string, options requestOptions) (Result, error) {
+	basePath, err := l.ioutilTempDir(l.temporaryDir, name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	index, err := l.readFilteredIndex(src, filter, options)
+	if err != nil {
+		return Result{}, err
+	}
+
+	refs := newEntryRefs()
+	if err := l.processIndexEntries(index.entries, func(entry indexEntry) error {
+		header, digest, err := l.downloadIndexEntry(basePath, entry)
+		if err != nil {
+			return err
+		}
+		refs.add(entry, header, digest)
+		return nil
+	}); err != nil {
+		return Result{}, err
+	}
+
+	result := index.result(refs, l.maxIndexEntryRefs)
+	result.BasePath = basePath
+
+	return result, nil
+}
+
+func (l *loader) readFilteredIndex(src, filter string, options requestOptions) (index, error) {
+	filterRegexp, err := regexp.Compile(filter)
+	if err != nil {
+		return index{}, errors.Wrapf(err, "while compiling filter")
+	}
+
+	result, err := l.readIndex(src, options)
+	if err != nil {
+		return index{}, err
+	}
+
+	var filtered []indexEntry
+	for _, entry := range result.entries {
+		if !filterRegexp.MatchString(entry.name) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	result.entries = filtered
+
+	return result, nil
+}
+
+// result lists the loaded files with the validators of the entries, which are skipped if there are more of them
+// than the status should keep
+func (i index) result(refs *entryRefs, maxEntryRefs int) Result {
+	files := make([]string, 0, len(i.entries))
+	var entries []v1beta1.AssetEntryRef
+	for _, entry := range i.entries {
+		files = append(files, entry.name)
+	}
+	if maxEntryRefs == 0 || len(i.entries) <= maxEntryRefs {
+		entries = make([]v1beta1.AssetEntryRef, 0, len(i.entries))
+		for _, entry := range i.entries {
+			entries = append(entries, refs.get(entry))
+		}
+	}
+
+	return Result{
+		Files:        files,
+		ETag:         i.etag,
+		LastModified: i.lastModified,
+		Digest:       i.digest,
+		Entries:      entries,
+	}
+}
+
+// entryRefs collects the validators of the index entries, which are downloaded concurrently
+type entryRefs struct {
+	mu   sync.Mutex
+	refs map[string]v1beta1.AssetEntryRef
+}
+
+func newEntryRefs() *entryRefs {
+	return &entryRefs{refs: make(map[string]v1beta1.AssetEntryRef)}
+}
+
+func (r *entryRefs) add(entry indexEntry, header http.Header, digest string) {
+	ref := v1beta1.AssetEntryRef{
+		URL:          entry.url,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+	}
+	if len(ref.ETag) == 0 && len(ref.LastModified) == 0 {
+		ref.Digest = digest
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refs[entry.name] = ref
+}
+
+func (r *entryRefs) get(entry indexEntry) v1beta1.AssetEntryRef {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.refs[entry.name]
+}
+
+func (l *loader) readIndex(src string, options requestOptions) (index, error) {
+	baseURL, err := url.Parse(src)
+	if err != nil {
+		return index{}, errors.Wrap(err, "while parsing index URL")
+	}
+
+	response, err := l.get(src, options)
+	if err != nil {
+		return index{}, errors.Wrap(err, "while downloading index")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return index{}, errors.New(response.Status)
+	}
+
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return index{}, errors.Wrap(err, "while reading index")
+	}
+
+	var locations []string
+	if err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), len(content)).Decode(&locations); err != nil {
+		return index{}, errors.Wrap(err, "while decoding index")
+	}
+
+	entries := make([]indexEntry, 0, len(locations))
+	names := make(map[string]struct{}, len(locations))
+	for _, location := range locations {
+		entry, err := l.indexEntry(baseURL, location, options)
+		if err != nil {
+			return index{}, err
+		}
+
+		if _, exists := names[entry.name]; exists {
+			return index{}, fmt.Errorf("%s: duplicated file in index", entry.name)
+		}
+		names[entry.name] = struct{}{}
+
+		entries = append(entries, entry)
+	}
+
+	return index{
+		entries:      entries,
+		etag:         response.Header.Get("ETag"),
+		lastModified: response.Header.Get("Last-Modified"),
+		digest:       l.digest(content),
+	}, nil
+}
+
+func (l *loader) indexEntry(base *url.URL, location string, options requestOptions) (indexEntry, error) {
+	reference, err := url.Parse(location)
+	if err != nil {
+		return indexEntry{}, errors.Wrapf(err, "while parsing index entry %s", location)
+	}
+	resolved := base.ResolveReference(reference)
+
+	var name string
+	baseDir := path.Dir(base.Path) + "/"
+	switch {
+	case !reference.IsAbs():
+		name = path.Clean(reference.Path)
+	case resolved.Host == base.Host && strings.HasPrefix(resolved.Path, baseDir):
+		name = strings.TrimPrefix(resolved.Path, baseDir)
+	default:
+		name = path.Join(resolved.Host, resolved.Path)
+	}
+	name = strings.TrimPrefix(name, "/")
+
+	if name == "" || name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return indexEntry{}, fmt.Errorf("%s: illegal file path", location)
+	}
+
+	entry := indexEntry{name: name, url: resolved.String(), options: options.withHeader(nil)}
+	// credentials are sent only to the host serving the index
+	if resolved.Host == base.Host {
+		entry.options = options
+	}
+
+	return entry, nil
+}
+
+func (l *loader) processIndexEntries(entries []indexEntry, process func(entry indexEntry) error) error {
+	entryChan := make(chan indexEntry, len(entries))
+	for _, entry := range entries {
+		entryChan <- entry
+	}
+	close(entryChan)
+
+	errChan := make(chan error, len(entries))
+	var waitGroup sync.WaitGroup
+	for i := 0; i < l.indexWorkers(); i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for entry := range entryChan {
+				if err := process(entry); err != nil {
+					errChan <- errors.Wrapf(err, "while downloading %s", entry.url)
+				}
+			}
+		}()
+	}
+	waitGroup.Wait()
+	close(errChan)
+
+	var errorMessages []string
+	for err := range errChan {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if len(errorMessages) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(errorMessages, "\n"))
+}
+
+// downloadIndexEntry downloads the entry and returns its response header and digest
+func (l *loader) downloadIndexEntry(basePath string, entry indexEntry) (http.Header, string, error) {
+	destination := filepath.Join(basePath, filepath.FromSlash(entry.name))
+	if err := l.createDir(filepath.Dir(destination)); err != nil {
+		return nil, "", errors.Wrap(err, "while creating directory")
+	}
+
+	header, err := l.download(destination, entry.url, entry.options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	digest, err := l.digestFile(destination)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return header, digest, nil
+}
+
+// indexChanged checks the index and then each of the loaded entries, as the files can change while the index does not.
+// The entries are checked again only after the entries interval, as every one of them takes a request.
+func (l *loader) indexChanged(src string, options requestOptions, ref v1beta1.AssetStatusRef) (bool, error) {
+	changed, err := l.remoteChanged(src, options, ref)
+	if err != nil || changed || len(ref.Entries) == 0 {
+		return changed, err
+	}
+
+	key := src + " " + l.entriesDigest(ref.Entries)
+	now := time.Now()
+	if !l.entryChecks.due(key, l.indexEntriesInterval, now) {
+		return false, nil
+	}
+
+	baseURL, err := url.Parse(src)
+	if err != nil {
+		return false, errors.Wrap(err, "while parsing index URL")
+	}
+
+	entries := make([]indexEntry, 0, len(ref.Entries))
+	validators := make(map[string]v1beta1.AssetStatusRef, len(ref.Entries))
+	for _, entryRef := range ref.Entries {
+		entry, err := l.indexEntry(baseURL, entryRef.URL, options)
+		if err != nil {
+			return false, err
+		}
+		entries = append(entries, entry)
+		validators[entry.url] = v1beta1.AssetStatusRef{ETag: entryRef.ETag, LastModified: entryRef.LastModified, Digest: entryRef.Digest}
+	}
+
+	var changedCount int32
+	err = l.processIndexEntries(entries, func(entry indexEntry) error {
+		changed, err := l.remoteChanged(entry.url, entry.options, validators[entry.url])
+		if changed {
+			atomic.AddInt32(&changedCount, 1)
+		}
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if atomic.LoadInt32(&changedCount) > 0 {
+		return true, nil
+	}
+	l.entryChecks.record(key, l.indexEntriesInterval, now)
+
+	return false, nil
+}
+
+// entriesDigest identifies the validators of the entries, so the entries loaded at different times are checked separately
+func (l *loader) entriesDigest(entries []v1beta1.AssetEntryRef) string {
+	values := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		values[entry.URL] = []byte(strings.Join([]string{entry.ETag, entry.LastModified, entry.Digest}, "\n"))
+	}
+
+	return l.dataDigest(values)
+}
+
+func (l *loader) indexWorkers() int {
+	if l.indexWorkersCount < 1 {
+		return 1
+	}
+
+	return l.indexWorkersCount
+}
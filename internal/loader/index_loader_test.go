@@ -0,0 +1,201 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestLoader_Load_Index(t *testing.T) {
+	jsonIndex := `["README.md", "docs/guide.md", "https://cdn.example.com/assets/logo.svg", "https://other.example.com/style.css"]`
+	yamlIndex := "- README.md\n- docs/guide.md\n- https://cdn.example.com/assets/logo.svg\n- https://other.example.com/style.css\n"
+
+	expected := []string{
+		"README.md",
+		"docs/guide.md",
+		"logo.svg",
+		"other.example.com/style.css",
+	}
+
+	for testName, testCase := range map[string]struct {
+		index string
+	}{
+		"JSON": {
+			index: jsonIndex,
+		},
+		"YAML": {
+			index: yamlIndex,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{
+				temporaryDir:      "/tmp",
+				indexWorkersCount: 2,
+				osRemoveAllFunc:   os.RemoveAll,
+				osCreateFunc:      os.Create,
+				httpDoFunc: getContent(map[string]string{
+					"https://cdn.example.com/assets/index.json":    testCase.index,
+					"https://cdn.example.com/assets/README.md":     "readme",
+					"https://cdn.example.com/assets/docs/guide.md": "guide",
+					"https://cdn.example.com/assets/logo.svg":      "<svg/>",
+					"https://other.example.com/style.css":          "body {}",
+				}),
+				ioutilTempDir: ioutil.TempDir,
+			}
+
+			// When
+			result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "https://cdn.example.com/assets/index.json", Mode: v1beta1.AssetIndex})
+			basePath, files := result.BasePath, result.Files
+			defer loader.Clean(basePath)
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(files).To(gomega.ConsistOf(expected))
+			content, err := ioutil.ReadFile(basePath + "/docs/guide.md")
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(string(content)).To(gomega.Equal("guide"))
+		})
+	}
+
+	t.Run("WithFilter", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc: getContent(map[string]string{
+				"https://cdn.example.com/index.yaml": "- README.md\n- swagger.json\n",
+				"https://cdn.example.com/README.md":  "readme",
+			}),
+			ioutilTempDir: ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "https://cdn.example.com/index.yaml", Mode: v1beta1.AssetIndex, Filter: "\\.md$"})
+		basePath, files := result.BasePath, result.Files
+		defer loader.Clean(basePath)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(files).To(gomega.ConsistOf("README.md"))
+	})
+
+	t.Run("TooManyEntryRefs", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:      "/tmp",
+			maxIndexEntryRefs: 1,
+			osRemoveAllFunc:   os.RemoveAll,
+			osCreateFunc:      os.Create,
+			httpDoFunc: getContent(map[string]string{
+				"https://cdn.example.com/index.yaml": "- README.md\n- guide.md\n",
+				"https://cdn.example.com/README.md":  "readme",
+				"https://cdn.example.com/guide.md":   "guide",
+			}),
+			ioutilTempDir: ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "https://cdn.example.com/index.yaml", Mode: v1beta1.AssetIndex})
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Files).To(gomega.ConsistOf("README.md", "guide.md"))
+		g.Expect(result.Entries).To(gomega.BeEmpty())
+	})
+
+	t.Run("FailIllegalPath", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc: getContent(map[string]string{
+				"https://cdn.example.com/docs/index.yaml": "- ../secret.md\n",
+			}),
+			ioutilTempDir: ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "https://cdn.example.com/docs/index.yaml", Mode: v1beta1.AssetIndex})
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("FailInvalidIndex", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc: getContent(map[string]string{
+				"https://cdn.example.com/index.yaml": "files: README.md",
+			}),
+			ioutilTempDir: ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "https://cdn.example.com/index.yaml", Mode: v1beta1.AssetIndex})
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("FailDownload", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc: getContent(map[string]string{
+				"https://cdn.example.com/index.yaml": "- README.md\n- missing.md\n",
+				"https://cdn.example.com/README.md":  "readme",
+			}),
+			ioutilTempDir: ioutil.TempDir,
+		}
+
+		// When
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "https://cdn.example.com/index.yaml", Mode: v1beta1.AssetIndex})
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(err.Error()).To(gomega.ContainSubstring("missing.md"))
+	})
+}
+
+func getContent(contents map[string]string) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		content, ok := contents[req.URL.String()]
+		if !ok {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     fmt.Sprintf("%d %s", http.StatusNotFound, http.StatusText(http.StatusNotFound)),
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			ContentLength: int64(len(content)),
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte(content))),
+		}, nil
+	}
+}
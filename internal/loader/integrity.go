@@ -0,0 +1,270 @@
+package loader
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+const (
+	publicKeyDefaultKey  = "publicKey"
+	signatureMaxSize     = 64 * 1024
+	checksumSeparator    = ":"
+	checksumSHA256Prefix = "sha256"
+	checksumSHA512Prefix = "sha512"
+)
+
+// IntegrityError means that the downloaded content does not match the checksum or the signature
+type IntegrityError struct {
+	message string
+}
+
+func (e *IntegrityError) Error() string {
+	return e.message
+}
+
+func IsIntegrityError(err error) bool {
+	_, ok := errors.Cause(err).(*IntegrityError)
+	return ok
+}
+
+func (l *loader) verifyIntegrity(namespace string, source v1beta1.AssetSource, options requestOptions, path string) error {
+	if len(source.Checksum) > 0 {
+		if err := l.verifyChecksum(path, source.Checksum); err != nil {
+			return errors.Wrap(err, "while verifying checksum")
+		}
+	}
+
+	if source.Signature != nil {
+		if err := l.verifySignature(namespace, source, options, path); err != nil {
+			return errors.Wrap(err, "while verifying signature")
+		}
+	}
+
+	return nil
+}
+
+func (l *loader) verifyChecksum(path string, checksum v1beta1.AssetChecksum) error {
+	parts := strings.SplitN(string(checksum), checksumSeparator, 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid checksum format %s", checksum)
+	}
+
+	var digest hash.Hash
+	switch strings.ToLower(parts[0]) {
+	case checksumSHA256Prefix:
+		digest = sha256.New()
+	case checksumSHA512Prefix:
+		digest = sha512.New()
+	default:
+		return fmt.Errorf("not supported checksum algorithm %s", parts[0])
+	}
+
+	if err := l.hashFile(path, digest); err != nil {
+		return err
+	}
+
+	actual := hex.EncodeToString(digest.Sum(nil))
+	if !strings.EqualFold(actual, parts[1]) {
+		return &IntegrityError{message: fmt.Sprintf("checksum mismatch, expected %s, got %s%s%s", parts[1], parts[0], checksumSeparator, actual)}
+	}
+
+	return nil
+}
+
+func (l *loader) verifySignature(namespace string, source v1beta1.AssetSource, options requestOptions, path string) error {
+	publicKey, err := l.publicKey(namespace, source.Signature.PublicKeyRef)
+	if err != nil {
+		return err
+	}
+
+	signature, err := l.downloadSignature(source.URL, source.Signature.URL, options)
+	if err != nil {
+		return errors.Wrap(err, "while downloading signature")
+	}
+
+	var valid bool
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		digest, err := l.sha256File(path)
+		if err != nil {
+			return err
+		}
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil ||
+			rsa.VerifyPSS(key, crypto.SHA256, digest, signature, nil) == nil
+	case *ecdsa.PublicKey:
+		digest, err := l.sha256File(path)
+		if err != nil {
+			return err
+		}
+		valid = ecdsa.VerifyASN1(key, digest, signature)
+	case ed25519.PublicKey:
+		content, err := l.readSignedFile(path)
+		if err != nil {
+			return err
+		}
+		valid = ed25519.Verify(key, content, signature)
+	default:
+		return fmt.Errorf("not supported public key type %T", publicKey)
+	}
+
+	if !valid {
+		return &IntegrityError{message: "invalid signature"}
+	}
+
+	return nil
+}
+
+// downloadSignature downloads a detached signature, which can be stored either in the raw or in the base64 format.
+// The location is resolved relative to the asset URL and credentials are sent only to the host serving the asset.
+func (l *loader) downloadSignature(src, location string, options requestOptions) ([]byte, error) {
+	base, err := url.Parse(src)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing asset URL")
+	}
+	reference, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing signature URL")
+	}
+	resolved := base.ResolveReference(reference)
+	if resolved.Host != base.Host {
+		options = options.withHeader(nil)
+	}
+
+	response, err := l.get(resolved.String(), options)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, errors.New(response.Status)
+	}
+
+	content, err := ioutil.ReadAll(io.LimitReader(response.Body, signatureMaxSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading signature")
+	}
+
+	if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(content))); err == nil {
+		return decoded, nil
+	}
+
+	return content, nil
+}
+
+func (l *loader) publicKey(namespace string, ref v1beta1.AssetPublicKeyRef) (crypto.PublicKey, error) {
+	if len(ref.Key) == 0 {
+		ref.Key = publicKeyDefaultKey
+	}
+
+	content, refNamespace, err := l.referencedData(namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%s %s from %s namespace does not contain a PEM encoded public key under %s key", ref.Kind, ref.Name, refNamespace, ref.Key)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing public key")
+	}
+
+	return publicKey, nil
+}
+
+// referencedData returns the value stored under the key of the referenced Secret or ConfigMap, and its namespace
+func (l *loader) referencedData(namespace string, ref v1beta1.AssetPublicKeyRef) ([]byte, string, error) {
+	refNamespace, err := l.referenceNamespace(namespace, string(ref.Kind), ref.Name, ref.Namespace)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch ref.Kind {
+	case v1beta1.AssetPublicKeySecret:
+		secret, err := l.getSecret(refNamespace, ref.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		return secret.Data[ref.Key], refNamespace, nil
+	case v1beta1.AssetPublicKeyConfigMap:
+		configMap, err := l.getConfigMap(refNamespace, ref.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if content := configMap.Data[ref.Key]; len(content) > 0 {
+			return []byte(content), refNamespace, nil
+		}
+		return configMap.BinaryData[ref.Key], refNamespace, nil
+	default:
+		return nil, "", fmt.Errorf("not supported reference kind %s", ref.Kind)
+	}
+}
+
+// readSignedFile reads the whole file, as Ed25519 signatures are computed over the content itself. Files exceeding
+// the size limit of files are rejected, so the content can't exhaust the memory.
+func (l *loader) readSignedFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while opening file")
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if l.maxFileSize > 0 {
+		reader = io.LimitReader(file, l.maxFileSize+1)
+	}
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading file")
+	}
+	if l.maxFileSize > 0 && int64(len(content)) > l.maxFileSize {
+		return nil, &IntegrityError{message: fmt.Sprintf("file exceeds %d bytes, which is the limit of content verified with Ed25519 signatures", l.maxFileSize)}
+	}
+
+	return content, nil
+}
+
+func (l *loader) sha256File(path string) ([]byte, error) {
+	digest := sha256.New()
+	if err := l.hashFile(path, digest); err != nil {
+		return nil, err
+	}
+
+	return digest.Sum(nil), nil
+}
+
+func (l *loader) hashFile(path string, digest hash.Hash) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "while opening file")
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(digest, file); err != nil {
+		return errors.Wrap(err, "while reading file")
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package loader
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestLoader_Load_Integrity(t *testing.T) {
+	content := []byte("ala ma kota")
+	sha256Sum := sha256.Sum256(content)
+	sha512Sum := sha512.Sum512(content)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, sha256Sum[:])
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, sha256Sum[:])
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+
+	ed25519PublicKey, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+	ed25519Signature := ed25519.Sign(ed25519Key, content)
+
+	fakedc, err := newFakeDynamicClient(
+		fixSecret("rsa", "default", map[string][]byte{"publicKey": fixPublicKey(t, &rsaKey.PublicKey)}),
+		fixConfigMap("ecdsa", "default", map[string]string{"key.pem": string(fixPublicKey(t, &ecdsaKey.PublicKey))}, nil),
+		fixConfigMap("ed25519", "default", nil, map[string][]byte{"publicKey": fixPublicKey(t, ed25519PublicKey)}),
+		fixSecret("invalid", "default", map[string][]byte{"publicKey": []byte("not a key")}),
+	)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+
+	contents := map[string]string{
+		"https://example.com/spec.json":             string(content),
+		"https://example.com/spec.json.rsa.sig":     string(rsaSignature),
+		"https://example.com/spec.json.ecdsa.sig":   base64.StdEncoding.EncodeToString(ecdsaSignature) + "\n",
+		"https://example.com/spec.json.ed25519.sig": string(ed25519Signature),
+	}
+
+	for testName, testCase := range map[string]struct {
+		checksum  v1beta1.AssetChecksum
+		signature *v1beta1.AssetSignature
+		fail      bool
+		integrity bool
+	}{
+		"SHA256": {
+			checksum: v1beta1.AssetChecksum("sha256:" + hex.EncodeToString(sha256Sum[:])),
+		},
+		"SHA512": {
+			checksum: v1beta1.AssetChecksum("sha512:" + hex.EncodeToString(sha512Sum[:])),
+		},
+		"RSASignature": {
+			signature: &v1beta1.AssetSignature{
+				URL:          "spec.json.rsa.sig",
+				PublicKeyRef: v1beta1.AssetPublicKeyRef{Kind: v1beta1.AssetPublicKeySecret, Name: "rsa"},
+			},
+		},
+		"ECDSASignature": {
+			signature: &v1beta1.AssetSignature{
+				URL:          "https://example.com/spec.json.ecdsa.sig",
+				PublicKeyRef: v1beta1.AssetPublicKeyRef{Kind: v1beta1.AssetPublicKeyConfigMap, Name: "ecdsa", Key: "key.pem"},
+			},
+		},
+		"ED25519Signature": {
+			checksum: v1beta1.AssetChecksum("sha256:" + hex.EncodeToString(sha256Sum[:])),
+			signature: &v1beta1.AssetSignature{
+				URL:          "spec.json.ed25519.sig",
+				PublicKeyRef: v1beta1.AssetPublicKeyRef{Kind: v1beta1.AssetPublicKeyConfigMap, Name: "ed25519"},
+			},
+		},
+		"FailChecksumMismatch": {
+			fail:      true,
+			checksum:  v1beta1.AssetChecksum("sha256:" + hex.EncodeToString(sha512Sum[:32])),
+			integrity: true,
+		},
+		"FailSignatureMismatch": {
+			fail: true,
+			signature: &v1beta1.AssetSignature{
+				URL:          "spec.json.ecdsa.sig",
+				PublicKeyRef: v1beta1.AssetPublicKeyRef{Kind: v1beta1.AssetPublicKeySecret, Name: "rsa"},
+			},
+			integrity: true,
+		},
+		"FailNotSupportedAlgorithm": {
+			fail:     true,
+			checksum: "md5:d41d8cd98f00b204e9800998ecf8427e",
+		},
+		"FailMissingSignature": {
+			fail: true,
+			signature: &v1beta1.AssetSignature{
+				URL:          "spec.json.missing.sig",
+				PublicKeyRef: v1beta1.AssetPublicKeyRef{Kind: v1beta1.AssetPublicKeySecret, Name: "rsa"},
+			},
+		},
+		"FailInvalidPublicKey": {
+			fail: true,
+			signature: &v1beta1.AssetSignature{
+				URL:          "spec.json.rsa.sig",
+				PublicKeyRef: v1beta1.AssetPublicKeyRef{Kind: v1beta1.AssetPublicKeySecret, Name: "invalid"},
+			},
+		},
+		"FailPublicKeyFromOtherNamespace": {
+			fail: true,
+			signature: &v1beta1.AssetSignature{
+				URL:          "spec.json.rsa.sig",
+				PublicKeyRef: v1beta1.AssetPublicKeyRef{Kind: v1beta1.AssetPublicKeySecret, Name: "rsa", Namespace: "other"},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{
+				temporaryDir:    "/tmp",
+				dynamicClient:   fakedc,
+				osRemoveAllFunc: os.RemoveAll,
+				osCreateFunc:    os.Create,
+				httpDoFunc:      getContent(contents),
+				ioutilTempDir:   ioutil.TempDir,
+			}
+			source := v1beta1.AssetSource{
+				URL:       "https://example.com/spec.json",
+				Mode:      v1beta1.AssetSingle,
+				Checksum:  testCase.checksum,
+				Signature: testCase.signature,
+			}
+
+			// When
+			result, err := loader.Load("default", "asset", source)
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			if testCase.fail {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(IsIntegrityError(err)).To(gomega.Equal(testCase.integrity))
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.Files).To(gomega.ConsistOf("spec.json"))
+		})
+	}
+
+	t.Run("FailED25519SignatureOfLargeFile", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			dynamicClient:   fakedc,
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc:      getContent(contents),
+			ioutilTempDir:   ioutil.TempDir,
+			maxFileSize:     int64(len(content) - 1),
+		}
+		source := v1beta1.AssetSource{
+			URL:  "https://example.com/spec.json",
+			Mode: v1beta1.AssetSingle,
+			Signature: &v1beta1.AssetSignature{
+				URL:          "spec.json.ed25519.sig",
+				PublicKeyRef: v1beta1.AssetPublicKeyRef{Kind: v1beta1.AssetPublicKeyConfigMap, Name: "ed25519"},
+			},
+		}
+
+		// When
+		result, err := loader.Load("default", "asset", source)
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(IsIntegrityError(err)).To(gomega.BeTrue())
+	})
+
+	t.Run("PackageVerifiedBeforeUnpacking", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc:      getFile("./testdata/structure.zip"),
+			ioutilTempDir:   ioutil.TempDir,
+		}
+		source := v1beta1.AssetSource{
+			URL:      "https://example.com/structure.zip",
+			Mode:     v1beta1.AssetPackage,
+			Checksum: v1beta1.AssetChecksum("sha256:" + hex.EncodeToString(sha256Sum[:])),
+		}
+
+		// When
+		result, err := loader.Load("default", "asset", source)
+		defer loader.Clean(result.BasePath)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(IsIntegrityError(err)).To(gomega.BeTrue())
+		g.Expect(result.Files).To(gomega.BeEmpty())
+	})
+
+	t.Run("FailNotSupportedMode", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			temporaryDir:    "/tmp",
+			osRemoveAllFunc: os.RemoveAll,
+			osCreateFunc:    os.Create,
+			httpDoFunc:      getContent(contents),
+			ioutilTempDir:   ioutil.TempDir,
+		}
+		source := v1beta1.AssetSource{
+			URL:      "https://example.com/index.yaml",
+			Mode:     v1beta1.AssetIndex,
+			Checksum: v1beta1.AssetChecksum("sha256:" + hex.EncodeToString(sha256Sum[:])),
+		}
+
+		// When
+		_, err := loader.Load("default", "asset", source)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(IsIntegrityError(err)).To(gomega.BeFalse())
+	})
+}
+
+func fixPublicKey(t *testing.T, key crypto.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
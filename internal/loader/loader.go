@@ -1,7 +1,6 @@
 package loader
 
 import (
-	"crypto/tls"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -10,92 +9,222 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/pkg/errors"
 )
 
 type loader struct {
-	temporaryDir  string
-	dynamicClient dynamic.Interface
+	temporaryDir      string
+	dynamicClient     dynamic.Interface
+	verifySSL         bool
+	indexWorkersCount int
+	gitAllowProtocol  string
+
+	indexEntriesInterval time.Duration
+	maxIndexEntryRefs    int
+
+	maxUncompressedSize int64
+	maxFileSize         int64
+	maxFileCount        int
+	symlinkPolicy       string
+	requestTimeout      time.Duration
+	maxRetries          int
+	retryBackoff        time.Duration
+
+	policy      sourcePolicy
+	policies    sourcePolicyCache
+	transports  transportCache
+	entryChecks entryCheckCache
 
 	// for testing
 	osRemoveAllFunc func(string) error
 	osCreateFunc    func(name string) (*os.File, error)
-	httpGetFunc     func(url string) (*http.Response, error)
+	httpDoFunc      func(req *http.Request) (*http.Response, error)
 	ioutilTempDir   func(dir, prefix string) (string, error)
 }
 
+type Result struct {
+	BasePath     string
+	Files        []string
+	Revision     string
+	ETag         string
+	LastModified string
+	Digest       string
+	Entries      []v1beta1.AssetEntryRef
+}
+
+// Sink receives the files of a streamed asset. Put has to read exactly size bytes from the reader.
+type Sink interface {
+	Put(name string, reader io.Reader, size int64) error
+	Copy(source, name string) error
+}
+
 //go:generate mockery -name=Loader -output=automock -outpkg=automock -case=underscore
 type Loader interface {
-	Load(src, assetName string, mode v1beta1.AssetMode, filter string) (string, []string, error)
+	Load(namespace, assetName string, source v1beta1.AssetSource) (Result, error)
+	Streamable(source v1beta1.AssetSource) bool
+	Stream(namespace, assetName string, source v1beta1.AssetSource, sink Sink) (Result, error)
+	Changed(namespace string, source v1beta1.AssetSource, ref v1beta1.AssetStatusRef) (bool, error)
 	Clean(path string) error
 }
 
-func New(dynamicClient dynamic.Interface, temporaryDir string, verifySSL bool) Loader {
+func New(dynamicClient dynamic.Interface, cfg Config) Loader {
+	temporaryDir := cfg.TemporaryDirectory
 	if len(temporaryDir) == 0 {
 		temporaryDir = os.TempDir()
 	}
 
-	if !verifySSL {
-		cfg := &tls.Config{
-			InsecureSkipVerify: true,
-		}
-		http.DefaultClient.Transport = &http.Transport{
-			TLSClientConfig: cfg,
-		}
-	}
-
-	return &loader{
-		temporaryDir:    temporaryDir,
-		dynamicClient:   dynamicClient,
+	l := &loader{
+		temporaryDir:         temporaryDir,
+		dynamicClient:        dynamicClient,
+		verifySSL:            cfg.VerifySSL,
+		indexWorkersCount:    cfg.IndexWorkersCount,
+		gitAllowProtocol:     cfg.GitAllowProtocol,
+		indexEntriesInterval: cfg.IndexEntriesInterval,
+		maxIndexEntryRefs:    cfg.MaxIndexEntryRefs,
+		maxUncompressedSize:  cfg.MaxUncompressedSize,
+		maxFileSize:          cfg.MaxFileSize,
+		maxFileCount:         cfg.MaxFileCount,
+		symlinkPolicy:        cfg.SymlinkPolicy,
+		requestTimeout:       cfg.RequestTimeout,
+		maxRetries:           cfg.MaxRetries,
+		retryBackoff:         cfg.RetryBackoff,
+		policy: sourcePolicy{
+			rules:                []sourceRule{newSourceRule(configurationOrigin, cfg.AllowedSchemes, cfg.AllowedHosts, cfg.DeniedHosts)},
+			blockPrivateNetworks: cfg.BlockPrivateNetworks,
+			maxRedirects:         cfg.MaxRedirects,
+		},
 		osRemoveAllFunc: os.RemoveAll,
 		osCreateFunc:    os.Create,
-		httpGetFunc:     http.Get,
 		ioutilTempDir:   ioutil.TempDir,
 	}
+	l.httpDoFunc = l.newHTTPClient().Do
+
+	return l
 }
 
-func (l *loader) Load(src, assetName string, mode v1beta1.AssetMode, filter string) (string, []string, error) {
-	switch mode {
+// newHTTPClient returns the client which enforces the source policy on redirects, and uses the connection settings of the source
+func (l *loader) newHTTPClient() *http.Client {
+	return &http.Client{
+		Transport:     roundTripperFunc(l.roundTrip),
+		CheckRedirect: l.checkRedirect,
+	}
+}
+
+func (l *loader) Load(namespace, assetName string, source v1beta1.AssetSource) (Result, error) {
+	header, err := l.credentials(namespace, source.SecretRef)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "while reading credentials")
+	}
+
+	if err := l.checkOptions(source); err != nil {
+		return Result{}, err
+	}
+	options, err := l.requestOptions(namespace, source, header)
+	if err != nil {
+		return Result{}, err
+	}
+	verify := func(path string) error {
+		return l.verifyIntegrity(namespace, source, options, path)
+	}
+
+	var result Result
+	switch source.Mode {
 	case v1beta1.AssetSingle:
-		return l.loadSingle(src, assetName)
+		result, err = l.loadSingle(source.URL, assetName, options, verify)
 	case v1beta1.AssetPackage:
-		return l.loadPackage(src, assetName, filter)
+		result, err = l.loadPackage(assetName, source, options, verify)
+	case v1beta1.AssetIndex:
+		result, err = l.loadIndex(source.URL, assetName, source.Filter, options)
 	case v1beta1.AssetConfigMap:
-		return l.loadConfigMap(src, assetName, filter)
+		result, err = l.loadConfigMap(namespace, source.URL, assetName, source.Filter)
+	case v1beta1.AssetSecret:
+		result, err = l.loadSecret(namespace, source.URL, assetName, source.Filter)
+	case v1beta1.AssetGit:
+		result, err = l.loadGit(source.URL, assetName, source.Git, source.Filter, header, options.transport)
+	default:
+		err = fmt.Errorf("not supported source mode %+v", source.Mode)
 	}
 
-	return "", nil, fmt.Errorf("not supported source mode %+v", mode)
+	return result, err
+}
+
+func (l *loader) checkOptions(source v1beta1.AssetSource) error {
+	if (len(source.Checksum) > 0 || source.Signature != nil) && source.Mode != v1beta1.AssetSingle && source.Mode != v1beta1.AssetPackage {
+		return fmt.Errorf("integrity verification is not supported in %s mode", source.Mode)
+	}
+	if (source.StripComponents > 0 || len(source.TargetPrefix) > 0) && source.Mode != v1beta1.AssetPackage {
+		return fmt.Errorf("path rewriting is not supported in %s mode", source.Mode)
+	}
+	if prefix := path.Clean(source.TargetPrefix); len(source.TargetPrefix) > 0 && (path.IsAbs(prefix) || prefix == ".." || strings.HasPrefix(prefix, "../")) {
+		return fmt.Errorf("%s: illegal target prefix", source.TargetPrefix)
+	}
+
+	return nil
 }
 
 func (l *loader) Clean(path string) error {
 	return l.osRemoveAllFunc(path)
 }
 
-func (l *loader) download(destination, source string) error {
+func (l *loader) download(destination, source string, options requestOptions) (http.Header, error) {
 	file, err := l.osCreateFunc(destination)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer file.Close()
 
-	response, err := l.httpGetFunc(source)
+	response, err := l.open(source, options)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer response.Body.Close()
 
+	_, err = io.Copy(file, response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Header, nil
+}
+
+// open sends the request and returns the response if it is successful. Reading of the response is resumed if it fails.
+func (l *loader) open(source string, options requestOptions) (*http.Response, error) {
+	response, err := l.get(source, options)
+	if err != nil {
+		return nil, err
+	}
+
 	if response.StatusCode < 200 || response.StatusCode > 299 {
-		return errors.New(response.Status)
+		response.Body.Close()
+		return nil, errors.New(response.Status)
 	}
+	response.Body = l.newResumableBody(source, options, response)
 
-	_, err = io.Copy(file, response.Body)
+	return response, nil
+}
+
+func (l *loader) get(source string, options requestOptions) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, source, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating request")
+	}
+	for key, values := range options.header {
+		request.Header[key] = values
+	}
+
+	policy, err := l.sourcePolicy()
 	if err != nil {
-		return err
+		return nil, errors.Wrap(err, "while reading source policy")
+	}
+	if err := policy.checkURL(request.URL); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return l.do(l.withTransport(l.withSourcePolicy(request, policy), options.transport), options)
 }
 
 func (l *loader) fileName(source string) string {
@@ -8,6 +8,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/onsi/gomega"
 )
 
@@ -50,12 +51,13 @@ func TestLoader_Load_NotSupported(t *testing.T) {
 		temporaryDir:    "/tmp",
 		osRemoveAllFunc: os.RemoveAll,
 		osCreateFunc:    os.Create,
-		httpGetFunc:     get,
+		httpDoFunc:      get,
 		ioutilTempDir:   ioutil.TempDir,
 	}
 
 	// When
-	_, files, err := loader.Load("test", "asset", "other", "")
+	result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "test", Mode: "other"})
+	files := result.Files
 
 	// Then
 	g.Expect(err).To(gomega.HaveOccurred())
@@ -105,8 +107,8 @@ func createError(name string) (*os.File, error) {
 
 }
 
-func get(url string) (*http.Response, error) {
-	if url == "error3" {
+func get(req *http.Request) (*http.Response, error) {
+	if req.URL.String() == "error3" {
 		return nil, fmt.Errorf("nope")
 	}
 
@@ -3,15 +3,14 @@ package loader
 import (
 	"archive/tar"
 	"archive/zip"
-	"compress/gzip"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
-	"strings"
 
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/pkg/errors"
 )
 
@@ -19,72 +18,123 @@ type matcher interface {
 	MatchString(s string) bool
 }
 
-func (l *loader) loadPackage(src, name, filter string) (string, []string, error) {
+func (l *loader) loadPackage(name string, source v1beta1.AssetSource, options requestOptions, verify func(path string) error) (Result, error) {
+	src := source.URL
 	basePath, err := ioutil.TempDir(l.temporaryDir, name)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	archiveDir, err := ioutil.TempDir(l.temporaryDir, name)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	defer l.Clean(archiveDir)
 
 	fileName := l.fileName(src)
 	archivePath := filepath.Join(archiveDir, fileName)
-	filterRegexp, err := regexp.Compile(filter)
+	filterRegexp, err := regexp.Compile(source.Filter)
 	if err != nil {
-		return "", nil, errors.Wrapf(err, "while compiling filter")
+		return Result{}, errors.Wrapf(err, "while compiling filter")
 	}
 
-	unpack, err := l.selectEngine(fileName)
+	responseHeader, err := l.download(archivePath, src, options)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
-	if err := l.download(archivePath, src); err != nil {
-		return "", nil, err
+	if err := verify(archivePath); err != nil {
+		return Result{}, err
 	}
 
-	files, err := unpack(archivePath, basePath, filterRegexp)
+	digest, err := l.digestFile(archivePath)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
-	return basePath, files, nil
-}
+	format, err := l.archiveFormat(source.ArchiveFormat, fileName, responseHeader.Get("Content-Type"), func() ([]byte, error) {
+		return l.readHeader(archivePath, archiveHeaderSize)
+	})
+	if err != nil {
+		return Result{}, err
+	}
 
-func (l *loader) selectEngine(filename string) (func(src, dst string, filter matcher) ([]string, error), error) {
-	extension := strings.ToLower(filepath.Ext(filename))
+	unpack, err := l.selectEngine(format)
+	if err != nil {
+		return Result{}, err
+	}
 
-	switch {
-	case extension == ".zip":
+	extraction := l.newExtraction(basePath, source.StripComponents, source.TargetPrefix)
+	files, err := unpack(archivePath, extraction, filterRegexp)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		BasePath:     basePath,
+		Files:        files,
+		ETag:         responseHeader.Get("ETag"),
+		LastModified: responseHeader.Get("Last-Modified"),
+		Digest:       digest,
+	}, nil
+}
+
+func (l *loader) selectEngine(format v1beta1.AssetArchiveFormat) (func(src string, extraction *extraction, filter matcher) ([]string, error), error) {
+	if format == v1beta1.AssetArchiveZIP {
 		return l.unpackZIP, nil
-	case extension == ".tar" || extension == ".tgz" || strings.HasSuffix(strings.ToLower(filename), ".tar.gz"):
-		return l.unpackTAR, nil
 	}
 
-	return nil, fmt.Errorf("not supported file type %s", extension)
+	decompress, err := l.selectDecompressor(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.tarEngine(decompress), nil
 }
 
-func (l *loader) unpackTAR(src, dst string, filter matcher) ([]string, error) {
-	var filenames []string
+func (l *loader) selectDecompressor(format v1beta1.AssetArchiveFormat) (func(src io.Reader) (io.ReadCloser, error), error) {
+	switch format {
+	case v1beta1.AssetArchiveTAR:
+		return nil, nil
+	case v1beta1.AssetArchiveTARGZ:
+		return decompressGZIP, nil
+	case v1beta1.AssetArchiveTARBZ2:
+		return decompressBZIP2, nil
+	case v1beta1.AssetArchiveTARXZ:
+		return decompressXZ, nil
+	case v1beta1.AssetArchiveTARZST:
+		return decompressZSTD, nil
+	}
+
+	return nil, fmt.Errorf("not supported archive format %s", format)
+}
+
+func (l *loader) tarEngine(decompress func(src io.Reader) (io.ReadCloser, error)) func(src string, extraction *extraction, filter matcher) ([]string, error) {
+	return func(src string, extraction *extraction, filter matcher) ([]string, error) {
+		return l.unpackTAR(src, extraction, filter, decompress)
+	}
+}
+
+func (l *loader) unpackTAR(src string, extraction *extraction, filter matcher, decompress func(src io.Reader) (io.ReadCloser, error)) ([]string, error) {
 	file, err := os.Open(src)
 	if err != nil {
 		return nil, errors.Wrap(err, "while opening archive")
 	}
 	defer file.Close()
 
-	var reader io.Reader
-	extension := strings.ToLower(filepath.Ext(src))
-	if extension == ".gz" || extension == ".gzip" || extension == ".tgz" {
-		reader, err = gzip.NewReader(file)
+	return l.readTAR(file, extraction, filter, decompress)
+}
+
+func (l *loader) readTAR(src io.Reader, extraction *extraction, filter matcher, decompress func(src io.Reader) (io.ReadCloser, error)) ([]string, error) {
+	var filenames []string
+	reader := src
+	if decompress != nil {
+		decompressed, err := decompress(src)
 		if err != nil {
-			return nil, errors.Wrap(err, "while creating GZIP reader")
+			return nil, err
 		}
-	} else {
-		reader = file
+		defer decompressed.Close()
+		reader = decompressed
 	}
 
 	tarReader := tar.NewReader(reader)
@@ -99,28 +149,47 @@ unpack:
 			return nil, errors.Wrap(err, "while unpacking archive")
 		}
 
-		target := filepath.Join(dst, header.Name)
+		if err := extraction.checkName(header.Name); err != nil {
+			return nil, err
+		}
+		name, ok := extraction.rename(header.Name)
+		if !ok {
+			continue
+		}
+
+		target, err := extraction.target(name)
+		if err != nil {
+			return nil, err
+		}
 
 		switch {
-		case !filter.MatchString(header.Name):
+		case !filter.MatchString(name):
 			continue
 		case header.Typeflag == tar.TypeDir:
-			if err := l.createDir(target); err != nil {
+			if err := extraction.createDir(target); err != nil {
 				return nil, errors.Wrap(err, "while creating directory")
 			}
 		case header.Typeflag == tar.TypeReg:
-			filenames = append(filenames, header.Name)
+			filenames = append(filenames, name)
 
-			if err := l.createFile(tarReader, target, header.Mode); err != nil {
+			if err := extraction.createFile(tarReader, target, header.Mode, header.Size); err != nil {
+				return nil, err
+			}
+		case header.Typeflag == tar.TypeSymlink || header.Typeflag == tar.TypeLink:
+			created, err := extraction.link(name, header.Linkname, target, header.Typeflag == tar.TypeLink)
+			if err != nil {
 				return nil, err
 			}
+			if created {
+				filenames = append(filenames, name)
+			}
 		}
 	}
 
 	return filenames, nil
 }
 
-func (l *loader) unpackZIP(src, dest string, filter matcher) ([]string, error) {
+func (l *loader) unpackZIP(src string, extraction *extraction, filter matcher) ([]string, error) {
 	var filenames []string
 
 	zipReader, err := zip.OpenReader(src)
@@ -130,47 +199,63 @@ func (l *loader) unpackZIP(src, dest string, filter matcher) ([]string, error) {
 	defer zipReader.Close()
 
 	for _, file := range zipReader.File {
-		if !filter.MatchString(file.Name) {
+		if err := extraction.checkName(file.Name); err != nil {
+			return nil, err
+		}
+		name, ok := extraction.rename(file.Name)
+		if !ok {
 			continue
 		}
 
-		path, isDir, err := l.handleZIPEntry(file, dest)
+		target, err := extraction.target(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !filter.MatchString(name) {
+			continue
+		}
+
+		created, err := l.handleZIPEntry(extraction, file, name, target)
 		if err != nil {
 			return nil, errors.Wrap(err, "while handling ZIP entry")
 		}
 
-		if !isDir {
-			filenames = append(filenames, path)
+		if created {
+			filenames = append(filenames, name)
 		}
 	}
 
 	return filenames, nil
 }
 
-func (l *loader) handleZIPEntry(file *zip.File, dst string) (string, bool, error) {
+func (l *loader) handleZIPEntry(extraction *extraction, file *zip.File, name, target string) (bool, error) {
+	if file.FileInfo().IsDir() {
+		if err := extraction.createDir(target); err != nil {
+			return false, errors.Wrap(err, "while creating directory")
+		}
+		return false, nil
+	}
+
 	fileReader, err := file.Open()
 	if err != nil {
-		return "", false, err
+		return false, err
 	}
 	defer fileReader.Close()
 
-	path := filepath.Join(dst, file.Name)
-
-	if !strings.HasPrefix(path, filepath.Clean(dst)+string(os.PathSeparator)) {
-		return "", false, fmt.Errorf("%s: illegal file path", path)
+	if file.Mode()&os.ModeSymlink != 0 {
+		linkName, err := ioutil.ReadAll(io.LimitReader(fileReader, 4096))
+		if err != nil {
+			return false, errors.Wrap(err, "while reading link")
+		}
+		return extraction.link(name, string(linkName), target, false)
 	}
 
-	if file.FileInfo().IsDir() {
-		if err := l.createDir(path); err != nil {
-			return "", false, errors.Wrap(err, "while creating directory")
-		}
-	} else {
-		if err := l.createFile(fileReader, path, int64(file.Mode())); err != nil {
-			return "", false, err
-		}
+	if err := extraction.createFile(fileReader, target, int64(file.Mode().Perm()), int64(file.UncompressedSize64)); err != nil {
+		return false, err
 	}
 
-	return file.Name, file.FileInfo().IsDir(), nil
+	return true, nil
 }
 
 func (l *loader) createFile(src io.Reader, dst string, mode int64) error {
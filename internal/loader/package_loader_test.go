@@ -1,6 +1,7 @@
 package loader
 
 import (
+	"bytes"
 	"github.com/onsi/gomega"
 	"io/ioutil"
 	"net/http"
@@ -26,11 +27,20 @@ func TestLoader_Load_Package(t *testing.T) {
 			path: "./testdata/structure.tar.gz",
 		},
 		"TarArchive": {
-			path: "./testdata/structure.tar.gz",
+			path: "./testdata/structure.tar",
 		},
 		"TgzArchive": {
 			path: "./testdata/structure.tgz",
 		},
+		"TarBz2Archive": {
+			path: "./testdata/structure.tar.bz2",
+		},
+		"TarXzArchive": {
+			path: "./testdata/structure.tar.xz",
+		},
+		"TarZstArchive": {
+			path: "./testdata/structure.tar.zst",
+		},
 	} {
 		t.Run(testName, func(t *testing.T) {
 			// Given
@@ -45,12 +55,13 @@ func TestLoader_Load_Package(t *testing.T) {
 				temporaryDir:    tmpDir,
 				osRemoveAllFunc: os.RemoveAll,
 				osCreateFunc:    os.Create,
-				httpGetFunc:     getFile(testCase.path),
+				httpDoFunc:      getFile(testCase.path),
 				ioutilTempDir:   ioutil.TempDir,
 			}
 
 			// When
-			basePath, files, err := loader.Load(testCase.path, "asset", v1beta1.AssetPackage, "")
+			result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: testCase.path, Mode: v1beta1.AssetPackage})
+			basePath, files := result.BasePath, result.Files
 			defer loader.Clean(basePath)
 
 			// Then
@@ -61,6 +72,35 @@ func TestLoader_Load_Package(t *testing.T) {
 	}
 }
 
+func TestLoader_Load_CorruptedZSTD(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	content, err := ioutil.ReadFile("./testdata/structure.tar.zst")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	content[len(content)/2] ^= 0xff
+
+	loader := &loader{
+		temporaryDir:    os.TempDir(),
+		osRemoveAllFunc: os.RemoveAll,
+		osCreateFunc:    os.Create,
+		httpDoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		},
+		ioutilTempDir: ioutil.TempDir,
+	}
+	source := v1beta1.AssetSource{URL: "https://example.com/structure.tar.zst", Mode: v1beta1.AssetPackage}
+
+	// When
+	result, err := loader.Load("", "asset", source)
+	defer loader.Clean(result.BasePath)
+
+	// Then
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
 func TestLoader_Load_WithFilter(t *testing.T) {
 	for testName, testCase := range map[string]struct {
 		filter   string
@@ -116,12 +156,13 @@ func TestLoader_Load_WithFilter(t *testing.T) {
 				temporaryDir:    tmpDir,
 				osRemoveAllFunc: os.RemoveAll,
 				osCreateFunc:    os.Create,
-				httpGetFunc:     getFile(testPath),
+				httpDoFunc:      getFile(testPath),
 				ioutilTempDir:   ioutil.TempDir,
 			}
 
 			// When
-			basePath, files, err := loader.Load(testPath, "asset", v1beta1.AssetPackage, testCase.filter)
+			result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: testPath, Mode: v1beta1.AssetPackage, Filter: testCase.filter})
+			basePath, files := result.BasePath, result.Files
 			defer loader.Clean(basePath)
 
 			// Then
@@ -132,15 +173,111 @@ func TestLoader_Load_WithFilter(t *testing.T) {
 	}
 }
 
-func getFile(path string) func(url string) (*http.Response, error) {
+func TestLoader_Load_PackageFormatDetection(t *testing.T) {
+	expected := []string{
+		"structure/swagger.json",
+		"structure/docs/README.md",
+	}
+
+	for testName, testCase := range map[string]struct {
+		path        string
+		contentType string
+		format      v1beta1.AssetArchiveFormat
+		fail        bool
+	}{
+		"ContentType": {
+			path:        "./testdata/structure.zip",
+			contentType: "application/zip",
+		},
+		"ContentTypeWithParameters": {
+			path:        "./testdata/structure.tar.gz",
+			contentType: "application/gzip; charset=binary",
+		},
+		"ZipMagicBytes": {
+			path:        "./testdata/structure.zip",
+			contentType: "application/octet-stream",
+		},
+		"TarMagicBytes": {
+			path: "./testdata/structure.tar",
+		},
+		"TarGzMagicBytes": {
+			path: "./testdata/structure.tar.gz",
+		},
+		"TarBz2MagicBytes": {
+			path: "./testdata/structure.tar.bz2",
+		},
+		"TarXzMagicBytes": {
+			path: "./testdata/structure.tar.xz",
+		},
+		"TarZstMagicBytes": {
+			path: "./testdata/structure.tar.zst",
+		},
+		"ExplicitFormat": {
+			path:        "./testdata/structure.tar.xz",
+			contentType: "application/zip",
+			format:      v1beta1.AssetArchiveTARXZ,
+		},
+		"FailNotSupported": {
+			path: "./testdata/structure.tar.gz",
+			fail: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			httpDoFunc := getFileWithContentType(testCase.path, testCase.contentType)
+			if testCase.fail {
+				httpDoFunc = get
+			}
+
+			loader := &loader{
+				temporaryDir:    os.TempDir(),
+				osRemoveAllFunc: os.RemoveAll,
+				osCreateFunc:    os.Create,
+				httpDoFunc:      httpDoFunc,
+				ioutilTempDir:   ioutil.TempDir,
+			}
+			source := v1beta1.AssetSource{URL: "https://example.com/releases/download", Mode: v1beta1.AssetPackage, ArchiveFormat: testCase.format}
+
+			// When
+			result, err := loader.Load("", "asset", source)
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			if testCase.fail {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.Files).To(gomega.ConsistOf(expected))
+		})
+	}
+}
+
+func getFileWithContentType(path, contentType string) func(req *http.Request) (*http.Response, error) {
+	get := getFile(path)
+
+	return func(req *http.Request) (*http.Response, error) {
+		response, err := get(req)
+		if err != nil {
+			return nil, err
+		}
+		response.Header = http.Header{"Content-Type": []string{contentType}}
+
+		return response, nil
+	}
+}
+
+func getFile(path string) func(req *http.Request) (*http.Response, error) {
 	file, err := os.Open(path)
 	if err != nil {
-		return func(url string) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
 			return nil, err
 		}
 	}
 
-	get := func(url string) (*http.Response, error) {
+	get := func(req *http.Request) (*http.Response, error) {
 		return &http.Response{
 			StatusCode: http.StatusOK,
 			Body:       file,
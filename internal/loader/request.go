@@ -0,0 +1,237 @@
+package loader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+const maxRetryBackoff = time.Minute
+
+// requestOptions describe how the source content is downloaded
+type requestOptions struct {
+	header       http.Header
+	timeout      time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+	transport    transportOptions
+}
+
+// requestOptions returns the loader configuration overridden by the download options of the source
+func (l *loader) requestOptions(namespace string, source v1beta1.AssetSource, header http.Header) (requestOptions, error) {
+	transport, err := l.transportOptions(namespace, source.Download)
+	if err != nil {
+		return requestOptions{}, err
+	}
+
+	options := requestOptions{
+		header:       header,
+		timeout:      l.requestTimeout,
+		maxRetries:   l.maxRetries,
+		retryBackoff: l.retryBackoff,
+		transport:    transport,
+	}
+
+	if download := source.Download; download != nil {
+		if download.Timeout != nil {
+			options.timeout = download.Timeout.Duration
+		}
+		if download.MaxRetries != nil {
+			options.maxRetries = *download.MaxRetries
+		}
+		if download.RetryBackoff != nil {
+			options.retryBackoff = download.RetryBackoff.Duration
+		}
+	}
+
+	return options, nil
+}
+
+func (o requestOptions) withHeader(header http.Header) requestOptions {
+	o.header = header
+	return o
+}
+
+// backoff returns the exponential delay before the retry
+func (o requestOptions) backoff(attempt int) time.Duration {
+	backoff := o.retryBackoff
+	for i := 0; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+
+	return backoff
+}
+
+// do sends the request, and retries it on connection errors and server errors
+func (l *loader) do(request *http.Request, options requestOptions) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := l.send(request, options.timeout)
+		if attempt >= options.maxRetries || !l.retryable(response, err) {
+			return response, err
+		}
+		if response != nil {
+			response.Body.Close()
+		}
+
+		time.Sleep(options.backoff(attempt))
+	}
+}
+
+// send sends a single request. The timeout covers reading the response body, so it is canceled when the body is closed.
+func (l *loader) send(request *http.Request, timeout time.Duration) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(request.Context(), timeout)
+		request = request.WithContext(ctx)
+	}
+
+	response, err := l.httpDoFunc(request)
+	if err != nil {
+		cancel()
+		// violations found on redirects and when connecting are wrapped by the client
+		var policyErr *SourcePolicyError
+		if errors.As(err, &policyErr) {
+			return nil, policyErr
+		}
+		return nil, err
+	}
+	response.Body = &cancelBody{ReadCloser: response.Body, cancel: cancel}
+
+	return response, nil
+}
+
+func (l *loader) retryable(response *http.Response, err error) bool {
+	if err != nil {
+		return !IsSourcePolicyError(err)
+	}
+
+	return response.StatusCode >= http.StatusInternalServerError
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// resumableBody continues reading the response with Range requests if reading fails. Only responses with a validator
+// are resumed, so the If-Range condition guarantees that the rest of the content comes from the same version.
+type resumableBody struct {
+	loader    *loader
+	source    string
+	options   requestOptions
+	validator string
+	body      io.ReadCloser
+	offset    int64
+	retries   int
+}
+
+func (l *loader) newResumableBody(source string, options requestOptions, response *http.Response) io.ReadCloser {
+	validator := response.Header.Get("ETag")
+	if len(validator) == 0 || strings.HasPrefix(validator, "W/") {
+		validator = response.Header.Get("Last-Modified")
+	}
+	if response.StatusCode != http.StatusOK || len(validator) == 0 || options.maxRetries < 1 {
+		return response.Body
+	}
+
+	return &resumableBody{
+		loader:    l,
+		source:    source,
+		options:   options,
+		validator: validator,
+		body:      response.Body,
+	}
+}
+
+func (b *resumableBody) Read(p []byte) (int, error) {
+	for {
+		n, err := b.body.Read(p)
+		b.offset += int64(n)
+		if err == nil || err == io.EOF || b.retries >= b.options.maxRetries {
+			return n, err
+		}
+
+		if resumeErr := b.resume(); resumeErr != nil {
+			return n, errors.Wrapf(resumeErr, "while resuming download interrupted by %s", err)
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// resume requests the rest of the content. Failed requests are retried, as long as there are retries left.
+func (b *resumableBody) resume() error {
+	header := http.Header{}
+	for key, values := range b.options.header {
+		header[key] = values
+	}
+	header.Set("Range", fmt.Sprintf("bytes=%d-", b.offset))
+	header.Set("If-Range", b.validator)
+
+	options := b.options.withHeader(header)
+	options.maxRetries = 0
+
+	var err error
+	for b.retries < b.options.maxRetries {
+		time.Sleep(b.options.backoff(b.retries))
+		b.retries++
+
+		var response *http.Response
+		response, err = b.loader.get(b.source, options)
+		if err != nil {
+			if !b.loader.retryable(nil, err) {
+				return err
+			}
+			continue
+		}
+		if b.loader.retryable(response, nil) {
+			response.Body.Close()
+			err = errors.New(response.Status)
+			continue
+		}
+
+		if response.StatusCode != http.StatusPartialContent || b.rangeStart(response.Header.Get("Content-Range")) != b.offset {
+			response.Body.Close()
+			return fmt.Errorf("server did not return the content from byte %d", b.offset)
+		}
+
+		b.body.Close()
+		b.body = response.Body
+		return nil
+	}
+
+	return err
+}
+
+// rangeStart returns the first byte of the "bytes <start>-<end>/<size>" content range
+func (b *resumableBody) rangeStart(contentRange string) int64 {
+	value := strings.TrimPrefix(contentRange, "bytes ")
+	if dash := strings.Index(value, "-"); dash > 0 {
+		if start, err := strconv.ParseInt(value[:dash], 10, 64); err == nil {
+			return start
+		}
+	}
+
+	return -1
+}
+
+func (b *resumableBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,250 @@
+package loader
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoader_Load_Retries(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		failures   int32
+		status     int
+		maxRetries int
+		requests   int32
+		failed     bool
+	}{
+		"Success": {
+			maxRetries: 3,
+			requests:   1,
+		},
+		"RetriedServerError": {
+			failures:   2,
+			status:     http.StatusServiceUnavailable,
+			maxRetries: 2,
+			requests:   3,
+		},
+		"TooManyServerErrors": {
+			failures:   2,
+			status:     http.StatusBadGateway,
+			maxRetries: 1,
+			requests:   2,
+			failed:     true,
+		},
+		"NotRetriedClientError": {
+			failures:   1,
+			status:     http.StatusNotFound,
+			maxRetries: 3,
+			requests:   1,
+			failed:     true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= testCase.failures {
+					w.WriteHeader(testCase.status)
+					return
+				}
+				w.Write([]byte("# Test"))
+			}))
+			defer server.Close()
+
+			loader := New(nil, Config{
+				TemporaryDirectory: "/tmp",
+				MaxRetries:         testCase.maxRetries,
+				RetryBackoff:       time.Millisecond,
+			})
+
+			// When
+			result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: server.URL + "/README.md", Mode: v1beta1.AssetSingle})
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(readResult(t, result, "README.md")).To(gomega.Equal("# Test"))
+			}
+			g.Expect(atomic.LoadInt32(&requests)).To(gomega.Equal(testCase.requests))
+		})
+	}
+}
+
+func TestLoader_Load_ConnectionErrorRetries(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	var requests int
+	loader := &loader{
+		temporaryDir:    "/tmp",
+		osRemoveAllFunc: removeAll,
+		osCreateFunc:    os.Create,
+		ioutilTempDir:   ioutil.TempDir,
+		maxRetries:      1,
+		retryBackoff:    time.Millisecond,
+		httpDoFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			if requests == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return get(req)
+		},
+	}
+
+	// When
+	_, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "https://example.com/README.md", Mode: v1beta1.AssetSingle})
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(requests).To(gomega.Equal(2))
+}
+
+func TestLoader_Load_Timeout(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	loader := New(nil, Config{
+		TemporaryDirectory: "/tmp",
+		RequestTimeout:     time.Second,
+	})
+	timeout := metav1.Duration{Duration: 50 * time.Millisecond}
+	maxRetries := 0
+	source := v1beta1.AssetSource{
+		URL:      server.URL + "/README.md",
+		Mode:     v1beta1.AssetSingle,
+		Download: &v1beta1.AssetDownload{Timeout: &timeout, MaxRetries: &maxRetries},
+	}
+
+	// When
+	start := time.Now()
+	_, err := loader.Load("", "asset", source)
+
+	// Then
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(time.Since(start)).To(gomega.BeNumerically("<", 500*time.Millisecond))
+}
+
+func TestLoader_Load_Resume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+
+	for testName, testCase := range map[string]struct {
+		ignoreRange bool
+		maxRetries  int
+		failed      bool
+	}{
+		"Resumed": {
+			maxRetries: 1,
+		},
+		"NoRetries": {
+			failed: true,
+		},
+		"RangeNotSupported": {
+			ignoreRange: true,
+			maxRetries:  1,
+			failed:      true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			var ranges []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				if r.Header.Get("Range") == "" || testCase.ignoreRange {
+					// the connection breaks in the middle of the response
+					w.Header().Set("Content-Length", "10000")
+					w.Write(content[:4000])
+					panic(http.ErrAbortHandler)
+				}
+				ranges = append(ranges, r.Header.Get("Range"))
+				http.ServeContent(w, r, "package.bin", time.Time{}, bytes.NewReader(content))
+			}))
+			defer server.Close()
+
+			loader := New(nil, Config{
+				TemporaryDirectory: "/tmp",
+				MaxRetries:         testCase.maxRetries,
+				RetryBackoff:       time.Millisecond,
+			})
+
+			// When
+			result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: server.URL + "/package.bin", Mode: v1beta1.AssetSingle})
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(ranges).To(gomega.Equal([]string{"bytes=4000-"}))
+			g.Expect(readResult(t, result, "package.bin")).To(gomega.Equal(string(content)))
+		})
+	}
+}
+
+func TestLoader_RequestOptions(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	loader := &loader{
+		requestTimeout: time.Minute,
+		maxRetries:     3,
+		retryBackoff:   time.Second,
+	}
+	timeout := metav1.Duration{Duration: time.Hour}
+	maxRetries := 0
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+
+	// When
+	defaults, defaultsErr := loader.requestOptions("", v1beta1.AssetSource{}, header)
+	overridden, overriddenErr := loader.requestOptions("", v1beta1.AssetSource{Download: &v1beta1.AssetDownload{Timeout: &timeout, MaxRetries: &maxRetries}}, header)
+
+	// Then
+	g.Expect(defaultsErr).NotTo(gomega.HaveOccurred())
+	g.Expect(overriddenErr).NotTo(gomega.HaveOccurred())
+	g.Expect(defaults).To(gomega.Equal(requestOptions{header: header, timeout: time.Minute, maxRetries: 3, retryBackoff: time.Second, transport: transportOptions{insecureSkipVerify: true}}))
+	g.Expect(overridden).To(gomega.Equal(requestOptions{header: header, timeout: time.Hour, maxRetries: 0, retryBackoff: time.Second, transport: transportOptions{insecureSkipVerify: true}}))
+}
+
+func TestRequestOptions_Backoff(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	options := requestOptions{retryBackoff: time.Second}
+
+	// When
+	backoffs := []time.Duration{options.backoff(0), options.backoff(1), options.backoff(3), options.backoff(100)}
+
+	// Then
+	g.Expect(backoffs).To(gomega.Equal([]time.Duration{time.Second, 2 * time.Second, 8 * time.Second, maxRetryBackoff}))
+}
+
+func readResult(t *testing.T, result Result, name string) string {
+	content, err := ioutil.ReadFile(filepath.Join(result.BasePath, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.TrimSpace(string(content))
+}
@@ -0,0 +1,54 @@
+package loader
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (l *loader) loadSecret(namespace, src, name, filter string) (Result, error) {
+	basePath, err := l.ioutilTempDir(l.temporaryDir, name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	filterRegexp, err := regexp.Compile(filter)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "while compiling filter")
+	}
+
+	secret, err := l.sourceSecret(namespace, src)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var fileList []string
+	for key, value := range secret.Data {
+		if fileList, err = l.copyBytesToFile(value, key, basePath, filterRegexp, fileList); err != nil {
+			return Result{}, errors.Wrap(err, "while copying data to file")
+		}
+	}
+
+	return Result{BasePath: basePath, Files: fileList, Digest: l.dataDigest(secret.Data)}, nil
+}
+
+// sourceSecret returns the Secret the asset is loaded from. The source is either the Secret name or the namespace
+// and the name separated with a slash. Secrets can be read only from the Asset namespace.
+func (l *loader) sourceSecret(namespace, src string) (*corev1.Secret, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("%s: Secret sources are supported only by namespaced assets", src)
+	}
+
+	srcs := strings.Split(src, "/")
+	switch {
+	case len(srcs) > 2 || len(srcs[len(srcs)-1]) == 0:
+		return nil, fmt.Errorf("%s: invalid source format", src)
+	case len(srcs) == 2 && srcs[0] != namespace:
+		return nil, &AccessDeniedError{message: fmt.Sprintf("Secret %s has to be in %s namespace", srcs[1], namespace)}
+	}
+
+	return l.getSecret(namespace, srcs[len(srcs)-1])
+}
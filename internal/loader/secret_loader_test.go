@@ -0,0 +1,121 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+)
+
+func TestLoader_Load_Secret(t *testing.T) {
+	fakedc, err := newFakeDynamicClient(
+		fixSecret("certs", "default", map[string][]byte{
+			"tls.crt": []byte("certificate"),
+			"tls.key": []byte("key"),
+		}),
+		fixSecret("certs", "other", map[string][]byte{
+			"tls.crt": []byte("certificate"),
+		}),
+	)
+	if err != nil {
+		return
+	}
+	loader := &loader{
+		temporaryDir:    "/tmp",
+		dynamicClient:   fakedc,
+		osRemoveAllFunc: os.RemoveAll,
+		osCreateFunc:    os.Create,
+		httpDoFunc:      get,
+		ioutilTempDir:   ioutil.TempDir,
+	}
+
+	for testName, testData := range map[string]struct {
+		namespace  string
+		src        string
+		filter     string
+		files      int
+		errMatcher types.GomegaMatcher
+	}{
+		"Secret name": {
+			namespace:  "default",
+			src:        "certs",
+			files:      2,
+			errMatcher: gomega.BeNil(),
+		},
+		"Secret with namespace": {
+			namespace:  "default",
+			src:        "default/certs",
+			files:      2,
+			errMatcher: gomega.BeNil(),
+		},
+		"Filtered Secret": {
+			namespace:  "default",
+			src:        "certs",
+			filter:     "\\.crt$",
+			files:      1,
+			errMatcher: gomega.BeNil(),
+		},
+		"Secret from other namespace": {
+			namespace:  "default",
+			src:        "other/certs",
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"Cluster-wide asset": {
+			namespace:  "",
+			src:        "other/certs",
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"Secret not found": {
+			namespace:  "default",
+			src:        "notFound",
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"Bad src": {
+			namespace:  "default",
+			src:        "default/certs/tls.crt",
+			errMatcher: gomega.HaveOccurred(),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			result, err := loader.Load(testData.namespace, "asset-secret", v1beta1.AssetSource{URL: testData.src, Mode: v1beta1.AssetSecret, Filter: testData.filter})
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			g.Expect(err).To(testData.errMatcher)
+			g.Expect(result.Files).To(gomega.HaveLen(testData.files))
+			if testData.files > 0 {
+				content, err := ioutil.ReadFile(filepath.Join(result.BasePath, "tls.crt"))
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(string(content)).To(gomega.Equal("certificate"))
+			}
+		})
+	}
+}
+
+func TestLoader_Load_SecretFailTemp(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	fakedc, err := newFakeDynamicClient(fixSecret("certs", "default", map[string][]byte{"tls.crt": []byte("certificate")}))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	loader := &loader{
+		temporaryDir:    "/tmp",
+		dynamicClient:   fakedc,
+		osRemoveAllFunc: os.RemoveAll,
+		ioutilTempDir:   tempDirError,
+	}
+
+	// When
+	_, err = loader.Load("default", "asset", v1beta1.AssetSource{URL: "certs", Mode: v1beta1.AssetSecret})
+
+	// Then
+	g.Expect(err).To(gomega.HaveOccurred())
+}
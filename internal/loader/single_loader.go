@@ -4,18 +4,33 @@ import (
 	"path/filepath"
 )
 
-func (l *loader) loadSingle(src, name string) (string, []string, error) {
+func (l *loader) loadSingle(src, name string, options requestOptions, verify func(path string) error) (Result, error) {
 	basePath, err := l.ioutilTempDir(l.temporaryDir, name)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	fileName := l.fileName(src)
 	destination := filepath.Join(basePath, fileName)
-	err = l.download(destination, src)
+	responseHeader, err := l.download(destination, src, options)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
-	return basePath, []string{fileName}, nil
+	if err := verify(destination); err != nil {
+		return Result{}, err
+	}
+
+	digest, err := l.digestFile(destination)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		BasePath:     basePath,
+		Files:        []string{fileName},
+		ETag:         responseHeader.Get("ETag"),
+		LastModified: responseHeader.Get("Last-Modified"),
+		Digest:       digest,
+	}, nil
 }
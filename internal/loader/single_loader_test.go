@@ -17,12 +17,13 @@ func TestLoader_Load_Single(t *testing.T) {
 			temporaryDir:    "/tmp",
 			osRemoveAllFunc: os.RemoveAll,
 			osCreateFunc:    os.Create,
-			httpGetFunc:     get,
+			httpDoFunc:      get,
 			ioutilTempDir:   ioutil.TempDir,
 		}
 
 		// When
-		_, files, err := loader.Load("test", "asset", v1beta1.AssetSingle, "")
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "test", Mode: v1beta1.AssetSingle})
+		files := result.Files
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -36,12 +37,13 @@ func TestLoader_Load_Single(t *testing.T) {
 			temporaryDir:    "/tmp",
 			osRemoveAllFunc: os.RemoveAll,
 			osCreateFunc:    os.Create,
-			httpGetFunc:     get,
+			httpDoFunc:      get,
 			ioutilTempDir:   ioutil.TempDir,
 		}
 
 		// When
-		_, files, err := loader.Load("https://ala.ma/", "asset", v1beta1.AssetSingle, "")
+		result, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "https://ala.ma/", Mode: v1beta1.AssetSingle})
+		files := result.Files
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -55,12 +57,12 @@ func TestLoader_Load_Single(t *testing.T) {
 			temporaryDir:    "/tmp",
 			osRemoveAllFunc: os.RemoveAll,
 			osCreateFunc:    os.Create,
-			httpGetFunc:     get,
+			httpDoFunc:      get,
 			ioutilTempDir:   tempDirError,
 		}
 
 		// When
-		_, _, err := loader.Load("test", "asset", v1beta1.AssetSingle, "")
+		_, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "test", Mode: v1beta1.AssetSingle})
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -73,12 +75,12 @@ func TestLoader_Load_Single(t *testing.T) {
 			temporaryDir:    "/tmp",
 			osRemoveAllFunc: os.RemoveAll,
 			osCreateFunc:    createError,
-			httpGetFunc:     get,
+			httpDoFunc:      get,
 			ioutilTempDir:   ioutil.TempDir,
 		}
 
 		// When
-		_, _, err := loader.Load("test", "asset", v1beta1.AssetSingle, "")
+		_, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "test", Mode: v1beta1.AssetSingle})
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -91,12 +93,12 @@ func TestLoader_Load_Single(t *testing.T) {
 			temporaryDir:    "/tmp",
 			osRemoveAllFunc: os.RemoveAll,
 			osCreateFunc:    os.Create,
-			httpGetFunc:     get,
+			httpDoFunc:      get,
 			ioutilTempDir:   ioutil.TempDir,
 		}
 
 		// When
-		_, _, err := loader.Load("error3", "asset", v1beta1.AssetSingle, "")
+		_, err := loader.Load("", "asset", v1beta1.AssetSource{URL: "error3", Mode: v1beta1.AssetSingle})
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
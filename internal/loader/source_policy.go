@@ -0,0 +1,437 @@
+package loader
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	sourcePolicyCacheTTL = 10 * time.Second
+	configurationOrigin  = "loader configuration"
+)
+
+var privateNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+)
+
+// SourcePolicyError means that the source URL is not allowed by the loader configuration or an AssetSourcePolicy
+type SourcePolicyError struct {
+	message string
+}
+
+func (e *SourcePolicyError) Error() string {
+	return e.message
+}
+
+func IsSourcePolicyError(err error) bool {
+	_, ok := errors.Cause(err).(*SourcePolicyError)
+	return ok
+}
+
+// sourcePolicy combines the loader configuration with all AssetSourcePolicies, so a URL has to be allowed by each of them
+type sourcePolicy struct {
+	rules                []sourceRule
+	blockPrivateNetworks bool
+	maxRedirects         int
+}
+
+type sourceRule struct {
+	origin         string
+	allowedSchemes []string
+	allowedHosts   []string
+	deniedHosts    []string
+}
+
+// sourcePolicyCache limits the number of requests to the API server, as the policy is checked for every downloaded file
+type sourcePolicyCache struct {
+	mu       sync.Mutex
+	policies []v1beta1.AssetSourcePolicy
+	expires  time.Time
+}
+
+type sourcePolicyContextKey struct{}
+
+// requestPolicy is passed to the transport in the request context. Addresses of proxies are recorded,
+// as the target host is checked before the request is sent to the proxy.
+type requestPolicy struct {
+	sourcePolicy
+	proxies sync.Map
+}
+
+func newSourceRule(origin string, allowedSchemes, allowedHosts, deniedHosts []string) sourceRule {
+	return sourceRule{
+		origin:         origin,
+		allowedSchemes: normalize(allowedSchemes),
+		allowedHosts:   normalize(allowedHosts),
+		deniedHosts:    normalize(deniedHosts),
+	}
+}
+
+func (l *loader) sourcePolicy() (sourcePolicy, error) {
+	items, err := l.listSourcePolicies()
+	if err != nil {
+		return sourcePolicy{}, err
+	}
+
+	policy := sourcePolicy{
+		rules:                append([]sourceRule{}, l.policy.rules...),
+		blockPrivateNetworks: l.policy.blockPrivateNetworks,
+		maxRedirects:         l.policy.maxRedirects,
+	}
+	for _, item := range items {
+		spec := item.Spec
+		policy.rules = append(policy.rules, newSourceRule(fmt.Sprintf("AssetSourcePolicy %s", item.Name), spec.AllowedSchemes, spec.AllowedHosts, spec.DeniedHosts))
+		policy.blockPrivateNetworks = policy.blockPrivateNetworks || spec.BlockPrivateNetworks
+		if spec.MaxRedirects != nil && *spec.MaxRedirects < policy.maxRedirects {
+			policy.maxRedirects = *spec.MaxRedirects
+		}
+	}
+
+	return policy, nil
+}
+
+// listSourcePolicies returns all AssetSourcePolicies. Policies are optional, so a missing resource means that there are none.
+func (l *loader) listSourcePolicies() ([]v1beta1.AssetSourcePolicy, error) {
+	if l.dynamicClient == nil {
+		return nil, nil
+	}
+
+	l.policies.mu.Lock()
+	defer l.policies.mu.Unlock()
+	if time.Now().Before(l.policies.expires) {
+		return l.policies.policies, nil
+	}
+
+	policiesResource := schema.GroupVersionResource{Group: v1beta1.GroupVersion.Group, Version: v1beta1.GroupVersion.Version, Resource: "assetsourcepolicies"}
+	list, err := l.dynamicClient.Resource(policiesResource).List(metav1.ListOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "while listing AssetSourcePolicies")
+	}
+
+	var policies []v1beta1.AssetSourcePolicy
+	if list != nil {
+		for _, item := range list.Items {
+			var policy v1beta1.AssetSourcePolicy
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &policy); err != nil {
+				return nil, errors.Wrapf(err, "while converting Unstructured to AssetSourcePolicy %s", item.GetName())
+			}
+			policies = append(policies, policy)
+		}
+	}
+
+	l.policies.policies = policies
+	l.policies.expires = time.Now().Add(sourcePolicyCacheTTL)
+
+	return policies, nil
+}
+
+func (p sourcePolicy) checkURL(source *url.URL) error {
+	for _, rule := range p.rules {
+		if err := rule.checkScheme(source.Scheme); err != nil {
+			return err
+		}
+		if err := rule.checkHost(source.Hostname()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p sourcePolicy) checkScheme(scheme string) error {
+	for _, rule := range p.rules {
+		if err := rule.checkScheme(scheme); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gitProtocols narrows the colon-separated list of git protocols to the allowed schemes
+func (p sourcePolicy) gitProtocols(protocols string) string {
+	var allowed []string
+	for _, protocol := range strings.Split(protocols, ":") {
+		if len(protocol) > 0 && p.checkScheme(protocol) == nil {
+			allowed = append(allowed, protocol)
+		}
+	}
+
+	return strings.Join(allowed, ":")
+}
+
+func (p sourcePolicy) checkHost(host string) error {
+	for _, rule := range p.rules {
+		if err := rule.checkHost(host); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkProxy checks the host and the addresses of the proxy configured for the source
+func (p sourcePolicy) checkProxy(ctx context.Context, proxyURL *url.URL) error {
+	if err := p.checkHost(proxyURL.Hostname()); err != nil {
+		return errors.Wrap(err, "while checking proxy")
+	}
+	if p.blockPrivateNetworks {
+		if _, err := p.resolve(ctx, proxyURL.Hostname()); err != nil {
+			return errors.Wrap(err, "while checking proxy")
+		}
+	}
+
+	return nil
+}
+
+// restricted returns true if the policy limits the hosts or networks sources are loaded from
+func (p sourcePolicy) restricted() bool {
+	if p.blockPrivateNetworks {
+		return true
+	}
+	for _, rule := range p.rules {
+		if len(rule.allowedHosts) > 0 || len(rule.deniedHosts) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolve returns the addresses of the host, if they are allowed
+func (p sourcePolicy) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while resolving %s", host)
+	}
+
+	ips := make([]net.IP, 0, len(addresses))
+	for _, address := range addresses {
+		if p.blockPrivateNetworks && isPrivateIP(address.IP) {
+			return nil, &SourcePolicyError{message: fmt.Sprintf("host %s resolves to %s, which belongs to a blocked network", host, address.IP)}
+		}
+		ips = append(ips, address.IP)
+	}
+
+	return ips, nil
+}
+
+func (r sourceRule) checkScheme(scheme string) error {
+	if len(r.allowedSchemes) > 0 && !contains(r.allowedSchemes, strings.ToLower(scheme)) {
+		return &SourcePolicyError{message: fmt.Sprintf("scheme %q is not allowed by %s", scheme, r.origin)}
+	}
+
+	return nil
+}
+
+func (r sourceRule) checkHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if matchHost(r.deniedHosts, host) {
+		return &SourcePolicyError{message: fmt.Sprintf("host %q is denied by %s", host, r.origin)}
+	}
+	if len(r.allowedHosts) > 0 && !matchHost(r.allowedHosts, host) {
+		return &SourcePolicyError{message: fmt.Sprintf("host %q is not allowed by %s", host, r.origin)}
+	}
+
+	return nil
+}
+
+// matchHost checks the host against the patterns. "*.example.com" matches all subdomains of example.com, and "*" matches all hosts.
+func matchHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*":
+			return true
+		case strings.HasPrefix(pattern, "*."):
+			if strings.HasSuffix(host, pattern[1:]) {
+				return true
+			}
+		case pattern == host:
+			return true
+		}
+	}
+
+	return false
+}
+
+// withSourcePolicy attaches the policy to the request, so it is checked on redirects and when the connection is opened
+func (l *loader) withSourcePolicy(request *http.Request, policy sourcePolicy) *http.Request {
+	return request.WithContext(context.WithValue(request.Context(), sourcePolicyContextKey{}, &requestPolicy{sourcePolicy: policy}))
+}
+
+func requestPolicyFrom(ctx context.Context) (*requestPolicy, bool) {
+	policy, ok := ctx.Value(sourcePolicyContextKey{}).(*requestPolicy)
+	return policy, ok
+}
+
+func (l *loader) checkRedirect(request *http.Request, via []*http.Request) error {
+	policy, ok := requestPolicyFrom(request.Context())
+	if !ok {
+		return nil
+	}
+
+	if len(via) > policy.maxRedirects {
+		return &SourcePolicyError{message: fmt.Sprintf("stopped after %d redirects", policy.maxRedirects)}
+	}
+
+	return policy.checkURL(request.URL)
+}
+
+// proxy checks the target host before the request is sent to the proxy, as the proxy resolves the host itself
+func (l *loader) proxy(request *http.Request) (*url.URL, error) {
+	proxyURL, err := http.ProxyFromEnvironment(request)
+	if err != nil || proxyURL == nil {
+		return proxyURL, err
+	}
+
+	policy, ok := requestPolicyFrom(request.Context())
+	if !ok || !policy.blockPrivateNetworks {
+		return proxyURL, nil
+	}
+
+	if _, err := policy.resolve(request.Context(), request.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	policy.proxies.Store(proxyAddress(proxyURL), true)
+
+	return proxyURL, nil
+}
+
+// sourceProxy sends the request through the proxy of the source. Unlike the one from the environment, it is not trusted,
+// so its host is checked as well, and its address is checked when the connection is opened.
+func (l *loader) sourceProxy(proxyURL *url.URL) func(request *http.Request) (*url.URL, error) {
+	return func(request *http.Request) (*url.URL, error) {
+		policy, ok := requestPolicyFrom(request.Context())
+		if !ok {
+			return proxyURL, nil
+		}
+
+		if err := policy.checkProxy(request.Context(), proxyURL); err != nil {
+			return nil, err
+		}
+		if policy.blockPrivateNetworks {
+			if _, err := policy.resolve(request.Context(), request.URL.Hostname()); err != nil {
+				return nil, err
+			}
+		}
+
+		return proxyURL, nil
+	}
+}
+
+// dialContext checks the addresses after DNS resolution, and connects to the checked address,
+// so the host can't resolve to a different address in the meantime
+func (l *loader) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		policy, ok := requestPolicyFrom(ctx)
+		if !ok || !policy.blockPrivateNetworks {
+			return dialer.DialContext(ctx, network, address)
+		}
+		if _, proxied := policy.proxies.Load(address); proxied {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		ips, err := policy.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		err = fmt.Errorf("no addresses found for %s", host)
+		for _, ip := range ips {
+			var conn net.Conn
+			conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+
+		return nil, err
+	}
+}
+
+func proxyAddress(proxyURL *url.URL) string {
+	if port := proxyURL.Port(); port != "" {
+		return net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+
+	port := "80"
+	switch proxyURL.Scheme {
+	case "https":
+		port = "443"
+	case "socks5":
+		port = "1080"
+	}
+
+	return net.JoinHostPort(proxyURL.Hostname(), port)
+}
+
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+func normalize(values []string) []string {
+	var result []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
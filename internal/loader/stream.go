@@ -0,0 +1,221 @@
+package loader
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+// Streamable returns true if the source can be streamed into the object storage without temporary files.
+// Sources with integrity verification are loaded, as they have to be verified before anything is published.
+func (l *loader) Streamable(source v1beta1.AssetSource) bool {
+	if len(source.Checksum) > 0 || source.Signature != nil {
+		return false
+	}
+
+	switch source.Mode {
+	case v1beta1.AssetSingle, v1beta1.AssetPackage, v1beta1.AssetIndex:
+		return true
+	}
+
+	return false
+}
+
+func (l *loader) Stream(namespace, assetName string, source v1beta1.AssetSource, sink Sink) (Result, error) {
+	if !l.Streamable(source) {
+		return Result{}, fmt.Errorf("streaming is not supported for the source in %s mode", source.Mode)
+	}
+	if err := l.checkOptions(source); err != nil {
+		return Result{}, err
+	}
+
+	header, err := l.credentials(namespace, source.SecretRef)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "while reading credentials")
+	}
+
+	options, err := l.requestOptions(namespace, source, header)
+	if err != nil {
+		return Result{}, err
+	}
+	switch source.Mode {
+	case v1beta1.AssetSingle:
+		return l.streamSingle(source.URL, assetName, options, sink)
+	case v1beta1.AssetPackage:
+		return l.streamPackage(assetName, source, options, sink)
+	default:
+		return l.streamIndex(source.URL, assetName, source.Filter, options, sink)
+	}
+}
+
+func (l *loader) streamSingle(src, name string, options requestOptions, sink Sink) (Result, error) {
+	response, err := l.open(src, options)
+	if err != nil {
+		return Result{}, err
+	}
+	defer response.Body.Close()
+
+	fileName := l.fileName(src)
+	digest := sha256.New()
+	if err := l.put(sink, name, fileName, io.TeeReader(response.Body, digest), response.ContentLength); err != nil {
+		return Result{}, errors.Wrapf(err, "while uploading %s", fileName)
+	}
+
+	return Result{
+		Files:        []string{fileName},
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+		Digest:       digestPrefix + hex.EncodeToString(digest.Sum(nil)),
+	}, nil
+}
+
+// streamPackage unpacks TAR packages straight from the response. ZIP packages need random access,
+// so only the package is stored in a temporary file, and its entries are streamed.
+func (l *loader) streamPackage(name string, source v1beta1.AssetSource, options requestOptions, sink Sink) (Result, error) {
+	filterRegexp, err := regexp.Compile(source.Filter)
+	if err != nil {
+		return Result{}, errors.Wrapf(err, "while compiling filter")
+	}
+
+	response, err := l.open(source.URL, options)
+	if err != nil {
+		return Result{}, err
+	}
+	defer response.Body.Close()
+
+	fileName := l.fileName(source.URL)
+	digest := sha256.New()
+	reader := bufio.NewReaderSize(io.TeeReader(response.Body, digest), archiveHeaderSize)
+	format, err := l.archiveFormat(source.ArchiveFormat, fileName, response.Header.Get("Content-Type"), func() ([]byte, error) {
+		header, err := reader.Peek(archiveHeaderSize)
+		if err != nil && err != io.EOF {
+			return nil, errors.Wrap(err, "while reading archive header")
+		}
+		return header, nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	extraction := l.newStreamExtraction(sink, source.StripComponents, source.TargetPrefix)
+	var files []string
+	if format == v1beta1.AssetArchiveZIP {
+		files, err = l.streamZIP(name, reader, extraction, filterRegexp)
+	} else {
+		files, err = l.streamTAR(format, reader, extraction, filterRegexp)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	// the digest covers the whole package, including everything after the end of the archive
+	if _, err := io.Copy(ioutil.Discard, reader); err != nil {
+		return Result{}, errors.Wrap(err, "while reading package")
+	}
+
+	return Result{
+		Files:        files,
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+		Digest:       digestPrefix + hex.EncodeToString(digest.Sum(nil)),
+	}, nil
+}
+
+func (l *loader) streamTAR(format v1beta1.AssetArchiveFormat, src io.Reader, extraction *extraction, filter matcher) ([]string, error) {
+	decompress, err := l.selectDecompressor(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.readTAR(src, extraction, filter, decompress)
+}
+
+func (l *loader) streamZIP(name string, src io.Reader, extraction *extraction, filter matcher) ([]string, error) {
+	file, err := l.spool(name, src)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	return l.unpackZIP(file.Name(), extraction, filter)
+}
+
+func (l *loader) streamIndex(src, name, filter string, options requestOptions, sink Sink) (Result, error) {
+	index, err := l.readFilteredIndex(src, filter, options)
+	if err != nil {
+		return Result{}, err
+	}
+
+	refs := newEntryRefs()
+	if err := l.processIndexEntries(index.entries, func(entry indexEntry) error {
+		response, err := l.open(entry.url, entry.options)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+
+		digest := sha256.New()
+		if err := l.put(sink, name, entry.name, io.TeeReader(response.Body, digest), response.ContentLength); err != nil {
+			return err
+		}
+		refs.add(entry, response.Header, digestPrefix+hex.EncodeToString(digest.Sum(nil)))
+		return nil
+	}); err != nil {
+		return Result{}, err
+	}
+
+	return index.result(refs, l.maxIndexEntryRefs), nil
+}
+
+// put passes the content to the sink. Content of unknown size is stored in a temporary file first,
+// as uploads of unknown size are buffered in memory in large parts.
+func (l *loader) put(sink Sink, assetName, fileName string, src io.Reader, size int64) error {
+	if size >= 0 {
+		return sink.Put(fileName, src, size)
+	}
+
+	file, err := l.spool(assetName, src)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Wrap(err, "while reading temporary file")
+	}
+
+	return sink.Put(fileName, file, info.Size())
+}
+
+// spool stores the content in a temporary file, which is ready to be read from the beginning
+func (l *loader) spool(name string, src io.Reader) (*os.File, error) {
+	file, err := ioutil.TempFile(l.temporaryDir, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating temporary file")
+	}
+
+	if _, err := io.Copy(file, src); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, errors.Wrap(err, "while writing temporary file")
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, errors.Wrap(err, "while reading temporary file")
+	}
+
+	return file, nil
+}
@@ -0,0 +1,362 @@
+package loader
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestLoader_Streamable(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		source   v1beta1.AssetSource
+		expected bool
+	}{
+		"Single": {
+			source:   v1beta1.AssetSource{Mode: v1beta1.AssetSingle},
+			expected: true,
+		},
+		"Package": {
+			source:   v1beta1.AssetSource{Mode: v1beta1.AssetPackage},
+			expected: true,
+		},
+		"Index": {
+			source:   v1beta1.AssetSource{Mode: v1beta1.AssetIndex},
+			expected: true,
+		},
+		"ConfigMap": {
+			source: v1beta1.AssetSource{Mode: v1beta1.AssetConfigMap},
+		},
+		"Git": {
+			source: v1beta1.AssetSource{Mode: v1beta1.AssetGit},
+		},
+		"WithChecksum": {
+			source: v1beta1.AssetSource{Mode: v1beta1.AssetSingle, Checksum: "sha256:0000"},
+		},
+		"WithSignature": {
+			source: v1beta1.AssetSource{Mode: v1beta1.AssetPackage, Signature: &v1beta1.AssetSignature{}},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{}
+
+			// When
+			streamable := loader.Streamable(testCase.source)
+
+			// Then
+			g.Expect(streamable).To(gomega.Equal(testCase.expected))
+		})
+	}
+}
+
+func TestLoader_Stream_Single(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		contentLength int64
+	}{
+		"KnownSize": {
+			contentLength: 6,
+		},
+		"UnknownSize": {
+			contentLength: -1,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			sink := newMemorySink()
+			loader := &loader{
+				temporaryDir: "/tmp",
+				httpDoFunc:   getStream([]byte("# Test"), testCase.contentLength),
+			}
+
+			// When
+			result, err := loader.Stream("", "asset", v1beta1.AssetSource{URL: "https://example.com/README.md", Mode: v1beta1.AssetSingle}, sink)
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.BasePath).To(gomega.BeEmpty())
+			g.Expect(result.Files).To(gomega.ConsistOf("README.md"))
+			g.Expect(result.Digest).To(gomega.Equal(loader.digest([]byte("# Test"))))
+			g.Expect(sink.files).To(gomega.Equal(map[string]string{"README.md": "# Test"}))
+		})
+	}
+
+	t.Run("UploadError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		sink := newMemorySink()
+		sink.err = errors.New("nope")
+		loader := &loader{
+			temporaryDir: "/tmp",
+			httpDoFunc:   getStream([]byte("# Test"), 6),
+		}
+
+		// When
+		_, err := loader.Stream("", "asset", v1beta1.AssetSource{URL: "https://example.com/README.md", Mode: v1beta1.AssetSingle}, sink)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestLoader_Stream_Package(t *testing.T) {
+	expected := []string{
+		"structure/swagger.json",
+		"structure/docs/README.md",
+	}
+
+	for testName, testCase := range map[string]struct {
+		path string
+	}{
+		"ZipArchive": {
+			path: "./testdata/structure.zip",
+		},
+		"TarGzArchive": {
+			path: "./testdata/structure.tar.gz",
+		},
+		"TarArchive": {
+			path: "./testdata/structure.tar",
+		},
+		"TarBz2Archive": {
+			path: "./testdata/structure.tar.bz2",
+		},
+		"TarXzArchive": {
+			path: "./testdata/structure.tar.xz",
+		},
+		"TarZstArchive": {
+			path: "./testdata/structure.tar.zst",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			sink := newMemorySink()
+			loader := &loader{
+				temporaryDir: "/tmp",
+				httpDoFunc:   getFile(testCase.path),
+			}
+
+			// When
+			result, err := loader.Stream("", "asset", v1beta1.AssetSource{URL: testCase.path, Mode: v1beta1.AssetPackage}, sink)
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.Files).To(gomega.ConsistOf(expected))
+			g.Expect(sink.files).To(gomega.HaveLen(2))
+			g.Expect(sink.files).To(gomega.HaveKey("structure/docs/README.md"))
+			digest, err := loader.digestFile(testCase.path)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result.Digest).To(gomega.Equal(digest))
+		})
+	}
+
+	t.Run("DetectedByMagicBytes", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		sink := newMemorySink()
+		loader := &loader{
+			temporaryDir: "/tmp",
+			httpDoFunc:   getFile("./testdata/structure.tar.gz"),
+		}
+
+		// When
+		result, err := loader.Stream("", "asset", v1beta1.AssetSource{URL: "https://example.com/download", Mode: v1beta1.AssetPackage}, sink)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Files).To(gomega.ConsistOf(expected))
+	})
+}
+
+func TestLoader_Stream_ArchivePolicy(t *testing.T) {
+	regular := func(name, content string) archiveEntry {
+		return archiveEntry{name: name, content: content, typeflag: tar.TypeReg}
+	}
+	symlink := func(name, linkName string) archiveEntry {
+		return archiveEntry{name: name, linkName: linkName, typeflag: tar.TypeSymlink}
+	}
+
+	for testName, testCase := range map[string]struct {
+		entries             []archiveEntry
+		stripComponents     int
+		targetPrefix        string
+		symlinkPolicy       string
+		maxUncompressedSize int64
+		maxFileSize         int64
+		expected            map[string]string
+		rejected            bool
+	}{
+		"Layout": {
+			entries:         []archiveEntry{regular("repo-1.0/docs/README.md", "readme"), regular("repo-1.0/LICENSE", "license")},
+			stripComponents: 1,
+			targetPrefix:    "v1",
+			expected:        map[string]string{"v1/docs/README.md": "readme", "v1/LICENSE": "license"},
+		},
+		"FollowSymlink": {
+			entries:       []archiveEntry{regular("docs/guide.md", "guide"), symlink("docs/index.md", "guide.md")},
+			symlinkPolicy: SymlinkFollow,
+			expected:      map[string]string{"docs/guide.md": "guide", "docs/index.md": "guide"},
+		},
+		"RejectLinks": {
+			entries:       []archiveEntry{regular("README.md", "readme"), symlink("index.md", "README.md")},
+			symlinkPolicy: SymlinkReject,
+			rejected:      true,
+		},
+		"RejectLinkToMissingFile": {
+			entries:       []archiveEntry{symlink("index.md", "README.md"), regular("README.md", "readme")},
+			symlinkPolicy: SymlinkFollow,
+			rejected:      true,
+		},
+		"RejectPathTraversal": {
+			entries:  []archiveEntry{regular("../evil.sh", "evil")},
+			rejected: true,
+		},
+		"RejectTooBigFile": {
+			entries:     []archiveEntry{regular("a.md", "123456")},
+			maxFileSize: 5,
+			rejected:    true,
+		},
+		"RejectTooBigArchive": {
+			entries:             []archiveEntry{regular("a.md", "12345"), symlink("b.md", "a.md")},
+			symlinkPolicy:       SymlinkFollow,
+			maxUncompressedSize: 9,
+			rejected:            true,
+		},
+	} {
+		for format, archive := range map[string]func(t *testing.T, entries []archiveEntry) []byte{
+			"package.tar": fixTAR,
+			"package.zip": fixZIP,
+		} {
+			t.Run(testName+"/"+format, func(t *testing.T) {
+				// Given
+				g := gomega.NewGomegaWithT(t)
+				sink := newMemorySink()
+				loader := &loader{
+					temporaryDir:        "/tmp",
+					httpDoFunc:          getBytes(archive(t, testCase.entries)),
+					maxUncompressedSize: testCase.maxUncompressedSize,
+					maxFileSize:         testCase.maxFileSize,
+					symlinkPolicy:       testCase.symlinkPolicy,
+				}
+				source := v1beta1.AssetSource{
+					URL:             "https://example.com/" + format,
+					Mode:            v1beta1.AssetPackage,
+					StripComponents: testCase.stripComponents,
+					TargetPrefix:    testCase.targetPrefix,
+				}
+
+				// When
+				result, err := loader.Stream("", "asset", source, sink)
+
+				// Then
+				if testCase.rejected {
+					g.Expect(err).To(gomega.HaveOccurred())
+					g.Expect(IsArchiveError(err)).To(gomega.BeTrue(), err.Error())
+					return
+				}
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(result.Files).To(gomega.HaveLen(len(testCase.expected)))
+				g.Expect(sink.files).To(gomega.Equal(testCase.expected))
+			})
+		}
+	}
+}
+
+func TestLoader_Stream_Index(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	sink := newMemorySink()
+	loader := &loader{
+		temporaryDir:      "/tmp",
+		indexWorkersCount: 2,
+		httpDoFunc: getContent(map[string]string{
+			"https://cdn.example.com/index.yaml":    "- README.md\n- docs/guide.md\n- swagger.json\n",
+			"https://cdn.example.com/README.md":     "readme",
+			"https://cdn.example.com/docs/guide.md": "guide",
+		}),
+	}
+
+	// When
+	result, err := loader.Stream("", "asset", v1beta1.AssetSource{URL: "https://cdn.example.com/index.yaml", Mode: v1beta1.AssetIndex, Filter: "\\.md$"}, sink)
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.Files).To(gomega.ConsistOf("README.md", "docs/guide.md"))
+	g.Expect(sink.files).To(gomega.Equal(map[string]string{"README.md": "readme", "docs/guide.md": "guide"}))
+	g.Expect(result.Entries).To(gomega.ConsistOf(
+		v1beta1.AssetEntryRef{URL: "https://cdn.example.com/README.md", Digest: "sha256:711a6108ba2ce6ca93dd47d6817f2361db10d8ab6eec89460b2dfc2c325efabe"},
+		v1beta1.AssetEntryRef{URL: "https://cdn.example.com/docs/guide.md", Digest: "sha256:83ca68be6227af2feb15f227485ed18aff8ecae99416a4bd6df3be1b5e8059b4"},
+	))
+}
+
+func TestLoader_Stream_NotSupported(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	loader := &loader{}
+
+	// When
+	_, err := loader.Stream("", "asset", v1beta1.AssetSource{URL: "https://example.com/repo.git", Mode: v1beta1.AssetGit}, newMemorySink())
+
+	// Then
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+type memorySink struct {
+	mu    sync.Mutex
+	files map[string]string
+	err   error
+}
+
+func newMemorySink() *memorySink {
+	return &memorySink{files: make(map[string]string)}
+}
+
+func (s *memorySink) Put(name string, reader io.Reader, size int64) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	content := make([]byte, size)
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[name] = string(content)
+
+	return nil
+}
+
+func (s *memorySink) Copy(source, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, ok := s.files[source]
+	if !ok {
+		return os.ErrNotExist
+	}
+	s.files[name] = content
+
+	return nil
+}
+
+func getStream(content []byte, contentLength int64) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			ContentLength: contentLength,
+			Body:          ioutil.NopCloser(bytes.NewReader(content)),
+		}, nil
+	}
+}
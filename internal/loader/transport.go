@@ -0,0 +1,195 @@
+package loader
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+const (
+	caBundleDefaultKey  = "ca.crt"
+	maxCachedTransports = 32
+)
+
+// transportOptions describe the connection settings of a source. They are comparable, so they identify the transport.
+type transportOptions struct {
+	caBundle           string
+	insecureSkipVerify bool
+	proxy              string
+}
+
+// transportCache keeps the transports, so connections are reused by sources with the same settings
+type transportCache struct {
+	mu         sync.Mutex
+	transports map[transportOptions]*http.Transport
+}
+
+type transportContextKey struct{}
+
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// transportOptions reads the CA bundle and the proxy credentials referenced by the source
+func (l *loader) transportOptions(namespace string, download *v1beta1.AssetDownload) (transportOptions, error) {
+	options := transportOptions{insecureSkipVerify: !l.verifySSL}
+	if download == nil {
+		return options, nil
+	}
+	options.insecureSkipVerify = options.insecureSkipVerify || download.InsecureSkipVerify
+
+	if download.CABundleRef != nil {
+		caBundle, err := l.caBundle(namespace, *download.CABundleRef)
+		if err != nil {
+			return transportOptions{}, errors.Wrap(err, "while reading CA bundle")
+		}
+		options.caBundle = caBundle
+	}
+
+	if download.Proxy != nil {
+		proxy, err := l.proxyURL(namespace, *download.Proxy)
+		if err != nil {
+			return transportOptions{}, errors.Wrap(err, "while reading proxy")
+		}
+		options.proxy = proxy.String()
+	}
+
+	return options, nil
+}
+
+func (l *loader) caBundle(namespace string, ref v1beta1.AssetCABundleRef) (string, error) {
+	if len(ref.Key) == 0 {
+		ref.Key = caBundleDefaultKey
+	}
+
+	content, refNamespace, err := l.referencedData(namespace, v1beta1.AssetPublicKeyRef(ref))
+	if err != nil {
+		return "", err
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(content) {
+		return "", fmt.Errorf("%s %s from %s namespace does not contain PEM encoded certificates under %s key", ref.Kind, ref.Name, refNamespace, ref.Key)
+	}
+
+	return string(content), nil
+}
+
+func (l *loader) proxyURL(namespace string, proxy v1beta1.AssetProxy) (*url.URL, error) {
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing proxy URL")
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("not supported proxy scheme %s", proxyURL.Scheme)
+	}
+	if len(proxyURL.Hostname()) == 0 {
+		return nil, fmt.Errorf("%s: missing proxy host", proxy.URL)
+	}
+
+	if proxy.SecretRef != nil {
+		secretNamespace, err := l.referenceNamespace(namespace, "Secret", proxy.SecretRef.Name, proxy.SecretRef.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := l.getSecret(secretNamespace, proxy.SecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		proxyURL.User = url.UserPassword(string(secret.Data[secretUsernameKey]), string(secret.Data[secretPasswordKey]))
+	}
+
+	return proxyURL, nil
+}
+
+// withTransport attaches the connection settings of the source to the request, so they are used on redirects as well
+func (l *loader) withTransport(request *http.Request, options transportOptions) *http.Request {
+	return request.WithContext(context.WithValue(request.Context(), transportContextKey{}, options))
+}
+
+// roundTrip sends the request with the transport matching the connection settings of the source
+func (l *loader) roundTrip(request *http.Request) (*http.Response, error) {
+	options, ok := request.Context().Value(transportContextKey{}).(transportOptions)
+	if !ok {
+		options = transportOptions{insecureSkipVerify: !l.verifySSL}
+	}
+
+	transport, err := l.transport(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return transport.RoundTrip(request)
+}
+
+func (l *loader) transport(options transportOptions) (*http.Transport, error) {
+	l.transports.mu.Lock()
+	defer l.transports.mu.Unlock()
+
+	if transport, ok := l.transports.transports[options]; ok {
+		return transport, nil
+	}
+
+	transport, err := l.newTransport(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// settings of sources change rarely, so the cache is simply dropped when it grows too big
+	if len(l.transports.transports) >= maxCachedTransports {
+		for _, cached := range l.transports.transports {
+			cached.CloseIdleConnections()
+		}
+		l.transports.transports = nil
+	}
+	if l.transports.transports == nil {
+		l.transports.transports = make(map[transportOptions]*http.Transport)
+	}
+	l.transports.transports[options] = transport
+
+	return transport, nil
+}
+
+// newTransport returns the transport which enforces the source policy when connections are opened
+func (l *loader) newTransport(options transportOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = l.proxy
+	transport.DialContext = l.dialContext(&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	})
+
+	if len(options.proxy) > 0 {
+		proxyURL, err := url.Parse(options.proxy)
+		if err != nil {
+			return nil, errors.Wrap(err, "while parsing proxy URL")
+		}
+		transport.Proxy = l.sourceProxy(proxyURL)
+	}
+
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: options.insecureSkipVerify,
+	}
+	if len(options.caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AppendCertsFromPEM([]byte(options.caBundle))
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	return transport, nil
+}
@@ -0,0 +1,193 @@
+package loader
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestLoader_Load_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("# Test"))
+	}))
+	defer server.Close()
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	fakedc, err := newFakeDynamicClient(
+		fixConfigMap("ca", "default", map[string]string{"ca.crt": string(certificate)}, nil),
+		fixSecret("ca", "default", map[string][]byte{"bundle.pem": certificate}),
+		fixSecret("invalid", "default", map[string][]byte{"ca.crt": []byte("not a certificate")}),
+		fixConfigMap("ca", "other", map[string]string{"ca.crt": string(certificate)}, nil),
+	)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+
+	for testName, testCase := range map[string]struct {
+		download     *v1beta1.AssetDownload
+		failed       bool
+		accessDenied bool
+	}{
+		"ConfigMap": {
+			download: &v1beta1.AssetDownload{CABundleRef: &v1beta1.AssetCABundleRef{Kind: v1beta1.AssetPublicKeyConfigMap, Name: "ca"}},
+		},
+		"SecretWithKey": {
+			download: &v1beta1.AssetDownload{CABundleRef: &v1beta1.AssetCABundleRef{Kind: v1beta1.AssetPublicKeySecret, Name: "ca", Key: "bundle.pem"}},
+		},
+		"InsecureSkipVerify": {
+			download: &v1beta1.AssetDownload{InsecureSkipVerify: true},
+		},
+		"UnknownAuthority": {
+			failed: true,
+		},
+		"InvalidBundle": {
+			download: &v1beta1.AssetDownload{CABundleRef: &v1beta1.AssetCABundleRef{Kind: v1beta1.AssetPublicKeySecret, Name: "invalid"}},
+			failed:   true,
+		},
+		"OtherNamespace": {
+			download:     &v1beta1.AssetDownload{CABundleRef: &v1beta1.AssetCABundleRef{Kind: v1beta1.AssetPublicKeyConfigMap, Name: "ca", Namespace: "other"}},
+			failed:       true,
+			accessDenied: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := New(fakedc, Config{
+				TemporaryDirectory: "/tmp",
+				VerifySSL:          true,
+			})
+
+			// When
+			result, err := loader.Load("default", "asset", v1beta1.AssetSource{
+				URL:      server.URL + "/README.md",
+				Mode:     v1beta1.AssetSingle,
+				Download: testCase.download,
+			})
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(IsAccessDeniedError(err)).To(gomega.Equal(testCase.accessDenied))
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(readResult(t, result, "README.md")).To(gomega.Equal("# Test"))
+		})
+	}
+}
+
+func TestLoader_Load_Proxy(t *testing.T) {
+	fakedc, err := newFakeDynamicClient(
+		fixSecret("proxy", "default", map[string][]byte{"username": []byte("user"), "password": []byte("secret")}),
+	)
+	gomega.NewGomegaWithT(t).Expect(err).NotTo(gomega.HaveOccurred())
+
+	for testName, testCase := range map[string]struct {
+		secretRef            *v1beta1.AssetSecretRef
+		deniedHosts          []string
+		blockPrivateNetworks bool
+		authorization        string
+		policyError          bool
+	}{
+		"Proxy": {},
+		"ProxyWithCredentials": {
+			secretRef:     &v1beta1.AssetSecretRef{Name: "proxy"},
+			authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret")),
+		},
+		"DeniedProxy": {
+			deniedHosts: []string{"127.0.0.1"},
+			policyError: true,
+		},
+		"PrivateProxy": {
+			blockPrivateNetworks: true,
+			policyError:          true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			var requests []*http.Request
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r)
+				w.Write([]byte("# Test"))
+			}))
+			defer proxy.Close()
+
+			loader := New(fakedc, Config{
+				TemporaryDirectory:   "/tmp",
+				DeniedHosts:          testCase.deniedHosts,
+				BlockPrivateNetworks: testCase.blockPrivateNetworks,
+			})
+
+			// When
+			result, err := loader.Load("default", "asset", v1beta1.AssetSource{
+				URL:  "http://example.com/README.md",
+				Mode: v1beta1.AssetSingle,
+				Download: &v1beta1.AssetDownload{
+					Proxy: &v1beta1.AssetProxy{URL: proxy.URL, SecretRef: testCase.secretRef},
+				},
+			})
+			defer loader.Clean(result.BasePath)
+
+			// Then
+			if testCase.policyError {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(IsSourcePolicyError(err)).To(gomega.BeTrue())
+				g.Expect(requests).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(readResult(t, result, "README.md")).To(gomega.Equal("# Test"))
+			g.Expect(requests).To(gomega.HaveLen(1))
+			g.Expect(requests[0].URL.String()).To(gomega.Equal("http://example.com/README.md"))
+			g.Expect(requests[0].Header.Get("Proxy-Authorization")).To(gomega.Equal(testCase.authorization))
+		})
+	}
+}
+
+func TestLoader_TransportOptions_InvalidProxy(t *testing.T) {
+	for testName, proxyURL := range map[string]string{
+		"Scheme":  "ftp://proxy.example.com",
+		"NoHost":  "http://",
+		"Invalid": "http://proxy example.com:port",
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{}
+
+			// When
+			_, err := loader.transportOptions("default", &v1beta1.AssetDownload{Proxy: &v1beta1.AssetProxy{URL: proxyURL}})
+
+			// Then
+			g.Expect(err).To(gomega.HaveOccurred())
+		})
+	}
+}
+
+func TestLoader_GitEnvironment(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	loader := &loader{temporaryDir: "/tmp", osRemoveAllFunc: os.RemoveAll}
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+	transport := transportOptions{caBundle: "bundle", insecureSkipVerify: true, proxy: "http://proxy.example.com:3128"}
+
+	// When
+	env, cleanup, err := loader.gitEnvironment(header, transport)
+	defer cleanup()
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(env).To(gomega.ContainElement("GIT_CONFIG_VALUE_0=Authorization: Bearer token"))
+	g.Expect(env).To(gomega.ContainElement("GIT_CONFIG_KEY_1=http.proxy"))
+	g.Expect(env).To(gomega.ContainElement("GIT_CONFIG_VALUE_1=http://proxy.example.com:3128"))
+	g.Expect(env).To(gomega.ContainElement("GIT_CONFIG_COUNT=2"))
+	g.Expect(env).To(gomega.ContainElement("GIT_SSL_NO_VERIFY=true"))
+	g.Expect(env).To(gomega.ContainElement(gomega.HavePrefix("GIT_SSL_CAINFO=/tmp/ca-bundle")))
+}
@@ -0,0 +1,196 @@
+package loader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const digestPrefix = checksumSHA256Prefix + checksumSeparator
+
+// Changed checks if the source content differs from the one described by the asset status. Remote sources are checked
+// with conditional requests, so the content is downloaded only if the server does not support them.
+func (l *loader) Changed(namespace string, source v1beta1.AssetSource, ref v1beta1.AssetStatusRef) (bool, error) {
+	switch source.Mode {
+	case v1beta1.AssetSingle, v1beta1.AssetPackage, v1beta1.AssetIndex:
+		header, err := l.credentials(namespace, source.SecretRef)
+		if err != nil {
+			return false, errors.Wrap(err, "while reading credentials")
+		}
+		options, err := l.requestOptions(namespace, source, header)
+		if err != nil {
+			return false, err
+		}
+		if source.Mode == v1beta1.AssetIndex {
+			return l.indexChanged(source.URL, options, ref)
+		}
+		return l.remoteChanged(source.URL, options, ref)
+	case v1beta1.AssetConfigMap:
+		return l.configMapChanged(namespace, source.URL, ref)
+	case v1beta1.AssetSecret:
+		return l.secretChanged(namespace, source.URL, ref)
+	case v1beta1.AssetGit:
+		header, err := l.credentials(namespace, source.SecretRef)
+		if err != nil {
+			return false, errors.Wrap(err, "while reading credentials")
+		}
+		transport, err := l.transportOptions(namespace, source.Download)
+		if err != nil {
+			return false, err
+		}
+		return l.repositoryChanged(source.URL, source.Git, header, transport, ref)
+	default:
+		return false, fmt.Errorf("not supported source mode %+v", source.Mode)
+	}
+}
+
+func (l *loader) remoteChanged(src string, options requestOptions, ref v1beta1.AssetStatusRef) (bool, error) {
+	conditionalHeader := http.Header{}
+	for key, values := range options.header {
+		conditionalHeader[key] = values
+	}
+	if len(ref.ETag) > 0 {
+		conditionalHeader.Set("If-None-Match", ref.ETag)
+	}
+	if len(ref.LastModified) > 0 {
+		conditionalHeader.Set("If-Modified-Since", ref.LastModified)
+	}
+
+	response, err := l.get(src, options.withHeader(conditionalHeader))
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotModified:
+		return false, nil
+	case response.StatusCode < 200 || response.StatusCode > 299:
+		return false, errors.New(response.Status)
+	case len(ref.ETag) > 0 && response.Header.Get("ETag") == ref.ETag:
+		return false, nil
+	case len(ref.Digest) > 0:
+		digest := sha256.New()
+		if _, err := io.Copy(digest, response.Body); err != nil {
+			return false, errors.Wrap(err, "while reading content")
+		}
+		return digestPrefix+hex.EncodeToString(digest.Sum(nil)) != ref.Digest, nil
+	case len(ref.LastModified) > 0 && response.Header.Get("Last-Modified") == ref.LastModified:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+func (l *loader) configMapChanged(namespace, src string, ref v1beta1.AssetStatusRef) (bool, error) {
+	configMap, err := l.sourceConfigMap(namespace, src)
+	if err != nil {
+		return false, err
+	}
+
+	return l.configMapDigest(configMap) != ref.Digest, nil
+}
+
+func (l *loader) secretChanged(namespace, src string, ref v1beta1.AssetStatusRef) (bool, error) {
+	secret, err := l.sourceSecret(namespace, src)
+	if err != nil {
+		return false, err
+	}
+
+	return l.dataDigest(secret.Data) != ref.Digest, nil
+}
+
+// repositoryChanged compares the revision the reference points to with the loaded one. References which are not
+// branches or tags are treated as commits, which never change.
+func (l *loader) repositoryChanged(src string, git *v1beta1.AssetGitSource, header http.Header, transport transportOptions, ref v1beta1.AssetStatusRef) (bool, error) {
+	gitRef := "HEAD"
+	if git != nil && len(git.Ref) > 0 {
+		gitRef = git.Ref
+	}
+
+	options, protocolEnv, err := l.checkRepository(src, transport)
+	if err != nil {
+		return false, err
+	}
+
+	env, cleanup, err := l.gitEnvironment(header, transport)
+	if err != nil {
+		return false, err
+	}
+	defer cleanup()
+	env = append(env, protocolEnv...)
+
+	output, err := l.runGit("", env, append(options, "ls-remote", "--", src)...)
+	if err != nil {
+		return false, errors.Wrap(err, "while listing remote references")
+	}
+
+	revisions := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		revisions[fields[1]] = fields[0]
+	}
+
+	for _, name := range []string{gitRef, "refs/heads/" + gitRef, "refs/tags/" + gitRef + "^{}", "refs/tags/" + gitRef} {
+		if revision, ok := revisions[name]; ok {
+			return revision != ref.Revision, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (l *loader) digestFile(path string) (string, error) {
+	digest, err := l.sha256File(path)
+	if err != nil {
+		return "", err
+	}
+
+	return digestPrefix + hex.EncodeToString(digest), nil
+}
+
+func (l *loader) digest(content []byte) string {
+	digest := sha256.Sum256(content)
+	return digestPrefix + hex.EncodeToString(digest[:])
+}
+
+func (l *loader) configMapDigest(configMap *corev1.ConfigMap) string {
+	values := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		values[key] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		values[key] = value
+	}
+
+	return l.dataDigest(values)
+}
+
+func (l *loader) dataDigest(values map[string][]byte) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	digest := sha256.New()
+	for _, key := range keys {
+		digest.Write([]byte(key))
+		digest.Write([]byte{0})
+		digest.Write(values[key])
+		digest.Write([]byte{0})
+	}
+
+	return digestPrefix + hex.EncodeToString(digest.Sum(nil))
+}
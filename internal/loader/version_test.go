@@ -0,0 +1,384 @@
+package loader
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestLoader_Load_Version(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	loader := &loader{
+		temporaryDir:    "/tmp",
+		osRemoveAllFunc: os.RemoveAll,
+		osCreateFunc:    os.Create,
+		httpDoFunc:      respond(http.StatusOK, "\"v1\"", "Wed, 21 Oct 2015 07:28:00 GMT", "ala ma kota"),
+		ioutilTempDir:   ioutil.TempDir,
+	}
+	source := v1beta1.AssetSource{URL: "https://example.com/spec.json", Mode: v1beta1.AssetSingle}
+
+	// When
+	result, err := loader.Load("", "asset", source)
+	defer loader.Clean(result.BasePath)
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.ETag).To(gomega.Equal("\"v1\""))
+	g.Expect(result.LastModified).To(gomega.Equal("Wed, 21 Oct 2015 07:28:00 GMT"))
+	g.Expect(result.Digest).To(gomega.Equal("sha256:c623e3ee2d7fa2c770f19cace523191cf92f1d59b0678bbbb1825817c9a61575"))
+}
+
+func TestLoader_Changed_Remote(t *testing.T) {
+	lastModified := "Wed, 21 Oct 2015 07:28:00 GMT"
+	contentDigest := (&loader{}).digest([]byte("ala ma kota"))
+
+	for testName, testCase := range map[string]struct {
+		ref          v1beta1.AssetStatusRef
+		status       int
+		etag         string
+		lastModified string
+		content      string
+		expected     bool
+	}{
+		"NotModified": {
+			ref:    v1beta1.AssetStatusRef{ETag: "\"v1\""},
+			status: http.StatusNotModified,
+		},
+		"SameETag": {
+			ref:    v1beta1.AssetStatusRef{ETag: "\"v1\""},
+			status: http.StatusOK,
+			etag:   "\"v1\"",
+		},
+		"DifferentETag": {
+			ref:      v1beta1.AssetStatusRef{ETag: "\"v1\""},
+			status:   http.StatusOK,
+			etag:     "\"v2\"",
+			expected: true,
+		},
+		"DifferentETagSameDigest": {
+			ref:     v1beta1.AssetStatusRef{ETag: "\"v1\"", Digest: contentDigest},
+			status:  http.StatusOK,
+			etag:    "\"v2\"",
+			content: "ala ma kota",
+		},
+		"DifferentDigest": {
+			ref:      v1beta1.AssetStatusRef{Digest: contentDigest},
+			status:   http.StatusOK,
+			content:  "ala ma psa",
+			expected: true,
+		},
+		"SameLastModified": {
+			ref:          v1beta1.AssetStatusRef{LastModified: lastModified},
+			status:       http.StatusOK,
+			lastModified: lastModified,
+		},
+		"NoValidators": {
+			status:   http.StatusOK,
+			expected: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{
+				httpDoFunc: respond(testCase.status, testCase.etag, testCase.lastModified, testCase.content),
+			}
+			source := v1beta1.AssetSource{URL: "https://example.com/spec.json", Mode: v1beta1.AssetSingle}
+
+			// When
+			changed, err := loader.Changed("", source, testCase.ref)
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(changed).To(gomega.Equal(testCase.expected))
+		})
+	}
+
+	t.Run("ConditionalHeaders", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		var request *http.Request
+		loader := &loader{
+			httpDoFunc: func(req *http.Request) (*http.Response, error) {
+				request = req
+				return respond(http.StatusNotModified, "", "", "")(req)
+			},
+		}
+		source := v1beta1.AssetSource{URL: "https://example.com/index.yaml", Mode: v1beta1.AssetIndex}
+
+		// When
+		_, err := loader.Changed("", source, v1beta1.AssetStatusRef{ETag: "\"v1\"", LastModified: lastModified})
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(request.Header.Get("If-None-Match")).To(gomega.Equal("\"v1\""))
+		g.Expect(request.Header.Get("If-Modified-Since")).To(gomega.Equal(lastModified))
+	})
+
+	t.Run("FailServerError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		loader := &loader{
+			httpDoFunc: respond(http.StatusInternalServerError, "", "", ""),
+		}
+		source := v1beta1.AssetSource{URL: "https://example.com/spec.json", Mode: v1beta1.AssetSingle}
+
+		// When
+		_, err := loader.Changed("", source, v1beta1.AssetStatusRef{})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestLoader_Changed_Index(t *testing.T) {
+	source := v1beta1.AssetSource{URL: "https://example.com/index.yaml", Mode: v1beta1.AssetIndex}
+	indexRef := v1beta1.AssetStatusRef{ETag: "\"index\""}
+
+	for testName, testCase := range map[string]struct {
+		indexETag string
+		entries   []v1beta1.AssetEntryRef
+		expected  bool
+	}{
+		"SameEntries": {
+			indexETag: "\"index\"",
+			entries: []v1beta1.AssetEntryRef{
+				{URL: "https://example.com/README.md", ETag: "\"readme\""},
+				{URL: "https://cdn.example.com/logo.svg", ETag: "\"logo\""},
+			},
+		},
+		"DifferentEntry": {
+			indexETag: "\"index\"",
+			entries: []v1beta1.AssetEntryRef{
+				{URL: "https://example.com/README.md", ETag: "\"readme\""},
+				{URL: "https://cdn.example.com/logo.svg", ETag: "\"old\""},
+			},
+			expected: true,
+		},
+		"DifferentIndex": {
+			indexETag: "\"new\"",
+			expected:  true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			etags := map[string]string{
+				"https://example.com/index.yaml":   testCase.indexETag,
+				"https://example.com/README.md":    "\"readme\"",
+				"https://cdn.example.com/logo.svg": "\"logo\"",
+			}
+			loader := &loader{
+				indexWorkersCount: 2,
+				httpDoFunc: func(req *http.Request) (*http.Response, error) {
+					etag := etags[req.URL.String()]
+					if req.Header.Get("If-None-Match") == etag {
+						return respond(http.StatusNotModified, etag, "", "")(req)
+					}
+					return respond(http.StatusOK, etag, "", "content")(req)
+				},
+			}
+			ref := indexRef
+			ref.Entries = testCase.entries
+
+			// When
+			changed, err := loader.Changed("", source, ref)
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(changed).To(gomega.Equal(testCase.expected))
+		})
+	}
+}
+
+func TestLoader_Changed_IndexEntriesInterval(t *testing.T) {
+	source := v1beta1.AssetSource{URL: "https://example.com/index.yaml", Mode: v1beta1.AssetIndex}
+
+	for testName, testCase := range map[string]struct {
+		entryETag        string
+		expected         bool
+		expectedRequests int
+	}{
+		"NotChanged": {
+			entryETag:        "\"readme\"",
+			expectedRequests: 3,
+		},
+		"Changed": {
+			entryETag:        "\"old\"",
+			expected:         true,
+			expectedRequests: 4,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			etags := map[string]string{
+				"https://example.com/index.yaml": "\"index\"",
+				"https://example.com/README.md":  "\"readme\"",
+			}
+			var requests int32
+			loader := &loader{
+				indexWorkersCount:    1,
+				indexEntriesInterval: time.Hour,
+				httpDoFunc: func(req *http.Request) (*http.Response, error) {
+					atomic.AddInt32(&requests, 1)
+					etag := etags[req.URL.String()]
+					if req.Header.Get("If-None-Match") == etag {
+						return respond(http.StatusNotModified, etag, "", "")(req)
+					}
+					return respond(http.StatusOK, etag, "", "content")(req)
+				},
+			}
+			ref := v1beta1.AssetStatusRef{
+				ETag:    "\"index\"",
+				Entries: []v1beta1.AssetEntryRef{{URL: "https://example.com/README.md", ETag: testCase.entryETag}},
+			}
+
+			// When
+			first, err := loader.Changed("", source, ref)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			second, err := loader.Changed("", source, ref)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			// Then
+			g.Expect(first).To(gomega.Equal(testCase.expected))
+			g.Expect(second).To(gomega.Equal(testCase.expected))
+			g.Expect(int(atomic.LoadInt32(&requests))).To(gomega.Equal(testCase.expectedRequests))
+		})
+	}
+}
+
+func TestLoader_Changed_ConfigMap(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	configMap := fixConfigMap("docs", "default", map[string]string{"README.md": "readme"}, nil)
+	fakedc, err := newFakeDynamicClient(configMap)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	loader := &loader{
+		dynamicClient: fakedc,
+	}
+	source := v1beta1.AssetSource{URL: "default/docs", Mode: v1beta1.AssetConfigMap}
+
+	// When
+	notChanged, err := loader.Changed("default", source, v1beta1.AssetStatusRef{Digest: loader.configMapDigest(configMap)})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	changed, err := loader.Changed("default", source, v1beta1.AssetStatusRef{Digest: "sha256:00"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// Then
+	g.Expect(notChanged).To(gomega.BeFalse())
+	g.Expect(changed).To(gomega.BeTrue())
+}
+
+func TestLoader_Changed_ConfigMapAccessDenied(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	fakedc, err := newFakeDynamicClient(fixConfigMap("docs", "default", map[string]string{"README.md": "readme"}, nil))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	loader := &loader{
+		dynamicClient: fakedc,
+	}
+	source := v1beta1.AssetSource{URL: "default/docs", Mode: v1beta1.AssetConfigMap}
+
+	// When
+	_, err = loader.Changed("other", source, v1beta1.AssetStatusRef{Digest: "sha256:00"})
+
+	// Then
+	g.Expect(IsAccessDeniedError(err)).To(gomega.BeTrue())
+}
+
+func TestLoader_Changed_Secret(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	secret := fixSecret("certs", "default", map[string][]byte{"tls.crt": []byte("certificate")})
+	fakedc, err := newFakeDynamicClient(secret)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	loader := &loader{
+		dynamicClient: fakedc,
+	}
+	source := v1beta1.AssetSource{URL: "certs", Mode: v1beta1.AssetSecret}
+
+	// When
+	notChanged, err := loader.Changed("default", source, v1beta1.AssetStatusRef{Digest: loader.dataDigest(secret.Data)})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	changed, err := loader.Changed("default", source, v1beta1.AssetStatusRef{Digest: "sha256:00"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// Then
+	g.Expect(notChanged).To(gomega.BeFalse())
+	g.Expect(changed).To(gomega.BeTrue())
+}
+
+func TestLoader_Changed_Git(t *testing.T) {
+	repository, commits := createRepository(t)
+
+	for testName, testCase := range map[string]struct {
+		git      *v1beta1.AssetGitSource
+		revision string
+		expected bool
+	}{
+		"DefaultBranchNotChanged": {
+			revision: commits[1],
+		},
+		"DefaultBranchChanged": {
+			revision: commits[0],
+			expected: true,
+		},
+		"Branch": {
+			git:      &v1beta1.AssetGitSource{Ref: "master"},
+			revision: commits[0],
+			expected: true,
+		},
+		"Tag": {
+			git:      &v1beta1.AssetGitSource{Ref: "v1"},
+			revision: commits[0],
+		},
+		"Commit": {
+			git:      &v1beta1.AssetGitSource{Ref: commits[0]},
+			revision: commits[0],
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			loader := &loader{
+				gitAllowProtocol: "file",
+			}
+			source := v1beta1.AssetSource{URL: repository, Mode: v1beta1.AssetGit, Git: testCase.git}
+
+			// When
+			changed, err := loader.Changed("", source, v1beta1.AssetStatusRef{Revision: testCase.revision})
+
+			// Then
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(changed).To(gomega.Equal(testCase.expected))
+		})
+	}
+}
+
+func respond(status int, etag, lastModified, content string) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		if etag != "" {
+			header.Set("ETag", etag)
+		}
+		if lastModified != "" {
+			header.Set("Last-Modified", lastModified)
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(content))),
+		}, nil
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/golang/glog"
 	"github.com/kyma-project/rafter/internal/bucket"
 	"github.com/kyma-project/rafter/internal/fileheader"
+	"github.com/kyma-project/rafter/internal/store"
 	"github.com/kyma-project/rafter/internal/uploader"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go/pkg/encrypt"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -23,6 +26,7 @@ type RequestHandler struct {
 	uploadTimeout        time.Duration
 	maxUploadWorkers     int
 	buckets              bucket.SystemBucketNames
+	privateEncryption    encrypt.ServerSide
 	externalUploadOrigin string
 }
 
@@ -51,19 +55,22 @@ func incrementStatusCounter(status int) {
 	statusCodesCounter.WithLabelValues(strconv.Itoa(status)).Inc()
 }
 
-func SetupHandlers(client uploader.MinioClient, buckets bucket.SystemBucketNames, uploadExternalEndpoint string, timeout time.Duration, maxWorkers int) *http.ServeMux {
+func SetupHandlers(client uploader.MinioClient, buckets bucket.SystemBucketNames, privateEncryption encrypt.ServerSide, uploadExternalEndpoint string, timeout time.Duration, maxWorkers int) *http.ServeMux {
 	mux := http.NewServeMux()
-	mux.Handle("/v1/upload", New(client, buckets, uploadExternalEndpoint, timeout, maxWorkers))
+	mux.Handle("/v1/upload", New(client, buckets, privateEncryption, uploadExternalEndpoint, timeout, maxWorkers))
 	mux.Handle("/metrics", promhttp.Handler())
 	return mux
 }
 
-func New(client uploader.MinioClient, buckets bucket.SystemBucketNames, externalUploadOrigin string, uploadTimeout time.Duration, maxUploadWorkers int) *RequestHandler {
+// New returns the handler uploading files to the system buckets. The files uploaded to the private bucket are encrypted
+// with the private encryption, if it is not nil.
+func New(client uploader.MinioClient, buckets bucket.SystemBucketNames, privateEncryption encrypt.ServerSide, externalUploadOrigin string, uploadTimeout time.Duration, maxUploadWorkers int) *RequestHandler {
 	return &RequestHandler{
 		client:               client,
 		uploadTimeout:        uploadTimeout,
 		maxUploadWorkers:     maxUploadWorkers,
 		buckets:              buckets,
+		privateEncryption:    privateEncryption,
 		externalUploadOrigin: externalUploadOrigin,
 	}
 }
@@ -114,6 +121,20 @@ func (r *RequestHandler) ServeHTTP(w http.ResponseWriter, rq *http.Request) {
 		directory = directoryValues[0]
 	}
 
+	metadata, err := r.objectMetadata(rq.MultipartForm.Value["metadata"])
+	if err != nil {
+		status := http.StatusBadRequest
+		incrementStatusCounter(status)
+		r.writeResponse(w, status, Response{
+			Errors: []ResponseError{
+				{
+					Message: err.Error(),
+				},
+			},
+		})
+		return
+	}
+
 	privateFiles := rq.MultipartForm.File["private"]
 	publicFiles := rq.MultipartForm.File["public"]
 	filesCount := len(publicFiles) + len(privateFiles)
@@ -132,7 +153,7 @@ func (r *RequestHandler) ServeHTTP(w http.ResponseWriter, rq *http.Request) {
 	}
 
 	u := uploader.New(r.client, r.externalUploadOrigin, r.uploadTimeout, r.maxUploadWorkers)
-	fileToUploadCh := r.populateFilesChannel(publicFiles, privateFiles, filesCount, directory)
+	fileToUploadCh := r.populateFilesChannel(publicFiles, privateFiles, filesCount, directory, metadata)
 	uploadedFiles, errs := u.UploadFiles(context.Background(), fileToUploadCh, filesCount)
 
 	glog.Infof("Finished processing request with uploading %d files.", filesCount)
@@ -171,9 +192,24 @@ func (r *RequestHandler) generateDirectoryName() string {
 	return strconv.FormatInt(unixTime, 32)
 }
 
-func (r *RequestHandler) populateFilesChannel(publicFiles, privateFiles []*multipart.FileHeader, filesCount int, directory string) chan uploader.FileUpload {
+// objectMetadata reads the object metadata rules, encoded in JSON in the same way as in the Asset specification
+func (r *RequestHandler) objectMetadata(values []string) (store.ObjectMetadata, error) {
+	if len(values) == 0 {
+		return store.ObjectMetadata{}, nil
+	}
+
+	var rules []v1beta1.AssetObjectMetadata
+	if err := json.Unmarshal([]byte(values[0]), &rules); err != nil {
+		return store.ObjectMetadata{}, errors.Wrap(err, "while parsing object metadata rules")
+	}
+
+	return store.NewObjectMetadata(rules)
+}
+
+func (r *RequestHandler) populateFilesChannel(publicFiles, privateFiles []*multipart.FileHeader, filesCount int, directory string, metadata store.ObjectMetadata) chan uploader.FileUpload {
 	filesCh := make(chan uploader.FileUpload, filesCount)
 
+	privateMetadata := metadata.WithEncryption(r.privateEncryption)
 	go func() {
 		defer close(filesCh)
 		for _, file := range publicFiles {
@@ -181,6 +217,7 @@ func (r *RequestHandler) populateFilesChannel(publicFiles, privateFiles []*multi
 				Bucket:    r.buckets.Public,
 				File:      fileheader.FromMultipart(file),
 				Directory: directory,
+				Metadata:  metadata,
 			}
 		}
 		for _, file := range privateFiles {
@@ -188,6 +225,7 @@ func (r *RequestHandler) populateFilesChannel(publicFiles, privateFiles []*multi
 				Bucket:    r.buckets.Private,
 				File:      fileheader.FromMultipart(file),
 				Directory: directory,
+				Metadata:  privateMetadata,
 			}
 		}
 	}()
@@ -16,15 +16,16 @@ import (
 	"testing"
 	"time"
 
-	"github.com/minio/minio-go"
 	"github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/kyma-project/rafter/internal/bucket"
 	"github.com/kyma-project/rafter/internal/requesthandler"
+	"github.com/kyma-project/rafter/internal/store"
 	"github.com/kyma-project/rafter/internal/uploader"
 	"github.com/kyma-project/rafter/internal/uploader/automock"
+	"github.com/minio/minio-go/pkg/encrypt"
 )
 
 func TestRequestHandler_ServeHTTP(t *testing.T) {
@@ -39,8 +40,8 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
 		client := &automock.MinioClient{}
-		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), minio.PutObjectOptions{}).Return(int64(1), nil).Once()
-		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), minio.PutObjectOptions{}).Return(int64(1), nil).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.yaml")).Return(int64(1), nil).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.txt")).Return(int64(1), nil).Once()
 		defer client.AssertExpectations(t)
 
 		files := []RequestFile{
@@ -71,7 +72,7 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 
 		// When
 
-		httpResp, result := testServeHTTP(g, client, files, "")
+		httpResp, result := testServeHTTP(g, client, files, "", "")
 
 		// Then
 
@@ -86,12 +87,46 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 		}
 	})
 
+	t.Run("Private Encryption", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		sse := encrypt.NewSSE()
+		client := &automock.MinioClient{}
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.yaml")).Return(int64(1), nil).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.WithEncryption(sse).Options("sample.txt")).Return(int64(1), nil).Once()
+		defer client.AssertExpectations(t)
+
+		buckets := bucket.SystemBucketNames{
+			Private: "private",
+			Public:  "public",
+		}
+		handler := requesthandler.New(client, buckets, sse, "https://example.com", 10*time.Second, 5)
+		rq, err := fixRequest([]RequestFile{
+			{
+				FieldName: "private",
+				Path:      "./testdata/sample.txt",
+			},
+			{
+				FieldName: "public",
+				Path:      "./testdata/sample.yaml",
+			},
+		}, "", "")
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		w := httptest.NewRecorder()
+
+		// When
+		handler.ServeHTTP(w, rq)
+
+		// Then
+		g.Expect(w.Result().StatusCode).To(gomega.Equal(http.StatusOK))
+	})
+
 	t.Run("Custom Directory", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
 		client := &automock.MinioClient{}
-		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), minio.PutObjectOptions{}).Return(int64(1), nil).Once()
-		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), minio.PutObjectOptions{}).Return(int64(1), nil).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.yaml")).Return(int64(1), nil).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.txt")).Return(int64(1), nil).Once()
 		defer client.AssertExpectations(t)
 
 		files := []RequestFile{
@@ -121,7 +156,7 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 		}
 
 		// When
-		httpResp, result := testServeHTTP(g, client, files, directoryName)
+		httpResp, result := testServeHTTP(g, client, files, directoryName, "")
 
 		// Then
 		g.Expect(httpResp.StatusCode).To(gomega.Equal(http.StatusOK))
@@ -133,6 +168,57 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 		}
 	})
 
+	t.Run("Object Metadata", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		options := store.ObjectMetadata{}.Options("sample.yaml")
+		options.CacheControl = "no-cache"
+		options.UserMetadata = map[string]string{"team": "docs"}
+
+		client := &automock.MinioClient{}
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", "test/sample.yaml", mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), options).Return(int64(1), nil).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", "test/sample.txt", mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.txt")).Return(int64(1), nil).Once()
+		defer client.AssertExpectations(t)
+
+		files := []RequestFile{
+			{
+				FieldName: "private",
+				Path:      "./testdata/sample.txt",
+			},
+			{
+				FieldName: "public",
+				Path:      "./testdata/sample.yaml",
+			},
+		}
+		metadata := `[{"pattern": "*.yaml", "cacheControl": "no-cache", "userMetadata": {"team": "docs"}}]`
+
+		// When
+		httpResp, result := testServeHTTP(g, client, files, "test", metadata)
+
+		// Then
+		g.Expect(httpResp.StatusCode).To(gomega.Equal(http.StatusOK))
+		g.Expect(result.Errors).To(gomega.BeEmpty())
+	})
+
+	t.Run("Invalid Object Metadata", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		client := &automock.MinioClient{}
+		files := []RequestFile{
+			{
+				FieldName: "public",
+				Path:      "./testdata/sample.yaml",
+			},
+		}
+
+		// When
+		httpResp, result := testServeHTTP(g, client, files, "", `[{"pattern": "[a-"}]`)
+
+		// Then
+		g.Expect(httpResp.StatusCode).To(gomega.Equal(http.StatusBadRequest))
+		g.Expect(result.Errors).To(gomega.HaveLen(1))
+	})
+
 	t.Run("No files to upload", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
@@ -140,7 +226,7 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 		var files []RequestFile
 
 		// When
-		httpResp, result := testServeHTTP(g, client, files, "")
+		httpResp, result := testServeHTTP(g, client, files, "", "")
 
 		// Then
 		g.Expect(httpResp.StatusCode).To(gomega.Equal(http.StatusBadRequest))
@@ -154,8 +240,8 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 		client := &automock.MinioClient{}
 
 		testErr1 := errors.New("Test err 1")
-		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), minio.PutObjectOptions{}).Return(int64(1), nil).Once()
-		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), minio.PutObjectOptions{}).Return(int64(1), testErr1).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.yaml")).Return(int64(1), nil).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.txt")).Return(int64(1), testErr1).Once()
 		defer client.AssertExpectations(t)
 
 		files := []RequestFile{
@@ -182,7 +268,7 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 		}
 
 		// When
-		httpResp, result := testServeHTTP(g, client, files, directoryName)
+		httpResp, result := testServeHTTP(g, client, files, directoryName, "")
 
 		// Then
 		g.Expect(httpResp.StatusCode).To(gomega.Equal(http.StatusMultiStatus))
@@ -204,8 +290,8 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 
 		testErr1 := errors.New("Test err 1")
 		testErr2 := errors.New("Test err 2")
-		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), minio.PutObjectOptions{}).Return(int64(1), testErr1).Once()
-		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), minio.PutObjectOptions{}).Return(int64(1), testErr2).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "public", mock.MatchedBy(randomDirFn("sample.yaml")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.yaml")).Return(int64(1), testErr1).Once()
+		client.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "private", mock.MatchedBy(randomDirFn("sample.txt")), mock.MatchedBy(anyReaderFn), mock.MatchedBy(anySizeFn), store.ObjectMetadata{}.Options("sample.txt")).Return(int64(1), testErr2).Once()
 		defer client.AssertExpectations(t)
 
 		files := []RequestFile{
@@ -225,7 +311,7 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 		}
 
 		// When
-		httpResp, result := testServeHTTP(g, client, files, directoryName)
+		httpResp, result := testServeHTTP(g, client, files, directoryName, "")
 
 		// Then
 		g.Expect(httpResp.StatusCode).To(gomega.Equal(http.StatusBadGateway))
@@ -244,7 +330,7 @@ func TestRequestHandler_ServeHTTP(t *testing.T) {
 			Private: "private",
 			Public:  "public",
 		}
-		mux := requesthandler.SetupHandlers(nil, buckets, "https://example.com", 10*time.Second, 5)
+		mux := requesthandler.SetupHandlers(nil, buckets, nil, "https://example.com", 10*time.Second, 5)
 
 		record := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
@@ -282,16 +368,16 @@ type RequestFile struct {
 	FieldName string
 }
 
-func testServeHTTP(g *gomega.GomegaWithT, minioClient uploader.MinioClient, files []RequestFile, directoryName string) (*http.Response, requesthandler.Response) {
+func testServeHTTP(g *gomega.GomegaWithT, minioClient uploader.MinioClient, files []RequestFile, directoryName, metadata string) (*http.Response, requesthandler.Response) {
 	buckets := bucket.SystemBucketNames{
 		Private: "private",
 		Public:  "public",
 	}
 
-	handler := requesthandler.New(minioClient, buckets, "https://example.com", 10*time.Second, 5)
+	handler := requesthandler.New(minioClient, buckets, nil, "https://example.com", 10*time.Second, 5)
 
 	w := httptest.NewRecorder()
-	rq, err := fixRequest(files, directoryName)
+	rq, err := fixRequest(files, directoryName, metadata)
 	g.Expect(err).NotTo(gomega.HaveOccurred())
 
 	handler.ServeHTTP(w, rq)
@@ -311,7 +397,7 @@ func testServeHTTP(g *gomega.GomegaWithT, minioClient uploader.MinioClient, file
 	return resp, result
 }
 
-func fixRequest(files []RequestFile, directoryName string) (*http.Request, error) {
+func fixRequest(files []RequestFile, directoryName, metadata string) (*http.Request, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
@@ -344,6 +430,13 @@ func fixRequest(files []RequestFile, directoryName string) (*http.Request, error
 		}
 	}
 
+	if metadata != "" {
+		err := writer.WriteField("metadata", metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err := writer.Close()
 	if err != nil {
 		return nil, err
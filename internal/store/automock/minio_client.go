@@ -5,6 +5,8 @@ package automock
 import (
 	context "context"
 
+	io "io"
+
 	minio "github.com/minio/minio-go"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -35,6 +37,20 @@ func (_m *MinioClient) BucketExists(bucketName string) (bool, error) {
 	return r0, r1
 }
 
+// CopyObject provides a mock function with given fields: dst, src
+func (_m *MinioClient) CopyObject(dst minio.DestinationInfo, src minio.SourceInfo) error {
+	ret := _m.Called(dst, src)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(minio.DestinationInfo, minio.SourceInfo) error); ok {
+		r0 = rf(dst, src)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FPutObjectWithContext provides a mock function with given fields: ctx, bucketName, objectName, filePath, opts
 func (_m *MinioClient) FPutObjectWithContext(ctx context.Context, bucketName string, objectName string, filePath string, opts minio.PutObjectOptions) (int64, error) {
 	ret := _m.Called(ctx, bucketName, objectName, filePath, opts)
@@ -56,6 +72,48 @@ func (_m *MinioClient) FPutObjectWithContext(ctx context.Context, bucketName str
 	return r0, r1
 }
 
+// GetBucketEncryption provides a mock function with given fields: bucketName
+func (_m *MinioClient) GetBucketEncryption(bucketName string) (string, error) {
+	ret := _m.Called(bucketName)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(bucketName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bucketName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBucketLifecycle provides a mock function with given fields: bucketName
+func (_m *MinioClient) GetBucketLifecycle(bucketName string) (string, error) {
+	ret := _m.Called(bucketName)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(bucketName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bucketName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBucketPolicy provides a mock function with given fields: bucketName
 func (_m *MinioClient) GetBucketPolicy(bucketName string) (string, error) {
 	ret := _m.Called(bucketName)
@@ -107,6 +165,27 @@ func (_m *MinioClient) MakeBucket(bucketName string, location string) error {
 	return r0
 }
 
+// PutObjectWithContext provides a mock function with given fields: ctx, bucketName, objectName, reader, objectSize, opts
+func (_m *MinioClient) PutObjectWithContext(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error) {
+	ret := _m.Called(ctx, bucketName, objectName, reader, objectSize, opts)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader, int64, minio.PutObjectOptions) int64); ok {
+		r0 = rf(ctx, bucketName, objectName, reader, objectSize, opts)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, io.Reader, int64, minio.PutObjectOptions) error); ok {
+		r1 = rf(ctx, bucketName, objectName, reader, objectSize, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveBucket provides a mock function with given fields: bucketName
 func (_m *MinioClient) RemoveBucket(bucketName string) error {
 	ret := _m.Called(bucketName)
@@ -121,6 +200,20 @@ func (_m *MinioClient) RemoveBucket(bucketName string) error {
 	return r0
 }
 
+// RemoveBucketEncryption provides a mock function with given fields: bucketName
+func (_m *MinioClient) RemoveBucketEncryption(bucketName string) error {
+	ret := _m.Called(bucketName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(bucketName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveObjectsWithContext provides a mock function with given fields: ctx, bucketName, objectsCh
 func (_m *MinioClient) RemoveObjectsWithContext(ctx context.Context, bucketName string, objectsCh <-chan string) <-chan minio.RemoveObjectError {
 	ret := _m.Called(ctx, bucketName, objectsCh)
@@ -137,6 +230,34 @@ func (_m *MinioClient) RemoveObjectsWithContext(ctx context.Context, bucketName
 	return r0
 }
 
+// SetBucketEncryption provides a mock function with given fields: bucketName, algorithm
+func (_m *MinioClient) SetBucketEncryption(bucketName string, algorithm string) error {
+	ret := _m.Called(bucketName, algorithm)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(bucketName, algorithm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetBucketLifecycle provides a mock function with given fields: bucketName, lifecycle
+func (_m *MinioClient) SetBucketLifecycle(bucketName string, lifecycle string) error {
+	ret := _m.Called(bucketName, lifecycle)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(bucketName, lifecycle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetBucketPolicy provides a mock function with given fields: bucketName, policy
 func (_m *MinioClient) SetBucketPolicy(bucketName string, policy string) error {
 	ret := _m.Called(bucketName, policy)
@@ -150,3 +271,24 @@ func (_m *MinioClient) SetBucketPolicy(bucketName string, policy string) error {
 
 	return r0
 }
+
+// StatObject provides a mock function with given fields: bucketName, objectName, opts
+func (_m *MinioClient) StatObject(bucketName string, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	ret := _m.Called(bucketName, objectName, opts)
+
+	var r0 minio.ObjectInfo
+	if rf, ok := ret.Get(0).(func(string, string, minio.StatObjectOptions) minio.ObjectInfo); ok {
+		r0 = rf(bucketName, objectName, opts)
+	} else {
+		r0 = ret.Get(0).(minio.ObjectInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, minio.StatObjectOptions) error); ok {
+		r1 = rf(bucketName, objectName, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -5,8 +5,14 @@ package automock
 import (
 	context "context"
 
+	encrypt "github.com/minio/minio-go/pkg/encrypt"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
+	store "github.com/kyma-project/rafter/internal/store"
+
 	v1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 )
 
@@ -36,20 +42,62 @@ func (_m *Store) BucketExists(name string) (bool, error) {
 	return r0, r1
 }
 
-// CompareBucketPolicy provides a mock function with given fields: name, expected
-func (_m *Store) CompareBucketPolicy(name string, expected v1beta1.BucketPolicy) (bool, error) {
-	ret := _m.Called(name, expected)
+// CompareBucketEncryption provides a mock function with given fields: name, encryption
+func (_m *Store) CompareBucketEncryption(name string, encryption *v1beta1.BucketEncryption) (bool, error) {
+	ret := _m.Called(name, encryption)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, *v1beta1.BucketEncryption) bool); ok {
+		r0 = rf(name, encryption)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *v1beta1.BucketEncryption) error); ok {
+		r1 = rf(name, encryption)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompareBucketLifecycle provides a mock function with given fields: name, rules
+func (_m *Store) CompareBucketLifecycle(name string, rules []v1beta1.BucketLifecycleRule) (bool, error) {
+	ret := _m.Called(name, rules)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, v1beta1.BucketPolicy) bool); ok {
-		r0 = rf(name, expected)
+	if rf, ok := ret.Get(0).(func(string, []v1beta1.BucketLifecycleRule) bool); ok {
+		r0 = rf(name, rules)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, v1beta1.BucketPolicy) error); ok {
-		r1 = rf(name, expected)
+	if rf, ok := ret.Get(1).(func(string, []v1beta1.BucketLifecycleRule) error); ok {
+		r1 = rf(name, rules)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompareBucketPolicy provides a mock function with given fields: name, expected, accessPolicy
+func (_m *Store) CompareBucketPolicy(name string, expected v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) (bool, error) {
+	ret := _m.Called(name, expected, accessPolicy)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, v1beta1.BucketPolicy, *v1beta1.BucketAccessPolicy) bool); ok {
+		r0 = rf(name, expected, accessPolicy)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, v1beta1.BucketPolicy, *v1beta1.BucketAccessPolicy) error); ok {
+		r1 = rf(name, expected, accessPolicy)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,6 +126,34 @@ func (_m *Store) ContainsAllObjects(ctx context.Context, bucketName string, asse
 	return r0, r1
 }
 
+// CopyObject provides a mock function with given fields: ctx, bucketName, assetName, sourceFileName, fileName, metadata
+func (_m *Store) CopyObject(ctx context.Context, bucketName string, assetName string, sourceFileName string, fileName string, metadata store.ObjectMetadata) error {
+	ret := _m.Called(ctx, bucketName, assetName, sourceFileName, fileName, metadata)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, store.ObjectMetadata) error); ok {
+		r0 = rf(ctx, bucketName, assetName, sourceFileName, fileName, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CopyObjects provides a mock function with given fields: ctx, bucketName, sourcePrefix, prefix, sse
+func (_m *Store) CopyObjects(ctx context.Context, bucketName string, sourcePrefix string, prefix string, sse encrypt.ServerSide) error {
+	ret := _m.Called(ctx, bucketName, sourcePrefix, prefix, sse)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, encrypt.ServerSide) error); ok {
+		r0 = rf(ctx, bucketName, sourcePrefix, prefix, sse)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateBucket provides a mock function with given fields: namespace, crName, region
 func (_m *Store) CreateBucket(namespace string, crName string, region string) (string, error) {
 	ret := _m.Called(namespace, crName, region)
@@ -99,6 +175,20 @@ func (_m *Store) CreateBucket(namespace string, crName string, region string) (s
 	return r0, r1
 }
 
+// CreateNamedBucket provides a mock function with given fields: name, region
+func (_m *Store) CreateNamedBucket(name string, region string) error {
+	ret := _m.Called(name, region)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, region)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteBucket provides a mock function with given fields: ctx, name
 func (_m *Store) DeleteBucket(ctx context.Context, name string) error {
 	ret := _m.Called(ctx, name)
@@ -127,6 +217,29 @@ func (_m *Store) DeleteObjects(ctx context.Context, bucketName string, prefix st
 	return r0
 }
 
+// DeleteStaleObjects provides a mock function with given fields: ctx, bucketName, assetName, files
+func (_m *Store) DeleteStaleObjects(ctx context.Context, bucketName string, assetName string, files []string) ([]string, error) {
+	ret := _m.Called(ctx, bucketName, assetName, files)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) []string); ok {
+		r0 = rf(ctx, bucketName, assetName, files)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
+		r1 = rf(ctx, bucketName, assetName, files)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListObjects provides a mock function with given fields: ctx, bucketName, prefix
 func (_m *Store) ListObjects(ctx context.Context, bucketName string, prefix string) ([]string, error) {
 	ret := _m.Called(ctx, bucketName, prefix)
@@ -150,13 +263,41 @@ func (_m *Store) ListObjects(ctx context.Context, bucketName string, prefix stri
 	return r0, r1
 }
 
-// PutObjects provides a mock function with given fields: ctx, bucketName, assetName, sourceBasePath, files
-func (_m *Store) PutObjects(ctx context.Context, bucketName string, assetName string, sourceBasePath string, files []string) error {
-	ret := _m.Called(ctx, bucketName, assetName, sourceBasePath, files)
+// PutObject provides a mock function with given fields: ctx, bucketName, assetName, fileName, reader, size, metadata
+func (_m *Store) PutObject(ctx context.Context, bucketName string, assetName string, fileName string, reader io.Reader, size int64, metadata store.ObjectMetadata) error {
+	ret := _m.Called(ctx, bucketName, assetName, fileName, reader, size, metadata)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, io.Reader, int64, store.ObjectMetadata) error); ok {
+		r0 = rf(ctx, bucketName, assetName, fileName, reader, size, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutObjects provides a mock function with given fields: ctx, bucketName, assetName, sourceBasePath, files, metadata
+func (_m *Store) PutObjects(ctx context.Context, bucketName string, assetName string, sourceBasePath string, files []string, metadata store.ObjectMetadata) error {
+	ret := _m.Called(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, store.ObjectMetadata) error); ok {
+		r0 = rf(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetBucketEncryption provides a mock function with given fields: name, encryption
+func (_m *Store) SetBucketEncryption(name string, encryption *v1beta1.BucketEncryption) error {
+	ret := _m.Called(name, encryption)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) error); ok {
-		r0 = rf(ctx, bucketName, assetName, sourceBasePath, files)
+	if rf, ok := ret.Get(0).(func(string, *v1beta1.BucketEncryption) error); ok {
+		r0 = rf(name, encryption)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -164,16 +305,51 @@ func (_m *Store) PutObjects(ctx context.Context, bucketName string, assetName st
 	return r0
 }
 
-// SetBucketPolicy provides a mock function with given fields: name, policy
-func (_m *Store) SetBucketPolicy(name string, policy v1beta1.BucketPolicy) error {
-	ret := _m.Called(name, policy)
+// SetBucketLifecycle provides a mock function with given fields: name, rules
+func (_m *Store) SetBucketLifecycle(name string, rules []v1beta1.BucketLifecycleRule) error {
+	ret := _m.Called(name, rules)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, v1beta1.BucketPolicy) error); ok {
-		r0 = rf(name, policy)
+	if rf, ok := ret.Get(0).(func(string, []v1beta1.BucketLifecycleRule) error); ok {
+		r0 = rf(name, rules)
 	} else {
 		r0 = ret.Error(0)
 	}
 
 	return r0
 }
+
+// SetBucketPolicy provides a mock function with given fields: name, policy, accessPolicy
+func (_m *Store) SetBucketPolicy(name string, policy v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) error {
+	ret := _m.Called(name, policy, accessPolicy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, v1beta1.BucketPolicy, *v1beta1.BucketAccessPolicy) error); ok {
+		r0 = rf(name, policy, accessPolicy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncObjects provides a mock function with given fields: ctx, bucketName, assetName, sourceBasePath, files, metadata
+func (_m *Store) SyncObjects(ctx context.Context, bucketName string, assetName string, sourceBasePath string, files []string, metadata store.ObjectMetadata) (store.SyncResult, error) {
+	ret := _m.Called(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+
+	var r0 store.SyncResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, store.ObjectMetadata) store.SyncResult); ok {
+		r0 = rf(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+	} else {
+		r0 = ret.Get(0).(store.SyncResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string, store.ObjectMetadata) error); ok {
+		r1 = rf(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/encrypt"
+	miniov7 "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/sse"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sseAlgorithm is the only algorithm of the objects encrypted with the SSE-S3 and SSE-C keys
+	sseAlgorithm = "AES256"
+)
+
+// Client is the MinIO client, which sets the default encryption of buckets as well. The client doesn't provide
+// the bucket encryption API, so the encryption requests are sent with the next version of the client, which uses
+// the same endpoint, credentials and transport.
+type Client struct {
+	*minio.Client
+	encryptionClient *miniov7.Client
+	transport        *clientTransport
+}
+
+func NewClient(endpoint, accessKey, secretKey string, secure bool) (*Client, error) {
+	client, err := minio.New(endpoint, accessKey, secretKey, secure)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &clientTransport{RoundTripper: minio.DefaultTransport}
+	encryptionClient, err := miniov7.New(endpoint, &miniov7.Options{
+		Creds:     credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:    secure,
+		Transport: transport,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Client:           client,
+		encryptionClient: encryptionClient,
+		transport:        transport,
+	}, nil
+}
+
+// SetCustomTransport sets the transport of the MinIO client and the encryption requests
+func (c *Client) SetCustomTransport(transport http.RoundTripper) {
+	c.Client.SetCustomTransport(transport)
+	c.transport.RoundTripper = transport
+}
+
+// SetBucketEncryption sets the algorithm encrypting the objects uploaded to the bucket without encryption headers
+func (c *Client) SetBucketEncryption(bucketName, algorithm string) error {
+	config := &sse.Configuration{Rules: []sse.Rule{{Apply: sse.ApplySSEByDefault{SSEAlgorithm: algorithm}}}}
+	return c.encryptionClient.SetBucketEncryption(context.Background(), bucketName, config)
+}
+
+// GetBucketEncryption returns the algorithm encrypting the objects uploaded to the bucket without encryption headers,
+// or an empty string if the bucket has no default encryption
+func (c *Client) GetBucketEncryption(bucketName string) (string, error) {
+	config, err := c.encryptionClient.GetBucketEncryption(context.Background(), bucketName)
+	if err != nil {
+		if miniov7.ToErrorResponse(err).Code == "ServerSideEncryptionConfigurationNotFoundError" {
+			return "", nil
+		}
+		return "", err
+	}
+	if len(config.Rules) == 0 {
+		return "", nil
+	}
+
+	return config.Rules[0].Apply.SSEAlgorithm, nil
+}
+
+// RemoveBucketEncryption removes the default encryption of the bucket
+func (c *Client) RemoveBucketEncryption(bucketName string) error {
+	return c.encryptionClient.RemoveBucketEncryption(context.Background(), bucketName)
+}
+
+// clientTransport lets the transport of the encryption client be replaced after the client is created
+type clientTransport struct {
+	http.RoundTripper
+}
+
+// NewServerSideEncryption returns the encryption of the type, which is nil for the empty type. The SSE-C key
+// has to be 32 bytes long.
+func NewServerSideEncryption(encryptionType v1beta1.BucketEncryptionType, key []byte) (encrypt.ServerSide, error) {
+	switch encryptionType {
+	case "":
+		return nil, nil
+	case v1beta1.BucketEncryptionSSES3:
+		return encrypt.NewSSE(), nil
+	case v1beta1.BucketEncryptionSSEC:
+		sse, err := encrypt.NewSSEC(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "while reading SSE-C key")
+		}
+		return sse, nil
+	default:
+		return nil, fmt.Errorf("not supported encryption type %s", encryptionType)
+	}
+}
+
+// defaultEncryptionAlgorithm returns the algorithm of the default encryption of the bucket with the encryption
+func defaultEncryptionAlgorithm(encryption *v1beta1.BucketEncryption) string {
+	if encryption == nil || encryption.Type != v1beta1.BucketEncryptionSSES3 {
+		return ""
+	}
+
+	return sseAlgorithm
+}
+
+// decryption returns the encryption which has to be sent to read the object, as only SSE-C keys are not known
+// to the server
+func decryption(sse encrypt.ServerSide) encrypt.ServerSide {
+	if sse == nil || sse.Type() != encrypt.SSEC {
+		return nil
+	}
+
+	return sse
+}
+
+// encryptionMatches returns true if the object is encrypted with the encryption. Objects encrypted with another
+// SSE-C key can't be read at all.
+func encryptionMatches(object minio.ObjectInfo, sse encrypt.ServerSide) bool {
+	if sse == nil {
+		return true
+	}
+
+	switch sse.Type() {
+	case encrypt.S3:
+		return object.Metadata.Get("X-Amz-Server-Side-Encryption") == sseAlgorithm
+	case encrypt.SSEC:
+		return object.Metadata.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm") == sseAlgorithm
+	default:
+		return false
+	}
+}
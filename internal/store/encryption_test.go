@@ -0,0 +1,268 @@
+package store_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go/pkg/encrypt"
+	"github.com/onsi/gomega"
+)
+
+func TestClient_SetBucketEncryption(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		var request *http.Request
+		var body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if writeLocation(w, r, "asia") {
+				return
+			}
+			content, _ := ioutil.ReadAll(r.Body)
+			request, body = r, string(content)
+		}))
+		defer server.Close()
+		client := fixClient(t, server.URL)
+
+		// When
+		err := client.SetBucketEncryption("test-bucket", "AES256")
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(request.Method).To(gomega.Equal(http.MethodPut))
+		g.Expect(request.URL.Path).To(gomega.Equal("/test-bucket/"))
+		g.Expect(request.URL.Query()).To(gomega.HaveKey("encryption"))
+		g.Expect(request.Header.Get("Authorization")).To(gomega.ContainSubstring("/asia/s3/aws4_request"))
+		g.Expect(request.Header.Get("Content-Md5")).NotTo(gomega.BeEmpty())
+		g.Expect(body).To(gomega.ContainSubstring("<SSEAlgorithm>AES256</SSEAlgorithm>"))
+	})
+
+	t.Run("CustomTransport", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeLocation(w, r, "")
+		}))
+		defer server.Close()
+		client := fixClient(t, server.URL)
+		transport := &countingTransport{}
+		client.SetCustomTransport(transport)
+
+		// When
+		err := client.SetBucketEncryption("test-bucket", "AES256")
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(transport.count).To(gomega.Equal(2))
+	})
+
+	t.Run("LocationError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		var encryptionRequested bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.URL.Query()["encryption"]; ok {
+				encryptionRequested = true
+			}
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchBucket</Code><Message>Not found</Message></Error>"))
+		}))
+		defer server.Close()
+		client := fixClient(t, server.URL)
+
+		// When
+		err := client.SetBucketEncryption("test-bucket", "AES256")
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(encryptionRequested).To(gomega.BeFalse())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if writeLocation(w, r, "") {
+				return
+			}
+			w.WriteHeader(http.StatusNotImplemented)
+			w.Write([]byte("<Error><Code>NotImplemented</Code><Message>Not implemented</Message></Error>"))
+		}))
+		defer server.Close()
+		client := fixClient(t, server.URL)
+
+		// When
+		err := client.SetBucketEncryption("test-bucket", "AES256")
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(err.Error()).To(gomega.Equal("Not implemented"))
+	})
+}
+
+func TestClient_GetBucketEncryption(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		status    int
+		body      string
+		algorithm string
+		failed    bool
+	}{
+		"Configured": {
+			status:    http.StatusOK,
+			body:      `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			algorithm: "AES256",
+		},
+		"NotConfigured": {
+			status: http.StatusNotFound,
+			body:   "<Error><Code>ServerSideEncryptionConfigurationNotFoundError</Code><Message>Not found</Message></Error>",
+		},
+		"Error": {
+			status: http.StatusNotImplemented,
+			body:   "<Error><Code>NotImplemented</Code><Message>Not implemented</Message></Error>",
+			failed: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			var request *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if writeLocation(w, r, "") {
+					return
+				}
+				request = r
+				w.WriteHeader(testCase.status)
+				w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+			client := fixClient(t, server.URL)
+
+			// When
+			algorithm, err := client.GetBucketEncryption("test-bucket")
+
+			// Then
+			g.Expect(request.Method).To(gomega.Equal(http.MethodGet))
+			g.Expect(request.URL.Query()).To(gomega.HaveKey("encryption"))
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(algorithm).To(gomega.Equal(testCase.algorithm))
+		})
+	}
+}
+
+func TestClient_RemoveBucketEncryption(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	var request *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if writeLocation(w, r, "") {
+			return
+		}
+		request = r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := fixClient(t, server.URL)
+
+	// When
+	err := client.RemoveBucketEncryption("test-bucket")
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(request.Method).To(gomega.Equal(http.MethodDelete))
+	g.Expect(request.URL.Query()).To(gomega.HaveKey("encryption"))
+}
+
+func TestNewServerSideEncryption(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		encryptionType v1beta1.BucketEncryptionType
+		key            []byte
+		sseType        encrypt.Type
+		empty          bool
+		failed         bool
+	}{
+		"None": {
+			empty: true,
+		},
+		"SSE-S3": {
+			encryptionType: v1beta1.BucketEncryptionSSES3,
+			sseType:        encrypt.S3,
+		},
+		"SSE-C": {
+			encryptionType: v1beta1.BucketEncryptionSSEC,
+			key:            []byte(strings.Repeat("k", 32)),
+			sseType:        encrypt.SSEC,
+		},
+		"InvalidKey": {
+			encryptionType: v1beta1.BucketEncryptionSSEC,
+			key:            []byte("short"),
+			failed:         true,
+		},
+		"UnknownType": {
+			encryptionType: "SSE-KMS",
+			failed:         true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			sse, err := store.NewServerSideEncryption(testCase.encryptionType, testCase.key)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			if testCase.empty {
+				g.Expect(sse).To(gomega.BeNil())
+				return
+			}
+			g.Expect(sse.Type()).To(gomega.Equal(testCase.sseType))
+		})
+	}
+}
+
+func fixClient(t *testing.T, serverURL string) *store.Client {
+	endpoint, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := store.NewClient(endpoint.Host, "access", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+// writeLocation answers the bucket location requests with the location
+func writeLocation(w http.ResponseWriter, r *http.Request, location string) bool {
+	if _, ok := r.URL.Query()["location"]; !ok {
+		return false
+	}
+
+	w.Write([]byte(fmt.Sprintf(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">%s</LocationConstraint>`, location)))
+	return true
+}
+
+type countingTransport struct {
+	count int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count++
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,522 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go/pkg/encrypt"
+	"github.com/pkg/errors"
+)
+
+const filesystemTemporaryDir = ".tmp"
+
+// filesystemBucketModes maps bucket policies to the permissions of bucket directories,
+// which apply to a web server serving the files as another user
+var filesystemBucketModes = map[v1beta1.BucketPolicy]os.FileMode{
+	v1beta1.BucketPolicyNone:      0700,
+	v1beta1.BucketPolicyReadOnly:  0755,
+	v1beta1.BucketPolicyReadWrite: 0777,
+}
+
+// filesystemStore keeps every bucket in a directory, and every object in a file under its key. Files don't keep
+// the object metadata, so the headers are set by the web server serving them. Encryption at rest is left
+// to the volume.
+type filesystemStore struct {
+	root string
+}
+
+func NewFilesystem(root string) (Store, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, errors.Wrapf(err, "while creating directory %s", root)
+	}
+	if err := os.MkdirAll(filepath.Join(root, filesystemTemporaryDir), 0700); err != nil {
+		return nil, errors.Wrapf(err, "while creating temporary directory in %s", root)
+	}
+
+	return &filesystemStore{root: root}, nil
+}
+
+// Bucket
+
+func (s *filesystemStore) CreateBucket(namespace, crName, region string) (string, error) {
+	bucketName, err := findBucketName(crName, s.BucketExists)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.CreateNamedBucket(bucketName, region); err != nil {
+		return "", err
+	}
+
+	return bucketName, nil
+}
+
+func (s *filesystemStore) CreateNamedBucket(name, region string) error {
+	bucketPath, err := s.bucketPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Mkdir(bucketPath, 0700); err != nil {
+		return errors.Wrapf(err, "while creating bucket %s", name)
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) BucketExists(name string) (bool, error) {
+	bucketPath, err := s.bucketPath(name)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(bucketPath)
+	switch {
+	case os.IsNotExist(err):
+		return false, nil
+	case err != nil:
+		return false, errors.Wrapf(err, "while checking if bucket %s exists", name)
+	default:
+		return info.IsDir(), nil
+	}
+}
+
+func (s *filesystemStore) DeleteBucket(ctx context.Context, name string) error {
+	bucketPath, err := s.bucketPath(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(bucketPath); err != nil {
+		return errors.Wrapf(err, "while deleting bucket %s", name)
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) SetBucketPolicy(name string, policy v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) error {
+	mode, err := s.bucketMode(policy, accessPolicy)
+	if err != nil {
+		return err
+	}
+	bucketPath, err := s.existingBucketPath(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chmod(bucketPath, mode); err != nil {
+		return errors.Wrapf(err, "while setting policy `%s` for bucket %s", policy, name)
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) CompareBucketPolicy(name string, expected v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) (bool, error) {
+	mode, err := s.bucketMode(expected, accessPolicy)
+	if err != nil {
+		return false, err
+	}
+	bucketPath, err := s.existingBucketPath(name)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(bucketPath)
+	if err != nil {
+		return false, errors.Wrapf(err, "while getting policy for bucket %s", name)
+	}
+
+	return info.Mode().Perm() == mode, nil
+}
+
+func (s *filesystemStore) SetBucketLifecycle(name string, rules []v1beta1.BucketLifecycleRule) error {
+	if len(rules) > 0 {
+		return s.lifecycleNotSupported()
+	}
+	_, err := s.existingBucketPath(name)
+
+	return err
+}
+
+func (s *filesystemStore) CompareBucketLifecycle(name string, rules []v1beta1.BucketLifecycleRule) (bool, error) {
+	if len(rules) > 0 {
+		return false, s.lifecycleNotSupported()
+	}
+	if _, err := s.existingBucketPath(name); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *filesystemStore) SetBucketEncryption(name string, encryption *v1beta1.BucketEncryption) error {
+	if encryption != nil {
+		return s.encryptionNotSupported()
+	}
+	_, err := s.existingBucketPath(name)
+
+	return err
+}
+
+func (s *filesystemStore) CompareBucketEncryption(name string, encryption *v1beta1.BucketEncryption) (bool, error) {
+	if encryption != nil {
+		return false, s.encryptionNotSupported()
+	}
+	if _, err := s.existingBucketPath(name); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Object
+
+func (s *filesystemStore) ContainsAllObjects(ctx context.Context, bucketName, assetName string, files []string) (bool, error) {
+	for _, f := range files {
+		objectPath, err := s.objectPath(bucketName, fmt.Sprintf("%s/%s", assetName, f))
+		if err != nil {
+			return false, err
+		}
+
+		info, err := os.Stat(objectPath)
+		switch {
+		case os.IsNotExist(err):
+			return false, nil
+		case err != nil:
+			return false, errors.Wrapf(err, "while checking object %s", f)
+		case !info.Mode().IsRegular():
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (s *filesystemStore) PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string, metadata ObjectMetadata) error {
+	if metadata.Encryption() != nil {
+		return s.encryptionNotSupported()
+	}
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.putFile(ctx, bucketName, assetName, file, filepath.Join(sourceBasePath, file)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) putFile(ctx context.Context, bucketName, assetName, fileName, sourcePath string) error {
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return errors.Wrapf(err, "while opening file %s", sourcePath)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "while reading file %s", sourcePath)
+	}
+
+	return s.PutObject(ctx, bucketName, assetName, fileName, file, info.Size(), ObjectMetadata{})
+}
+
+// PutObject writes the object to a temporary file first, so it is replaced at once
+func (s *filesystemStore) PutObject(ctx context.Context, bucketName, assetName, fileName string, reader io.Reader, size int64, metadata ObjectMetadata) error {
+	if metadata.Encryption() != nil {
+		return s.encryptionNotSupported()
+	}
+	objectName := fmt.Sprintf("%s/%s", assetName, fileName)
+	if _, err := s.existingBucketPath(bucketName); err != nil {
+		return err
+	}
+	objectPath, err := s.objectPath(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Join(s.root, filesystemTemporaryDir), "object")
+	if err != nil {
+		return errors.Wrapf(err, "while uploading object %s", objectName)
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "while uploading object %s", objectName)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("while uploading object %s: read %d bytes instead of %d", objectName, written, size)
+	}
+
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return errors.Wrapf(err, "while uploading object %s", objectName)
+	}
+	if err := os.MkdirAll(filepath.Dir(objectPath), 0755); err != nil {
+		return errors.Wrapf(err, "while uploading object %s", objectName)
+	}
+	if err := os.Rename(file.Name(), objectPath); err != nil {
+		return errors.Wrapf(err, "while uploading object %s", objectName)
+	}
+
+	return nil
+}
+
+// SyncObjects copies only the files which are missing or differ from the objects, and deletes the objects of the files
+// which are gone afterwards
+func (s *filesystemStore) SyncObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string, metadata ObjectMetadata) (SyncResult, error) {
+	if metadata.Encryption() != nil {
+		return SyncResult{}, s.encryptionNotSupported()
+	}
+	var result SyncResult
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		changed, err := s.syncObject(ctx, bucketName, assetName, file, filepath.Join(sourceBasePath, file))
+		if err != nil {
+			return result, err
+		}
+		if changed {
+			result.Uploaded = append(result.Uploaded, file)
+		}
+	}
+
+	deleted, err := s.DeleteStaleObjects(ctx, bucketName, assetName, files)
+	result.Deleted = deleted
+
+	return result, err
+}
+
+func (s *filesystemStore) syncObject(ctx context.Context, bucketName, assetName, fileName, sourcePath string) (bool, error) {
+	objectPath, err := s.objectPath(bucketName, fmt.Sprintf("%s/%s", assetName, fileName))
+	if err != nil {
+		return false, err
+	}
+
+	sourceSum, _, err := hashFile(sourcePath)
+	if err != nil {
+		return false, errors.Wrapf(err, "while computing digest of file %s", sourcePath)
+	}
+	if objectSum, _, err := hashFile(objectPath); err == nil && objectSum == sourceSum {
+		return false, nil
+	}
+
+	if err := s.putFile(ctx, bucketName, assetName, fileName, sourcePath); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *filesystemStore) CopyObject(ctx context.Context, bucketName, assetName, sourceFileName, fileName string, metadata ObjectMetadata) error {
+	if metadata.Encryption() != nil {
+		return s.encryptionNotSupported()
+	}
+	sourcePath, err := s.objectPath(bucketName, fmt.Sprintf("%s/%s", assetName, sourceFileName))
+	if err != nil {
+		return err
+	}
+
+	if err := s.putFile(ctx, bucketName, assetName, fileName, sourcePath); err != nil {
+		return errors.Wrapf(err, "while copying object %s", fmt.Sprintf("%s/%s", assetName, fileName))
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) CopyObjects(ctx context.Context, bucketName, sourcePrefix, prefix string, sse encrypt.ServerSide) error {
+	if sse != nil {
+		return s.encryptionNotSupported()
+	}
+	objects, err := s.ListObjects(ctx, bucketName, sourcePrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		sourcePath, err := s.objectPath(bucketName, object)
+		if err != nil {
+			return err
+		}
+
+		objectName := prefix + strings.TrimPrefix(object, sourcePrefix)
+		if err := s.putFile(ctx, bucketName, path.Dir(objectName), path.Base(objectName), sourcePath); err != nil {
+			return errors.Wrapf(err, "while copying object %s", objectName)
+		}
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error) {
+	bucketPath, err := s.existingBucketPath(bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the directory of the prefix is walked, as the keys of the objects in other directories can't start with it
+	walkPath := bucketPath
+	if slash := strings.LastIndex(prefix, "/"); slash >= 0 {
+		dir := strings.TrimPrefix(path.Clean("/"+prefix[:slash]), "/")
+		walkPath = filepath.Join(bucketPath, filepath.FromSlash(dir))
+	}
+
+	var result []string
+	err = filepath.Walk(walkPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if filePath == walkPath && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(bucketPath, filePath)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(relative); strings.HasPrefix(key, prefix) {
+			result = append(result, key)
+		}
+
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list objects in bucket %s", bucketName)
+	}
+
+	return result, nil
+}
+
+func (s *filesystemStore) DeleteObjects(ctx context.Context, bucketName, prefix string) error {
+	if err := checkDeletePrefix(prefix); err != nil {
+		return err
+	}
+
+	objects, err := s.ListObjects(ctx, bucketName, prefix)
+	if err != nil {
+		return err
+	}
+
+	return s.removeObjects(bucketName, objects)
+}
+
+// DeleteStaleObjects deletes the objects of the asset which do not belong to any of the files, and returns their names
+func (s *filesystemStore) DeleteStaleObjects(ctx context.Context, bucketName, assetName string, files []string) ([]string, error) {
+	objects, err := s.ListObjects(ctx, bucketName, AssetPrefix(assetName))
+	if err != nil {
+		return nil, err
+	}
+
+	stale := staleObjects(assetName, objects, files)
+	if err := s.removeObjects(bucketName, stale); err != nil {
+		return nil, err
+	}
+
+	return stale, nil
+}
+
+// Helpers
+
+// bucketPath returns the directory of the bucket. Names starting with a dot are reserved for the store.
+func (s *filesystemStore) bucketPath(name string) (string, error) {
+	if len(name) == 0 || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("%s: invalid bucket name", name)
+	}
+
+	return filepath.Join(s.root, name), nil
+}
+
+func (s *filesystemStore) existingBucketPath(name string) (string, error) {
+	exists, err := s.BucketExists(name)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("bucket %s does not exist", name)
+	}
+
+	return filepath.Join(s.root, name), nil
+}
+
+// objectPath returns the file of the object. The name is cleaned like a rooted path, so it can't point outside of the bucket.
+func (s *filesystemStore) objectPath(bucketName, objectName string) (string, error) {
+	bucketPath, err := s.bucketPath(bucketName)
+	if err != nil {
+		return "", err
+	}
+
+	key := strings.TrimPrefix(path.Clean("/"+objectName), "/")
+	if len(key) == 0 {
+		return "", fmt.Errorf("%s: invalid object name", objectName)
+	}
+
+	return filepath.Join(bucketPath, filepath.FromSlash(key)), nil
+}
+
+func (s *filesystemStore) bucketMode(policy v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) (os.FileMode, error) {
+	if accessPolicy != nil {
+		return 0, &NotSupportedError{message: fmt.Sprintf("access policy is not supported by %s backend", BackendFilesystem)}
+	}
+	if len(policy) == 0 {
+		policy = v1beta1.BucketPolicyNone
+	}
+
+	mode, ok := filesystemBucketModes[policy]
+	if !ok {
+		return 0, &NotSupportedError{message: fmt.Sprintf("bucket policy `%s` is not supported by %s backend", policy, BackendFilesystem)}
+	}
+
+	return mode, nil
+}
+
+func (s *filesystemStore) lifecycleNotSupported() error {
+	return &NotSupportedError{message: fmt.Sprintf("lifecycle rules are not supported by %s backend", BackendFilesystem)}
+}
+
+func (s *filesystemStore) encryptionNotSupported() error {
+	return &NotSupportedError{message: fmt.Sprintf("encryption is not supported by %s backend", BackendFilesystem)}
+}
+
+func (s *filesystemStore) removeObjects(bucketName string, objects []string) error {
+	bucketPath := filepath.Join(s.root, bucketName)
+	var messages []string
+	for _, object := range objects {
+		objectPath := filepath.Join(bucketPath, filepath.FromSlash(object))
+		if err := os.Remove(objectPath); err != nil && !os.IsNotExist(err) {
+			messages = append(messages, err.Error())
+			continue
+		}
+		s.removeEmptyDirs(bucketPath, filepath.Dir(objectPath))
+	}
+
+	if len(messages) > 0 {
+		return fmt.Errorf("cannot delete objects from bucket: %+v", messages)
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) removeEmptyDirs(bucketPath, dir string) {
+	for dir != bucketPath && strings.HasPrefix(dir, bucketPath) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,459 @@
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestFilesystemStore_Bucket(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	root := fixRoot(t)
+	defer os.RemoveAll(root)
+
+	fsStore, err := store.NewFilesystem(root)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// When
+	name, err := fsStore.CreateBucket("default", "test-bucket", "")
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(name).To(gomega.HavePrefix("test-bucket-"))
+
+	exists, err := fsStore.BucketExists(name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(exists).To(gomega.BeTrue())
+
+	equal, err := fsStore.CompareBucketPolicy(name, v1beta1.BucketPolicyNone, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(equal).To(gomega.BeTrue())
+
+	err = fsStore.DeleteBucket(context.TODO(), name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	exists, err = fsStore.BucketExists(name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(exists).To(gomega.BeFalse())
+}
+
+func TestFilesystemStore_NamedBucket(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	root := fixRoot(t)
+	defer os.RemoveAll(root)
+
+	fsStore, err := store.NewFilesystem(root)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// When
+	err = fsStore.CreateNamedBucket("restored-bucket", "")
+	duplicateErr := fsStore.CreateNamedBucket("restored-bucket", "")
+	invalidErr := fsStore.CreateNamedBucket("../restored-bucket", "")
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(duplicateErr).To(gomega.HaveOccurred())
+	g.Expect(invalidErr).To(gomega.HaveOccurred())
+
+	exists, err := fsStore.BucketExists("restored-bucket")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(exists).To(gomega.BeTrue())
+}
+
+func TestFilesystemStore_BucketPolicy(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		policy       v1beta1.BucketPolicy
+		accessPolicy *v1beta1.BucketAccessPolicy
+		mode         os.FileMode
+		notSupported bool
+	}{
+		"None": {
+			policy: v1beta1.BucketPolicyNone,
+			mode:   0700,
+		},
+		"ReadOnly": {
+			policy: v1beta1.BucketPolicyReadOnly,
+			mode:   0755,
+		},
+		"ReadWrite": {
+			policy: v1beta1.BucketPolicyReadWrite,
+			mode:   0777,
+		},
+		"WriteOnly": {
+			policy:       v1beta1.BucketPolicyWriteOnly,
+			notSupported: true,
+		},
+		"AccessPolicy": {
+			policy:       v1beta1.BucketPolicyReadOnly,
+			accessPolicy: &v1beta1.BucketAccessPolicy{Rules: []v1beta1.BucketPolicyRule{{Prefix: "assets/", Policy: v1beta1.BucketPolicyReadOnly}}},
+			notSupported: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			root := fixRoot(t)
+			defer os.RemoveAll(root)
+
+			fsStore, err := store.NewFilesystem(root)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			name, err := fsStore.CreateBucket("default", "test-bucket", "")
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			// When
+			err = fsStore.SetBucketPolicy(name, testCase.policy, testCase.accessPolicy)
+
+			// Then
+			if testCase.notSupported {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(store.IsNotSupportedError(err)).To(gomega.BeTrue())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			info, err := os.Stat(filepath.Join(root, name))
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(info.Mode().Perm()).To(gomega.Equal(testCase.mode))
+
+			equal, err := fsStore.CompareBucketPolicy(name, testCase.policy, nil)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(equal).To(gomega.BeTrue())
+
+			equal, err = fsStore.CompareBucketPolicy(name, v1beta1.BucketPolicyWriteOnly, nil)
+			g.Expect(err).To(gomega.HaveOccurred())
+			g.Expect(equal).To(gomega.BeFalse())
+		})
+	}
+}
+
+func TestFilesystemStore_BucketLifecycle(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	root := fixRoot(t)
+	defer os.RemoveAll(root)
+	expirationDays := int64(7)
+	rules := []v1beta1.BucketLifecycleRule{{ExpirationDays: &expirationDays}}
+
+	fsStore, err := store.NewFilesystem(root)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	name, err := fsStore.CreateBucket("default", "test-bucket", "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// When
+	setErr := fsStore.SetBucketLifecycle(name, nil)
+	equal, compareErr := fsStore.CompareBucketLifecycle(name, nil)
+	notSupportedErr := fsStore.SetBucketLifecycle(name, rules)
+	_, notSupportedCompareErr := fsStore.CompareBucketLifecycle(name, rules)
+
+	// Then
+	g.Expect(setErr).NotTo(gomega.HaveOccurred())
+	g.Expect(compareErr).NotTo(gomega.HaveOccurred())
+	g.Expect(equal).To(gomega.BeTrue())
+	g.Expect(store.IsNotSupportedError(notSupportedErr)).To(gomega.BeTrue())
+	g.Expect(store.IsNotSupportedError(notSupportedCompareErr)).To(gomega.BeTrue())
+}
+
+func TestFilesystemStore_Objects(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	root := fixRoot(t)
+	defer os.RemoveAll(root)
+	source := fixRoot(t)
+	defer os.RemoveAll(source)
+
+	g.Expect(os.MkdirAll(filepath.Join(source, "docs"), 0755)).To(gomega.Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(source, "README.md"), []byte("# Test"), 0644)).To(gomega.Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(source, "docs", "index.md"), []byte("# Index"), 0644)).To(gomega.Succeed())
+
+	fsStore, err := store.NewFilesystem(root)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	bucket, err := fsStore.CreateBucket("default", "test-bucket", "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	ctx := context.TODO()
+
+	// When
+	err = fsStore.PutObjects(ctx, bucket, "asset", source, []string{"README.md", "docs/index.md"}, store.ObjectMetadata{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	err = fsStore.PutObject(ctx, bucket, "asset-v2", "spec.json", strings.NewReader("{}"), 2, store.ObjectMetadata{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	err = fsStore.CopyObject(ctx, bucket, "asset", "README.md", "docs/README.md", store.ObjectMetadata{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// Then
+	objects, err := fsStore.ListObjects(ctx, bucket, "asset/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(objects).To(gomega.ConsistOf("asset/README.md", "asset/docs/index.md", "asset/docs/README.md"))
+
+	contains, err := fsStore.ContainsAllObjects(ctx, bucket, "asset", []string{"README.md", "docs/README.md"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(contains).To(gomega.BeTrue())
+
+	contains, err = fsStore.ContainsAllObjects(ctx, bucket, "asset", []string{"README.md", "missing.md"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(contains).To(gomega.BeFalse())
+
+	content, err := ioutil.ReadFile(filepath.Join(root, bucket, "asset", "docs", "README.md"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(content)).To(gomega.Equal("# Test"))
+
+	objects, err = fsStore.ListObjects(ctx, bucket, "asset/docs/index")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(objects).To(gomega.ConsistOf("asset/docs/index.md"))
+
+	objects, err = fsStore.ListObjects(ctx, bucket, "asset/missing/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(objects).To(gomega.BeEmpty())
+
+	objects, err = fsStore.ListObjects(ctx, bucket, "asset/../asset-v2/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(objects).To(gomega.BeEmpty())
+
+	err = fsStore.CopyObjects(ctx, bucket, "asset/docs/", "copy/", nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	objects, err = fsStore.ListObjects(ctx, bucket, "copy/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(objects).To(gomega.ConsistOf("copy/index.md", "copy/README.md"))
+
+	err = fsStore.DeleteObjects(ctx, bucket, "asset/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	objects, err = fsStore.ListObjects(ctx, bucket, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(objects).To(gomega.ConsistOf("asset-v2/spec.json", "copy/index.md", "copy/README.md"))
+	_, err = os.Stat(filepath.Join(root, bucket, "asset"))
+	g.Expect(os.IsNotExist(err)).To(gomega.BeTrue())
+}
+
+func TestFilesystemStore_PutObject(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		bucket   string
+		fileName string
+		size     int64
+		object   string
+	}{
+		"Success": {
+			fileName: "README.md",
+			size:     6,
+			object:   "asset/README.md",
+		},
+		"UnknownSize": {
+			fileName: "README.md",
+			size:     -1,
+			object:   "asset/README.md",
+		},
+		"PathInsideBucket": {
+			fileName: "../../../README.md",
+			size:     6,
+			object:   "README.md",
+		},
+		"SizeMismatch": {
+			fileName: "README.md",
+			size:     10,
+		},
+		"MissingBucket": {
+			bucket:   "missing",
+			fileName: "README.md",
+			size:     6,
+		},
+		"InvalidBucket": {
+			bucket:   "..",
+			fileName: "README.md",
+			size:     6,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			root := fixRoot(t)
+			defer os.RemoveAll(root)
+
+			fsStore, err := store.NewFilesystem(root)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			bucket, err := fsStore.CreateBucket("default", "test-bucket", "")
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			if testCase.bucket != "" {
+				bucket = testCase.bucket
+			}
+
+			// When
+			err = fsStore.PutObject(context.TODO(), bucket, "asset", testCase.fileName, bytes.NewReader([]byte("# Test")), testCase.size, store.ObjectMetadata{})
+
+			// Then
+			if testCase.object == "" {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			objects, err := fsStore.ListObjects(context.TODO(), bucket, "")
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(objects).To(gomega.ConsistOf(testCase.object))
+		})
+	}
+}
+
+func TestFilesystemStore_SyncObjects(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	root := fixRoot(t)
+	defer os.RemoveAll(root)
+	source := fixSourceDirectory(t, map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"})
+	defer os.RemoveAll(source)
+
+	fsStore, err := store.NewFilesystem(root)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	bucket, err := fsStore.CreateBucket("default", "test-bucket", "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	ctx := context.TODO()
+
+	_, err = fsStore.SyncObjects(ctx, bucket, "asset", source, []string{"a.txt", "b.txt"}, store.ObjectMetadata{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(ioutil.WriteFile(filepath.Join(source, "b.txt"), []byte("changed"), 0644)).To(gomega.Succeed())
+
+	// When
+	result, err := fsStore.SyncObjects(ctx, bucket, "asset", source, []string{"b.txt", "c.txt"}, store.ObjectMetadata{})
+
+	// Then
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.Uploaded).To(gomega.ConsistOf("b.txt", "c.txt"))
+	g.Expect(result.Deleted).To(gomega.ConsistOf("asset/a.txt"))
+
+	objects, err := fsStore.ListObjects(ctx, bucket, "asset/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(objects).To(gomega.ConsistOf("asset/b.txt", "asset/c.txt"))
+
+	content, err := ioutil.ReadFile(filepath.Join(root, bucket, "asset", "b.txt"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(content)).To(gomega.Equal("changed"))
+
+	result, err = fsStore.SyncObjects(ctx, bucket, "asset", source, []string{"b.txt", "c.txt"}, store.ObjectMetadata{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.Uploaded).To(gomega.BeEmpty())
+	g.Expect(result.Deleted).To(gomega.BeEmpty())
+}
+
+func TestFilesystemStore_SiblingAssets(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	root := fixRoot(t)
+	defer os.RemoveAll(root)
+	source := fixSourceDirectory(t, map[string]string{"README.md": "# Test", "spec.json": "{}"})
+	defer os.RemoveAll(source)
+
+	fsStore, err := store.NewFilesystem(root)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	bucket, err := fsStore.CreateBucket("default", "test-bucket", "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	ctx := context.TODO()
+
+	for _, asset := range []string{"api", "api-v2", "apis"} {
+		err = fsStore.PutObjects(ctx, bucket, asset, source, []string{"README.md", "spec.json"}, store.ObjectMetadata{})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+
+	// When
+	result, err := fsStore.SyncObjects(ctx, bucket, "api", source, []string{"README.md"}, store.ObjectMetadata{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.Deleted).To(gomega.ConsistOf("api/spec.json"))
+
+	contains, err := fsStore.ContainsAllObjects(ctx, bucket, "api", []string{"README.md"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(contains).To(gomega.BeTrue())
+
+	err = fsStore.DeleteObjects(ctx, bucket, store.AssetPrefix("api"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	err = fsStore.DeleteObjects(ctx, bucket, "api")
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// Then
+	objects, err := fsStore.ListObjects(ctx, bucket, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(objects).To(gomega.ConsistOf("api-v2/README.md", "api-v2/spec.json", "apis/README.md", "apis/spec.json"))
+}
+
+func TestFilesystemStore_Encryption(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	root := fixRoot(t)
+	defer os.RemoveAll(root)
+	source := fixSourceDirectory(t, map[string]string{"a.txt": "a"})
+	defer os.RemoveAll(source)
+
+	fsStore, err := store.NewFilesystem(root)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	sse, err := store.NewServerSideEncryption(v1beta1.BucketEncryptionSSES3, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// When
+	bucket, err := fsStore.CreateBucket("default", "test-bucket", "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	setErr := fsStore.SetBucketEncryption(bucket, &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3})
+	putErr := fsStore.PutObjects(context.TODO(), bucket, "asset", source, []string{"a.txt"}, store.ObjectMetadata{}.WithEncryption(sse))
+
+	// Then
+	g.Expect(store.IsNotSupportedError(setErr)).To(gomega.BeTrue())
+	g.Expect(store.IsNotSupportedError(putErr)).To(gomega.BeTrue())
+}
+
+func TestNewForConfig(t *testing.T) {
+	root := fixRoot(t)
+	defer os.RemoveAll(root)
+
+	for testName, testCase := range map[string]struct {
+		cfg    store.Config
+		failed bool
+	}{
+		"Minio": {
+			cfg: store.Config{Backend: store.BackendMinio, Endpoint: "minio.kyma.local", AccessKey: "access", SecretKey: "secret"},
+		},
+		"S3": {
+			cfg: store.Config{Backend: store.BackendS3, Endpoint: "s3.amazonaws.com", AccessKey: "access", SecretKey: "secret", UseSSL: true},
+		},
+		"MinioWithoutCredentials": {
+			cfg:    store.Config{Backend: store.BackendMinio, Endpoint: "minio.kyma.local"},
+			failed: true,
+		},
+		"Filesystem": {
+			cfg: store.Config{Backend: store.BackendFilesystem, Directory: filepath.Join(root, "store")},
+		},
+		"Unknown": {
+			cfg:    store.Config{Backend: "gcs"},
+			failed: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			result, err := store.NewForConfig(testCase.cfg)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(result).NotTo(gomega.BeNil())
+		})
+	}
+}
+
+func fixRoot(t *testing.T) string {
+	root, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return root
+}
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+const lifecycleRuleEnabled = "Enabled"
+
+type lifecycleConfiguration struct {
+	XMLName xml.Name        `xml:"LifecycleConfiguration"`
+	Rules   []lifecycleRule `xml:"Rule"`
+}
+
+type lifecycleRule struct {
+	ID     string `xml:"ID,omitempty"`
+	Status string `xml:"Status"`
+	// Prefix is the filter of the rules in the legacy format, which the server can still return
+	Prefix                         string                          `xml:"Prefix,omitempty"`
+	Filter                         *lifecycleFilter                `xml:"Filter"`
+	Expiration                     *lifecycleExpiration            `xml:"Expiration,omitempty"`
+	AbortIncompleteMultipartUpload *lifecycleAbortIncompleteUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+}
+
+type lifecycleFilter struct {
+	Prefix string        `xml:"Prefix"`
+	Tag    *lifecycleTag `xml:"Tag,omitempty"`
+	And    *lifecycleAnd `xml:"And,omitempty"`
+}
+
+type lifecycleAnd struct {
+	Prefix string         `xml:"Prefix,omitempty"`
+	Tags   []lifecycleTag `xml:"Tag"`
+}
+
+type lifecycleTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type lifecycleExpiration struct {
+	Days int64  `xml:"Days,omitempty"`
+	Date string `xml:"Date,omitempty"`
+}
+
+type lifecycleAbortIncompleteUpload struct {
+	DaysAfterInitiation int64 `xml:"DaysAfterInitiation"`
+}
+
+// BucketLifecycle returns the lifecycle configuration of the rules, which is empty if there are no rules.
+// Rules without identifiers are identified by their position.
+func BucketLifecycle(rules []v1beta1.BucketLifecycleRule) (string, error) {
+	if len(rules) == 0 {
+		return "", nil
+	}
+
+	configuration := lifecycleConfiguration{}
+	ids := make(map[string]struct{}, len(rules))
+	for i, rule := range rules {
+		id := lifecycleRuleID(i, rule)
+		if _, ok := ids[id]; ok {
+			return "", fmt.Errorf("lifecycle rule ID %s is not unique", id)
+		}
+		ids[id] = struct{}{}
+
+		result := lifecycleRule{ID: id, Status: lifecycleRuleEnabled, Filter: &lifecycleFilter{Prefix: rule.Prefix}}
+		if rule.ExpirationDays == nil && rule.AbortIncompleteUploadDays == nil {
+			return "", fmt.Errorf("lifecycle rule %s has to expire objects or abort incomplete uploads", id)
+		}
+		if rule.ExpirationDays != nil {
+			if *rule.ExpirationDays < 1 {
+				return "", fmt.Errorf("expiration days of lifecycle rule %s must be positive", id)
+			}
+			result.Expiration = &lifecycleExpiration{Days: *rule.ExpirationDays}
+		}
+		if rule.AbortIncompleteUploadDays != nil {
+			if *rule.AbortIncompleteUploadDays < 1 {
+				return "", fmt.Errorf("incomplete upload days of lifecycle rule %s must be positive", id)
+			}
+			result.AbortIncompleteMultipartUpload = &lifecycleAbortIncompleteUpload{DaysAfterInitiation: *rule.AbortIncompleteUploadDays}
+		}
+		configuration.Rules = append(configuration.Rules, result)
+	}
+
+	marshaled, err := xml.Marshal(configuration)
+	if err != nil {
+		return "", errors.Wrap(err, "while marshalling bucket lifecycle")
+	}
+
+	return string(marshaled), nil
+}
+
+// lifecycleEqual compares the rules of the configurations regardless of their order and format
+func lifecycleEqual(expected, current string) (bool, error) {
+	expectedRules, err := lifecycleRules(expected)
+	if err != nil {
+		return false, err
+	}
+	currentRules, err := lifecycleRules(current)
+	if err != nil {
+		return false, err
+	}
+	if len(expectedRules) != len(currentRules) {
+		return false, nil
+	}
+
+	for i := range expectedRules {
+		if expectedRules[i] != currentRules[i] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// lifecycleRules returns the sorted rules of the configuration in the normalized form
+func lifecycleRules(configuration string) ([]string, error) {
+	if configuration == "" {
+		return nil, nil
+	}
+
+	var result lifecycleConfiguration
+	if err := xml.Unmarshal([]byte(configuration), &result); err != nil {
+		return nil, errors.Wrap(err, "while unmarshalling bucket lifecycle")
+	}
+
+	rules := make([]string, 0, len(result.Rules))
+	for _, rule := range result.Rules {
+		prefix, tags := rule.Prefix, 0
+		if rule.Filter != nil {
+			prefix = rule.Filter.Prefix
+			if rule.Filter.Tag != nil {
+				tags = 1
+			}
+			if rule.Filter.And != nil {
+				prefix, tags = rule.Filter.And.Prefix, len(rule.Filter.And.Tags)
+			}
+		}
+		var expiration lifecycleExpiration
+		if rule.Expiration != nil {
+			expiration = *rule.Expiration
+		}
+		var abortDays int64
+		if rule.AbortIncompleteMultipartUpload != nil {
+			abortDays = rule.AbortIncompleteMultipartUpload.DaysAfterInitiation
+		}
+
+		rules = append(rules, fmt.Sprintf("%s|%s|%s|%d|%d|%s|%d", rule.ID, rule.Status, prefix, tags, expiration.Days, expiration.Date, abortDays))
+	}
+	sort.Strings(rules)
+
+	return rules, nil
+}
+
+func lifecycleRuleID(index int, rule v1beta1.BucketLifecycleRule) string {
+	if rule.ID != "" {
+		return rule.ID
+	}
+
+	return fmt.Sprintf("rule-%d", index+1)
+}
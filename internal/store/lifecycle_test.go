@@ -0,0 +1,72 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestBucketLifecycle(t *testing.T) {
+	days := func(value int64) *int64 {
+		return &value
+	}
+
+	for testName, testCase := range map[string]struct {
+		rules     []v1beta1.BucketLifecycleRule
+		lifecycle string
+		failed    bool
+	}{
+		"NoRules": {
+			lifecycle: "",
+		},
+		"Expiration": {
+			rules:     []v1beta1.BucketLifecycleRule{{Prefix: "tmp/", ExpirationDays: days(7)}},
+			lifecycle: "<LifecycleConfiguration><Rule><ID>rule-1</ID><Status>Enabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>7</Days></Expiration></Rule></LifecycleConfiguration>",
+		},
+		"AbortIncompleteUpload": {
+			rules:     []v1beta1.BucketLifecycleRule{{ID: "uploads", AbortIncompleteUploadDays: days(1)}},
+			lifecycle: "<LifecycleConfiguration><Rule><ID>uploads</ID><Status>Enabled</Status><Filter><Prefix></Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>",
+		},
+		"MultipleRules": {
+			rules: []v1beta1.BucketLifecycleRule{{ExpirationDays: days(30), AbortIncompleteUploadDays: days(2)}, {Prefix: "tmp/", ExpirationDays: days(1)}},
+			lifecycle: "<LifecycleConfiguration>" +
+				"<Rule><ID>rule-1</ID><Status>Enabled</Status><Filter><Prefix></Prefix></Filter><Expiration><Days>30</Days></Expiration><AbortIncompleteMultipartUpload><DaysAfterInitiation>2</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>" +
+				"<Rule><ID>rule-2</ID><Status>Enabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>" +
+				"</LifecycleConfiguration>",
+		},
+		"NoAction": {
+			rules:  []v1beta1.BucketLifecycleRule{{Prefix: "tmp/"}},
+			failed: true,
+		},
+		"ExpirationDays": {
+			rules:  []v1beta1.BucketLifecycleRule{{ExpirationDays: days(0)}},
+			failed: true,
+		},
+		"AbortIncompleteUploadDays": {
+			rules:  []v1beta1.BucketLifecycleRule{{AbortIncompleteUploadDays: days(-1)}},
+			failed: true,
+		},
+		"DuplicatedID": {
+			rules:  []v1beta1.BucketLifecycleRule{{ExpirationDays: days(1)}, {ID: "rule-1", ExpirationDays: days(2)}},
+			failed: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			lifecycle, err := store.BucketLifecycle(testCase.rules)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(lifecycle).To(gomega.Equal(testCase.lifecycle))
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package store
+
+import (
+	"fmt"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/encrypt"
+	"github.com/pkg/errors"
+)
+
+const (
+	userMetadataHeaderPrefix = "X-Amz-Meta-"
+	defaultContentType       = "application/octet-stream"
+)
+
+// contentTypes overrides the system MIME types of the file types served by static sites, which are missing
+// or wrong in some distributions
+var contentTypes = map[string]string{
+	".css":  "text/css; charset=utf-8",
+	".html": "text/html; charset=utf-8",
+	".js":   "text/javascript; charset=utf-8",
+	".json": "application/json",
+	".mjs":  "text/javascript; charset=utf-8",
+	".svg":  "image/svg+xml",
+	".wasm": "application/wasm",
+}
+
+var userMetadataKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// ObjectMetadata resolves the headers of the uploaded objects from the metadata rules of the asset.
+// The zero value sets only the content type guessed from the file extension.
+type ObjectMetadata struct {
+	rules          []v1beta1.AssetObjectMetadata
+	encoded        map[string]EncodedFile
+	encryption     encrypt.ServerSide
+	unchangedRules bool
+}
+
+// EncodedFile is a file with the content of another file compressed with the encoding
+type EncodedFile struct {
+	Original string
+	Encoding string
+}
+
+// NewObjectMetadata validates the patterns and user metadata keys of the rules
+func NewObjectMetadata(rules []v1beta1.AssetObjectMetadata) (ObjectMetadata, error) {
+	for _, rule := range rules {
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			return ObjectMetadata{}, errors.Wrapf(err, "while parsing pattern %s", rule.Pattern)
+		}
+		for key := range rule.UserMetadata {
+			name := trimUserMetadataPrefix(key)
+			if !userMetadataKeyRegexp.MatchString(name) {
+				return ObjectMetadata{}, fmt.Errorf("%s: user metadata key must consist of alphanumeric characters and dashes", key)
+			}
+			if strings.EqualFold(name, sha256MetadataKey) {
+				return ObjectMetadata{}, fmt.Errorf("%s: user metadata key is reserved", key)
+			}
+		}
+	}
+
+	return ObjectMetadata{rules: rules}, nil
+}
+
+// WithEncodedFiles returns the metadata which sets the headers of the original file for the encoded files,
+// together with their content encoding
+func (m ObjectMetadata) WithEncodedFiles(files map[string]EncodedFile) ObjectMetadata {
+	encoded := make(map[string]EncodedFile, len(m.encoded)+len(files))
+	for name, file := range m.encoded {
+		encoded[name] = file
+	}
+	for name, file := range files {
+		encoded[name] = file
+	}
+
+	result := m
+	result.encoded = encoded
+	return result
+}
+
+// WithEncryption returns the metadata which encrypts the objects on the server side with the encryption
+func (m ObjectMetadata) WithEncryption(sse encrypt.ServerSide) ObjectMetadata {
+	result := m
+	result.encryption = sse
+	return result
+}
+
+// WithUnchangedRules returns the metadata with the rules the existing objects were uploaded with, so the headers
+// of unencrypted objects with the same content don't have to be read to compare them
+func (m ObjectMetadata) WithUnchangedRules() ObjectMetadata {
+	result := m
+	result.unchangedRules = true
+	return result
+}
+
+// Encryption returns the server side encryption of the objects, which is nil if they are not encrypted
+func (m ObjectMetadata) Encryption() encrypt.ServerSide {
+	return m.encryption
+}
+
+// Options returns the upload options of the file with the name relative to the asset directory
+func (m ObjectMetadata) Options(fileName string) minio.PutObjectOptions {
+	file, ok := m.encoded[fileName]
+	if !ok {
+		return m.options(fileName)
+	}
+
+	options := m.options(file.Original)
+	options.ContentEncoding = file.Encoding
+	return options
+}
+
+func (m ObjectMetadata) options(fileName string) minio.PutObjectOptions {
+	options := minio.PutObjectOptions{ContentType: contentTypeByExtension(fileName), ServerSideEncryption: m.encryption}
+	for _, rule := range m.rules {
+		if !matchesPattern(rule.Pattern, fileName) {
+			continue
+		}
+		if rule.ContentType != "" {
+			options.ContentType = rule.ContentType
+		}
+		if rule.CacheControl != "" {
+			options.CacheControl = rule.CacheControl
+		}
+		if rule.ContentDisposition != "" {
+			options.ContentDisposition = rule.ContentDisposition
+		}
+		if rule.ContentEncoding != "" {
+			options.ContentEncoding = rule.ContentEncoding
+		}
+		for key, value := range rule.UserMetadata {
+			if options.UserMetadata == nil {
+				options.UserMetadata = make(map[string]string)
+			}
+			options.UserMetadata[strings.ToLower(trimUserMetadataPrefix(key))] = value
+		}
+	}
+
+	return options
+}
+
+// headers returns the headers set by the options, in the form accepted as user metadata of copied objects
+func headers(options minio.PutObjectOptions) map[string]string {
+	result := map[string]string{"Content-Type": options.ContentType}
+	for key, value := range map[string]string{
+		"Cache-Control":       options.CacheControl,
+		"Content-Disposition": options.ContentDisposition,
+		"Content-Encoding":    options.ContentEncoding,
+	} {
+		if value != "" {
+			result[key] = value
+		}
+	}
+	for key, value := range options.UserMetadata {
+		result[userMetadataHeaderPrefix+key] = value
+	}
+
+	return result
+}
+
+// headersMatch returns true if the object has the headers and no other user metadata than the options,
+// ignoring the digest of its content, and is encrypted in the same way. The client returns the content type
+// apart from the other headers.
+func headersMatch(object minio.ObjectInfo, options minio.PutObjectOptions) bool {
+	if object.ContentType != options.ContentType || !encryptionMatches(object, options.ServerSideEncryption) {
+		return false
+	}
+	expected := headers(options)
+	delete(expected, "Content-Type")
+	for key, value := range expected {
+		if object.Metadata.Get(key) != value {
+			return false
+		}
+	}
+	for _, key := range []string{"Cache-Control", "Content-Disposition", "Content-Encoding"} {
+		if _, ok := expected[key]; !ok && object.Metadata.Get(key) != "" {
+			return false
+		}
+	}
+	for key := range object.Metadata {
+		if !strings.HasPrefix(key, userMetadataHeaderPrefix) || strings.EqualFold(key, userMetadataHeaderPrefix+sha256MetadataKey) {
+			continue
+		}
+		if _, ok := options.UserMetadata[strings.ToLower(strings.TrimPrefix(key, userMetadataHeaderPrefix))]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesPattern matches patterns without a delimiter against the base name of the file, and other patterns
+// against the whole name
+func matchesPattern(pattern, fileName string) bool {
+	if pattern == "" {
+		return true
+	}
+	if !strings.Contains(pattern, "/") {
+		fileName = path.Base(fileName)
+	}
+	matched, _ := path.Match(pattern, fileName)
+
+	return matched
+}
+
+func contentTypeByExtension(fileName string) string {
+	extension := strings.ToLower(path.Ext(fileName))
+	if contentType, ok := contentTypes[extension]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+
+	return defaultContentType
+}
+
+func trimUserMetadataPrefix(key string) string {
+	if strings.HasPrefix(strings.ToLower(key), strings.ToLower(userMetadataHeaderPrefix)) {
+		return key[len(userMetadataHeaderPrefix):]
+	}
+
+	return key
+}
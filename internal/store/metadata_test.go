@@ -0,0 +1,81 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go"
+	"github.com/onsi/gomega"
+)
+
+func TestObjectMetadata_Options(t *testing.T) {
+	rules := []v1beta1.AssetObjectMetadata{
+		{CacheControl: "max-age=60", UserMetadata: map[string]string{"team": "docs"}},
+		{Pattern: "*.html", CacheControl: "no-cache"},
+		{Pattern: "downloads/*", ContentDisposition: "attachment", UserMetadata: map[string]string{"X-Amz-Meta-Team": "downloads"}},
+		{Pattern: "*.gz", ContentType: "application/json", ContentEncoding: "gzip"},
+	}
+
+	for testName, testCase := range map[string]struct {
+		fileName string
+		options  minio.PutObjectOptions
+	}{
+		"Defaults": {
+			fileName: "app.wasm",
+			options:  minio.PutObjectOptions{ContentType: "application/wasm", CacheControl: "max-age=60", UserMetadata: map[string]string{"team": "docs"}},
+		},
+		"BaseNamePattern": {
+			fileName: "docs/index.html",
+			options:  minio.PutObjectOptions{ContentType: "text/html; charset=utf-8", CacheControl: "no-cache", UserMetadata: map[string]string{"team": "docs"}},
+		},
+		"PathPattern": {
+			fileName: "downloads/logo.svg",
+			options:  minio.PutObjectOptions{ContentType: "image/svg+xml", CacheControl: "max-age=60", ContentDisposition: "attachment", UserMetadata: map[string]string{"team": "downloads"}},
+		},
+		"PathPatternNotMatched": {
+			fileName: "downloads/latest/module.mjs",
+			options:  minio.PutObjectOptions{ContentType: "text/javascript; charset=utf-8", CacheControl: "max-age=60", UserMetadata: map[string]string{"team": "docs"}},
+		},
+		"ContentTypeOverride": {
+			fileName: "spec.json.gz",
+			options:  minio.PutObjectOptions{ContentType: "application/json", CacheControl: "max-age=60", ContentEncoding: "gzip", UserMetadata: map[string]string{"team": "docs"}},
+		},
+		"UnknownExtension": {
+			fileName: "data.unknown-extension",
+			options:  minio.PutObjectOptions{ContentType: "application/octet-stream", CacheControl: "max-age=60", UserMetadata: map[string]string{"team": "docs"}},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			metadata, err := store.NewObjectMetadata(rules)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			// When
+			options := metadata.Options(testCase.fileName)
+
+			// Then
+			g.Expect(options).To(gomega.Equal(testCase.options))
+		})
+	}
+}
+
+func TestNewObjectMetadata_Invalid(t *testing.T) {
+	for testName, rule := range map[string]v1beta1.AssetObjectMetadata{
+		"Pattern":         {Pattern: "[a-"},
+		"UserMetadataKey": {UserMetadata: map[string]string{"team name": "docs"}},
+		"ReservedKey":     {UserMetadata: map[string]string{"x-amz-meta-sha256": "digest"}},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			_, err := store.NewObjectMetadata([]v1beta1.AssetObjectMetadata{rule})
+
+			// Then
+			g.Expect(err).To(gomega.HaveOccurred())
+		})
+	}
+}
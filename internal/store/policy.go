@@ -0,0 +1,221 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go/pkg/policy"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/pkg/errors"
+)
+
+const (
+	bucketPolicyVersion = "2012-10-17"
+	awsResourcePrefix   = "arn:aws:s3:::"
+
+	// BucketNamePlaceholder is replaced with the name of the bucket in the raw policy documents,
+	// as the names are generated by the controllers
+	BucketNamePlaceholder = "${bucket}"
+)
+
+// ValidateBucketPolicy makes sure that the policy document can be built from the access policy
+func ValidateBucketPolicy(bucketPolicy v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) error {
+	_, err := newBucketPolicy("bucket", bucketPolicy, accessPolicy)
+	return err
+}
+
+// newBucketPolicy builds the policy document from the canned policy of the whole bucket, the rules granting
+// the canned policies to the prefixes, and the explicit statements. The raw document is used on its own.
+func newBucketPolicy(bucketName string, bucketPolicy v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) (policy.BucketAccessPolicy, error) {
+	if accessPolicy != nil && accessPolicy.Raw != "" {
+		if len(accessPolicy.Rules) > 0 || len(accessPolicy.Statements) > 0 || isGrantingPolicy(bucketPolicy) {
+			return policy.BucketAccessPolicy{}, errors.New("raw policy can't be combined with other policies")
+		}
+		return parseRawPolicy(bucketName, accessPolicy.Raw)
+	}
+
+	statements := policy.SetPolicy(make([]policy.Statement, 0), cannedPolicy(bucketPolicy), bucketName, "")
+	if accessPolicy == nil {
+		return policy.BucketAccessPolicy{Version: bucketPolicyVersion, Statements: statements}, nil
+	}
+
+	for _, rule := range accessPolicy.Rules {
+		if rule.Prefix == "" {
+			return policy.BucketAccessPolicy{}, errors.New("prefix of the policy rule is required")
+		}
+		if !isGrantingPolicy(rule.Policy) {
+			return policy.BucketAccessPolicy{}, fmt.Errorf("policy `%s` of the rule for prefix %s is not supported", rule.Policy, rule.Prefix)
+		}
+		statements = policy.SetPolicy(statements, cannedPolicy(rule.Policy), bucketName, rule.Prefix)
+	}
+
+	for i, statement := range accessPolicy.Statements {
+		result, err := newStatement(bucketName, statement)
+		if err != nil {
+			return policy.BucketAccessPolicy{}, errors.Wrapf(err, "while reading statement %d", i)
+		}
+		statements = append(statements, result)
+	}
+
+	return policy.BucketAccessPolicy{Version: bucketPolicyVersion, Statements: statements}, nil
+}
+
+func newStatement(bucketName string, statement v1beta1.BucketPolicyStatement) (policy.Statement, error) {
+	if statement.Effect != v1beta1.BucketPolicyEffectAllow && statement.Effect != v1beta1.BucketPolicyEffectDeny {
+		return policy.Statement{}, fmt.Errorf("effect `%s` is not supported", statement.Effect)
+	}
+	if len(statement.Actions) == 0 || len(statement.Resources) == 0 {
+		return policy.Statement{}, errors.New("actions and resources are required")
+	}
+	for _, action := range statement.Actions {
+		if !strings.HasPrefix(action, "s3:") {
+			return policy.Statement{}, fmt.Errorf("action %s is not an S3 action", action)
+		}
+	}
+
+	principals := statement.Principals
+	if len(principals) == 0 {
+		principals = []string{"*"}
+	}
+	resources := set.NewStringSet()
+	for _, resource := range statement.Resources {
+		resources.Add(bucketResource(bucketName, resource))
+	}
+
+	return policy.Statement{
+		Sid:       statement.Sid,
+		Effect:    string(statement.Effect),
+		Principal: policy.User{AWS: set.CreateStringSet(principals...)},
+		Actions:   set.CreateStringSet(statement.Actions...),
+		Resources: resources,
+	}, nil
+}
+
+// parseRawPolicy reads only the policy elements known to the client, so the document can't be changed silently
+func parseRawPolicy(bucketName, raw string) (policy.BucketAccessPolicy, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(strings.Replace(raw, BucketNamePlaceholder, bucketName, -1))))
+	decoder.DisallowUnknownFields()
+
+	var result policy.BucketAccessPolicy
+	if err := decoder.Decode(&result); err != nil {
+		return policy.BucketAccessPolicy{}, errors.Wrap(err, "while parsing raw policy")
+	}
+	if result.Version == "" {
+		result.Version = bucketPolicyVersion
+	}
+	if result.Statements == nil {
+		result.Statements = make([]policy.Statement, 0)
+	}
+
+	return result, nil
+}
+
+// bucketResource returns the ARN of the bucket for the empty pattern, and the ARN of the matching objects otherwise
+func bucketResource(bucketName, pattern string) string {
+	if pattern == "" {
+		return awsResourcePrefix + bucketName
+	}
+
+	return awsResourcePrefix + bucketName + "/" + strings.TrimPrefix(pattern, "/")
+}
+
+func cannedPolicy(bucketPolicy v1beta1.BucketPolicy) policy.BucketPolicy {
+	switch bucketPolicy {
+	case v1beta1.BucketPolicyReadOnly:
+		return policy.BucketPolicyReadOnly
+	case v1beta1.BucketPolicyWriteOnly:
+		return policy.BucketPolicyWriteOnly
+	case v1beta1.BucketPolicyReadWrite:
+		return policy.BucketPolicyReadWrite
+	default:
+		return policy.BucketPolicyNone
+	}
+}
+
+func isGrantingPolicy(bucketPolicy v1beta1.BucketPolicy) bool {
+	return cannedPolicy(bucketPolicy) != policy.BucketPolicyNone
+}
+
+// policyPermissions expands the statements into single permissions, so the policies are compared regardless
+// of how the server merges or splits their statements. The permissions of bucket actions on objects and object
+// actions on the bucket are skipped, as they don't grant anything.
+func policyPermissions(bucketPolicy policy.BucketAccessPolicy) map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, statement := range bucketPolicy.Statements {
+		principals := append(prefixed("AWS:", statement.Principal.AWS), prefixed("CanonicalUser:", statement.Principal.CanonicalUser)...)
+		conditions := conditionAlternatives(statement.Conditions)
+		for _, action := range statement.Actions.ToSlice() {
+			for _, resource := range statement.Resources.ToSlice() {
+				if !actionApplies(action, resource) {
+					continue
+				}
+				for _, principal := range principals {
+					for _, condition := range conditions {
+						result[strings.Join([]string{statement.Effect, principal, action, resource, condition}, "|")] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// conditionAlternatives returns the conditions with a single value of every key, which are equivalent to
+// the condition map together. The values of negated operators have to be matched all at once.
+func conditionAlternatives(conditions policy.ConditionMap) []string {
+	alternatives := []string{""}
+	operators := make([]string, 0, len(conditions))
+	for operator := range conditions {
+		operators = append(operators, operator)
+	}
+	sort.Strings(operators)
+
+	for _, operator := range operators {
+		keys := make([]string, 0, len(conditions[operator]))
+		for key := range conditions[operator] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			values := conditions[operator][key].ToSlice()
+			if len(values) == 0 || strings.Contains(operator, "Not") {
+				values = []string{strings.Join(values, ",")}
+			}
+
+			var next []string
+			for _, alternative := range alternatives {
+				for _, value := range values {
+					next = append(next, fmt.Sprintf("%s%s:%s=%s;", alternative, operator, key, value))
+				}
+			}
+			alternatives = next
+		}
+	}
+
+	return alternatives
+}
+
+// actionApplies returns false for bucket actions on objects, and object actions on the bucket
+func actionApplies(action, resource string) bool {
+	if strings.HasSuffix(action, "*") {
+		return true
+	}
+
+	objectResource := strings.Contains(strings.TrimPrefix(resource, awsResourcePrefix), "/")
+	return strings.Contains(action, "Bucket") != objectResource
+}
+
+func prefixed(prefix string, values set.StringSet) []string {
+	var result []string
+	for _, value := range values.ToSlice() {
+		result = append(result, prefix+value)
+	}
+
+	return result
+}
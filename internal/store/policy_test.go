@@ -0,0 +1,89 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestValidateBucketPolicy(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		policy       v1beta1.BucketPolicy
+		accessPolicy *v1beta1.BucketAccessPolicy
+		failed       bool
+	}{
+		"Canned": {
+			policy: v1beta1.BucketPolicyReadOnly,
+		},
+		"Rules": {
+			policy: v1beta1.BucketPolicyNone,
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Rules: []v1beta1.BucketPolicyRule{{Prefix: "assets/", Policy: v1beta1.BucketPolicyReadOnly}, {Prefix: "uploads/", Policy: v1beta1.BucketPolicyWriteOnly}},
+			},
+		},
+		"Statements": {
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Statements: []v1beta1.BucketPolicyStatement{{Effect: v1beta1.BucketPolicyEffectAllow, Principals: []string{"arn:aws:iam::123456789012:user/docs"}, Actions: []string{"s3:GetObject"}, Resources: []string{"drafts/*"}}},
+			},
+		},
+		"Raw": {
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Raw: `{"Version":"2012-10-17","Statement":[{"Sid":"Public","Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":["*"]},"Resource":["arn:aws:s3:::${bucket}/*"]}]}`,
+			},
+		},
+		"RuleWithoutPrefix": {
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Rules: []v1beta1.BucketPolicyRule{{Policy: v1beta1.BucketPolicyReadOnly}},
+			},
+			failed: true,
+		},
+		"RuleWithNonePolicy": {
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Rules: []v1beta1.BucketPolicyRule{{Prefix: "drafts/", Policy: v1beta1.BucketPolicyNone}},
+			},
+			failed: true,
+		},
+		"StatementEffect": {
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Statements: []v1beta1.BucketPolicyStatement{{Effect: "Permit", Actions: []string{"s3:GetObject"}, Resources: []string{"*"}}},
+			},
+			failed: true,
+		},
+		"StatementAction": {
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Statements: []v1beta1.BucketPolicyStatement{{Effect: v1beta1.BucketPolicyEffectAllow, Actions: []string{"GetObject"}, Resources: []string{"*"}}},
+			},
+			failed: true,
+		},
+		"RawWithCannedPolicy": {
+			policy: v1beta1.BucketPolicyReadOnly,
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Raw: `{"Statement":[]}`,
+			},
+			failed: true,
+		},
+		"RawNotJSON": {
+			accessPolicy: &v1beta1.BucketAccessPolicy{
+				Raw: `Statement: []`,
+			},
+			failed: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			err := store.ValidateBucketPolicy(testCase.policy, testCase.accessPolicy)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	}
+}
@@ -2,8 +2,13 @@ package store
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
@@ -13,44 +18,110 @@ import (
 
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/encrypt"
 	"github.com/minio/minio-go/pkg/policy"
+	"github.com/minio/minio-go/pkg/s3utils"
 	"github.com/pkg/errors"
 )
 
+const (
+	BackendMinio      = "minio"
+	BackendS3         = "s3"
+	BackendFilesystem = "filesystem"
+)
+
 type Config struct {
+	Backend            string `envconfig:"default=minio"`
 	Endpoint           string `envconfig:"default=minio.kyma.local"`
 	ExternalEndpoint   string `envconfig:"default=https://minio.kyma.local"`
-	AccessKey          string `envconfig:""`
-	SecretKey          string `envconfig:""`
+	AccessKey          string `envconfig:"optional"`
+	SecretKey          string `envconfig:"optional"`
 	UseSSL             bool   `envconfig:"default=true"`
 	UploadWorkersCount int    `envconfig:"default=10"`
+	Directory          string `envconfig:"default=/var/lib/rafter/store"`
+}
+
+// NotSupportedError means that the backend can't provide the requested feature
+type NotSupportedError struct {
+	message string
+}
+
+func (e *NotSupportedError) Error() string {
+	return e.message
+}
+
+func IsNotSupportedError(err error) bool {
+	_, ok := errors.Cause(err).(*NotSupportedError)
+	return ok
+}
+
+// AssetPrefix returns the prefix of the objects of the asset. The delimiter keeps the objects of assets
+// with names starting the same way, such as api and api-v2, apart.
+func AssetPrefix(assetName string) string {
+	return strings.TrimSuffix(assetName, "/") + "/"
+}
+
+// checkDeletePrefix makes sure that objects are deleted only in whole directories
+func checkDeletePrefix(prefix string) error {
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		return fmt.Errorf("%s: prefix of deleted objects must end with a delimiter", prefix)
+	}
+
+	return nil
 }
 
 //go:generate mockery -name=MinioClient -output=automock -outpkg=automock -case=underscore
 type MinioClient interface {
 	FPutObjectWithContext(ctx context.Context, bucketName, objectName, filePath string, opts minio.PutObjectOptions) (n int64, err error)
+	PutObjectWithContext(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (n int64, err error)
+	CopyObject(dst minio.DestinationInfo, src minio.SourceInfo) error
 	ListObjects(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan minio.ObjectInfo
 	MakeBucket(bucketName string, location string) error
 	BucketExists(bucketName string) (bool, error)
 	RemoveBucket(bucketName string) error
 	SetBucketPolicy(bucketName, policy string) error
+	SetBucketEncryption(bucketName, algorithm string) error
+	GetBucketEncryption(bucketName string) (string, error)
+	RemoveBucketEncryption(bucketName string) error
+	SetBucketLifecycle(bucketName, lifecycle string) error
+	GetBucketLifecycle(bucketName string) (string, error)
 	GetBucketPolicy(bucketName string) (string, error)
 	RemoveObjectsWithContext(ctx context.Context, bucketName string, objectsCh <-chan string) <-chan minio.RemoveObjectError
+	StatObject(bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
 }
 
 //go:generate mockery -name=Store -output=automock -outpkg=automock -case=underscore
 type Store interface {
 	CreateBucket(namespace, crName, region string) (string, error)
+	CreateNamedBucket(name, region string) error
 	BucketExists(name string) (bool, error)
 	DeleteBucket(ctx context.Context, name string) error
-	SetBucketPolicy(name string, policy v1beta1.BucketPolicy) error
-	CompareBucketPolicy(name string, expected v1beta1.BucketPolicy) (bool, error)
+	SetBucketPolicy(name string, policy v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) error
+	CompareBucketPolicy(name string, expected v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) (bool, error)
+	SetBucketLifecycle(name string, rules []v1beta1.BucketLifecycleRule) error
+	CompareBucketLifecycle(name string, rules []v1beta1.BucketLifecycleRule) (bool, error)
+	SetBucketEncryption(name string, encryption *v1beta1.BucketEncryption) error
+	CompareBucketEncryption(name string, encryption *v1beta1.BucketEncryption) (bool, error)
 	ContainsAllObjects(ctx context.Context, bucketName, assetName string, files []string) (bool, error)
-	PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string) error
+	PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string, metadata ObjectMetadata) error
+	SyncObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string, metadata ObjectMetadata) (SyncResult, error)
+	PutObject(ctx context.Context, bucketName, assetName, fileName string, reader io.Reader, size int64, metadata ObjectMetadata) error
+	CopyObject(ctx context.Context, bucketName, assetName, sourceFileName, fileName string, metadata ObjectMetadata) error
+	CopyObjects(ctx context.Context, bucketName, sourcePrefix, prefix string, sse encrypt.ServerSide) error
 	DeleteObjects(ctx context.Context, bucketName, prefix string) error
+	DeleteStaleObjects(ctx context.Context, bucketName, assetName string, files []string) ([]string, error)
 	ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error)
 }
 
+// SyncResult lists the files uploaded and the objects deleted while the asset content is synchronized
+type SyncResult struct {
+	Uploaded []string
+	Deleted  []string
+}
+
+// sha256MetadataKey is the user metadata with the digest of the uploaded file, used when the ETag is not its MD5 sum
+const sha256MetadataKey = "sha256"
+
 type store struct {
 	client            MinioClient
 	uploadWorkerCount int
@@ -63,22 +134,50 @@ func New(client MinioClient, uploadWorkerCount int) Store {
 	}
 }
 
+// NewForConfig returns the store of the configured backend. The MinIO client works with any S3 compatible server.
+func NewForConfig(cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case BackendMinio, BackendS3:
+		if len(cfg.AccessKey) == 0 || len(cfg.SecretKey) == 0 {
+			return nil, fmt.Errorf("access key and secret key are required for %s backend", cfg.Backend)
+		}
+		client, err := NewClient(cfg.Endpoint, cfg.AccessKey, cfg.SecretKey, cfg.UseSSL)
+		if err != nil {
+			return nil, errors.Wrap(err, "while initializing Minio client")
+		}
+		return New(client, cfg.UploadWorkersCount), nil
+	case BackendFilesystem:
+		return NewFilesystem(cfg.Directory)
+	default:
+		return nil, fmt.Errorf("not supported store backend %s", cfg.Backend)
+	}
+}
+
 // Bucket
 
+// CreateBucket creates the bucket with the name generated from the name of the CR
 func (s *store) CreateBucket(namespace, crName, region string) (string, error) {
-	bucketName, err := s.findBucketName(crName)
+	bucketName, err := findBucketName(crName, s.BucketExists)
 	if err != nil {
 		return "", err
 	}
 
-	err = s.client.MakeBucket(bucketName, region)
-	if err != nil {
-		return "", errors.Wrapf(err, "while creating bucket %s in region %s", bucketName, region)
+	if err := s.CreateNamedBucket(bucketName, region); err != nil {
+		return "", err
 	}
 
 	return bucketName, nil
 }
 
+func (s *store) CreateNamedBucket(name, region string) error {
+	err := s.client.MakeBucket(name, region)
+	if err != nil {
+		return errors.Wrapf(err, "while creating bucket %s in region %s", name, region)
+	}
+
+	return nil
+}
+
 func (s *store) BucketExists(name string) (bool, error) {
 	exists, err := s.client.BucketExists(name)
 	if err != nil {
@@ -110,8 +209,11 @@ func (s *store) DeleteBucket(ctx context.Context, name string) error {
 	return nil
 }
 
-func (s *store) SetBucketPolicy(name string, policy v1beta1.BucketPolicy) error {
-	bucketPolicy := s.prepareBucketPolicy(name, policy)
+func (s *store) SetBucketPolicy(name string, policy v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) error {
+	bucketPolicy, err := newBucketPolicy(name, policy, accessPolicy)
+	if err != nil {
+		return errors.Wrapf(err, "while preparing policy for bucket %s", name)
+	}
 	marshaled, err := s.marshalBucketPolicy(bucketPolicy)
 	if err != nil {
 		return err
@@ -125,8 +227,13 @@ func (s *store) SetBucketPolicy(name string, policy v1beta1.BucketPolicy) error
 	return nil
 }
 
-func (s *store) CompareBucketPolicy(name string, expected v1beta1.BucketPolicy) (bool, error) {
-	expectedPolicy := s.prepareBucketPolicy(name, expected)
+// CompareBucketPolicy compares the permissions granted or denied by the whole policy documents, as the server
+// can merge or split their statements
+func (s *store) CompareBucketPolicy(name string, expected v1beta1.BucketPolicy, accessPolicy *v1beta1.BucketAccessPolicy) (bool, error) {
+	expectedPolicy, err := newBucketPolicy(name, expected, accessPolicy)
+	if err != nil {
+		return false, errors.Wrapf(err, "while preparing policy for bucket %s", name)
+	}
 	currentPolicy, err := s.getBucketPolicy(name)
 	if err != nil {
 		return false, err
@@ -136,17 +243,78 @@ func (s *store) CompareBucketPolicy(name string, expected v1beta1.BucketPolicy)
 		return false, nil
 	}
 
-	if len(expectedPolicy.Statements) > 1 && len(currentPolicy.Statements) == 1 {
-		return s.compareMergedPolicy(expectedPolicy, currentPolicy), nil
+	return reflect.DeepEqual(policyPermissions(expectedPolicy), policyPermissions(*currentPolicy)), nil
+}
+
+// SetBucketLifecycle replaces the lifecycle rules of the bucket, and removes them if there are no rules
+func (s *store) SetBucketLifecycle(name string, rules []v1beta1.BucketLifecycleRule) error {
+	lifecycle, err := BucketLifecycle(rules)
+	if err != nil {
+		return errors.Wrapf(err, "while preparing lifecycle for bucket %s", name)
+	}
+
+	if err := s.client.SetBucketLifecycle(name, lifecycle); err != nil {
+		return errors.Wrapf(err, "while setting lifecycle for bucket %s", name)
+	}
+
+	return nil
+}
+
+// CompareBucketLifecycle compares the lifecycle rules of the bucket regardless of their order. Buckets without rules
+// are up-to-date in storages which don't implement the lifecycle API.
+func (s *store) CompareBucketLifecycle(name string, rules []v1beta1.BucketLifecycleRule) (bool, error) {
+	expected, err := BucketLifecycle(rules)
+	if err != nil {
+		return false, errors.Wrapf(err, "while preparing lifecycle for bucket %s", name)
+	}
+
+	current, err := s.client.GetBucketLifecycle(name)
+	if err != nil {
+		if len(rules) == 0 && minio.ToErrorResponse(err).Code == "NotImplemented" {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "while getting lifecycle for bucket %s", name)
+	}
+
+	equal, err := lifecycleEqual(expected, current)
+	if err != nil {
+		return false, errors.Wrapf(err, "while comparing lifecycle for bucket %s", name)
+	}
+
+	return equal, nil
+}
+
+// SetBucketEncryption sets the SSE-S3 default encryption of the bucket, and removes the default encryption otherwise.
+// SSE-C keys are sent with every request instead, so they can't be the default encryption of the bucket.
+func (s *store) SetBucketEncryption(name string, encryption *v1beta1.BucketEncryption) error {
+	if algorithm := defaultEncryptionAlgorithm(encryption); algorithm != "" {
+		if err := s.client.SetBucketEncryption(name, algorithm); err != nil {
+			return errors.Wrapf(err, "while setting encryption of bucket %s", name)
+		}
+		return nil
+	}
+
+	if err := s.client.RemoveBucketEncryption(name); err != nil {
+		return errors.Wrapf(err, "while removing encryption of bucket %s", name)
+	}
+
+	return nil
+}
+
+// CompareBucketEncryption checks if the default encryption of the bucket matches the encryption
+func (s *store) CompareBucketEncryption(name string, encryption *v1beta1.BucketEncryption) (bool, error) {
+	current, err := s.client.GetBucketEncryption(name)
+	if err != nil {
+		return false, errors.Wrapf(err, "while getting encryption of bucket %s", name)
 	}
 
-	return reflect.DeepEqual(&expectedPolicy, currentPolicy), nil
+	return current == defaultEncryptionAlgorithm(encryption), nil
 }
 
 // Object
 
 func (s *store) ContainsAllObjects(ctx context.Context, bucketName, assetName string, files []string) (bool, error) {
-	objects, err := s.listObjects(ctx, bucketName, assetName)
+	objects, err := s.listObjects(ctx, bucketName, AssetPrefix(assetName))
 	if err != nil {
 		return false, err
 	}
@@ -175,9 +343,10 @@ func iterateSlice(files []string) chan string {
 
 type objectAttrs struct {
 	bucketName, assetName, sourceBasePath string
+	metadata                              ObjectMetadata
 }
 
-func (s *store) PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string) error {
+func (s *store) PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string, metadata ObjectMetadata) error {
 	fileNameChan := iterateSlice(files)
 	errChan := make(chan error)
 	go func() {
@@ -186,6 +355,7 @@ func (s *store) PutObjects(ctx context.Context, bucketName, assetName, sourceBas
 			bucketName:     bucketName,
 			assetName:      assetName,
 			sourceBasePath: sourceBasePath,
+			metadata:       metadata,
 		}
 		var waitGroup sync.WaitGroup
 		for i := 0; i < s.uploadWorkerCount; i++ {
@@ -223,7 +393,7 @@ func (s *store) putObject(ctx context.Context, attrs objectAttrs, fileNameChan c
 			bucketPath := filepath.Join(attrs.assetName, file)
 			sourcePath := filepath.Join(attrs.sourceBasePath, file)
 			_, err := s.client.FPutObjectWithContext(
-				ctx, attrs.bucketName, bucketPath, sourcePath, minio.PutObjectOptions{})
+				ctx, attrs.bucketName, bucketPath, sourcePath, attrs.metadata.Options(file))
 			if err != nil {
 				errChan <- err
 			}
@@ -231,6 +401,193 @@ func (s *store) putObject(ctx context.Context, attrs objectAttrs, fileNameChan c
 	}
 }
 
+func (s *store) PutObject(ctx context.Context, bucketName, assetName, fileName string, reader io.Reader, size int64, metadata ObjectMetadata) error {
+	objectName := filepath.Join(assetName, fileName)
+	_, err := s.client.PutObjectWithContext(ctx, bucketName, objectName, reader, size, metadata.Options(fileName))
+	if err != nil {
+		return errors.Wrapf(err, "while uploading object %s", objectName)
+	}
+
+	return nil
+}
+
+// SyncObjects uploads only the files which are missing in the bucket or differ from the objects of the asset,
+// and deletes the objects of the files which are gone afterwards, so the asset content is available all the time.
+// Objects with other headers than the ones resolved from the metadata are uploaded again.
+func (s *store) SyncObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string, metadata ObjectMetadata) (SyncResult, error) {
+	objects, err := s.listObjects(ctx, bucketName, AssetPrefix(assetName))
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	fileNameChan := iterateSlice(files)
+	errChan := make(chan error)
+	var mu sync.Mutex
+	var uploaded []string
+	go func() {
+		defer close(errChan)
+		var waitGroup sync.WaitGroup
+		for i := 0; i < s.uploadWorkerCount; i++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				for file := range fileNameChan {
+					if ctx.Err() != nil {
+						return
+					}
+					objectName := fmt.Sprintf("%s/%s", assetName, file)
+					object, exists := objects[objectName]
+					changed, err := s.syncObject(ctx, bucketName, objectName, filepath.Join(sourceBasePath, file), object, exists, metadata.Options(file), metadata.unchangedRules)
+					if err != nil {
+						errChan <- err
+						continue
+					}
+					if changed {
+						mu.Lock()
+						uploaded = append(uploaded, file)
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+		waitGroup.Wait()
+	}()
+
+	var errorMessages []string
+	for err := range errChan {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if len(errorMessages) > 0 {
+		return SyncResult{Uploaded: uploaded}, errors.New(strings.Join(errorMessages, "\n"))
+	}
+	if err := ctx.Err(); err != nil {
+		return SyncResult{Uploaded: uploaded}, err
+	}
+
+	deleted, err := s.deleteStaleObjects(ctx, bucketName, assetName, objects, files)
+	return SyncResult{Uploaded: uploaded, Deleted: deleted}, err
+}
+
+// syncObject uploads the file if it differs from the object, and returns true if it was uploaded
+func (s *store) syncObject(ctx context.Context, bucketName, objectName, sourcePath string, object minio.ObjectInfo, exists bool, options minio.PutObjectOptions, unchangedRules bool) (bool, error) {
+	sha256Sum, md5Sum, err := hashFile(sourcePath)
+	if err != nil {
+		return false, errors.Wrapf(err, "while computing digest of file %s", sourcePath)
+	}
+	if exists && s.objectUnchanged(bucketName, objectName, object, sha256Sum, md5Sum, options, unchangedRules) {
+		return false, nil
+	}
+
+	if options.UserMetadata == nil {
+		options.UserMetadata = make(map[string]string)
+	}
+	options.UserMetadata[sha256MetadataKey] = sha256Sum
+	if _, err := s.client.FPutObjectWithContext(ctx, bucketName, objectName, sourcePath, options); err != nil {
+		return false, errors.Wrapf(err, "while uploading object %s", objectName)
+	}
+
+	return true, nil
+}
+
+// objectUnchanged compares the file with the ETag, which is the MD5 sum of objects uploaded in a single part,
+// and with the SHA256 digest stored in the user metadata of other objects. The headers of the object are compared
+// with the options as well, as they are not listed with the objects. They are read only if the rules changed or the
+// object is encrypted, as the listing doesn't show the encryption either.
+func (s *store) objectUnchanged(bucketName, objectName string, object minio.ObjectInfo, sha256Sum, md5Sum string, options minio.PutObjectOptions, unchangedRules bool) bool {
+	md5Matches := strings.Trim(object.ETag, `"`) == md5Sum
+	if md5Matches && unchangedRules && options.ServerSideEncryption == nil {
+		return true
+	}
+
+	info, err := s.client.StatObject(bucketName, objectName, minio.StatObjectOptions{
+		GetObjectOptions: minio.GetObjectOptions{ServerSideEncryption: decryption(options.ServerSideEncryption)},
+	})
+	if err != nil {
+		return false
+	}
+	if !md5Matches && info.Metadata.Get(userMetadataHeaderPrefix+sha256MetadataKey) != sha256Sum {
+		return false
+	}
+
+	return headersMatch(info, options)
+}
+
+// CopyObject copies the object on the server side, replacing its headers with the ones resolved for the new name
+func (s *store) CopyObject(ctx context.Context, bucketName, assetName, sourceFileName, fileName string, metadata ObjectMetadata) error {
+	objectName := filepath.Join(assetName, fileName)
+	sse := metadata.Encryption()
+	destination, err := minio.NewDestinationInfo(bucketName, objectName, sse, headers(metadata.Options(fileName)))
+	if err != nil {
+		return errors.Wrapf(err, "while creating destination of object %s", objectName)
+	}
+	source := minio.NewSourceInfo(bucketName, filepath.Join(assetName, sourceFileName), decryption(sse))
+
+	if err := s.client.CopyObject(destination, source); err != nil {
+		return errors.Wrapf(err, "while copying object %s", objectName)
+	}
+
+	return nil
+}
+
+// CopyObjects copies the objects with the source prefix under the prefix on the server side. The source objects
+// have to be encrypted in the same way as the copies.
+func (s *store) CopyObjects(ctx context.Context, bucketName, sourcePrefix, prefix string, sse encrypt.ServerSide) error {
+	objects, err := s.listObjects(ctx, bucketName, sourcePrefix)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+
+	keyChan := iterateSlice(keys)
+	errChan := make(chan error)
+	go func() {
+		defer close(errChan)
+		var waitGroup sync.WaitGroup
+		for i := 0; i < s.uploadWorkerCount; i++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				for key := range keyChan {
+					if ctx.Err() != nil {
+						return
+					}
+					if err := s.copyObject(bucketName, key, prefix+strings.TrimPrefix(key, sourcePrefix), sse); err != nil {
+						errChan <- err
+					}
+				}
+			}()
+		}
+		waitGroup.Wait()
+	}()
+
+	var errorMessages []string
+	for err := range errChan {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "\n"))
+	}
+
+	return ctx.Err()
+}
+
+func (s *store) copyObject(bucketName, sourceObjectName, objectName string, sse encrypt.ServerSide) error {
+	destination, err := minio.NewDestinationInfo(bucketName, objectName, sse, nil)
+	if err != nil {
+		return errors.Wrapf(err, "while creating destination of object %s", objectName)
+	}
+
+	if err := s.client.CopyObject(destination, minio.NewSourceInfo(bucketName, sourceObjectName, decryption(sse))); err != nil {
+		return errors.Wrapf(err, "while copying object %s", objectName)
+	}
+
+	return nil
+}
+
 func (s *store) ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error) {
 	objects, err := s.listObjects(ctx, bucketName, prefix)
 	if err != nil {
@@ -245,35 +602,47 @@ func (s *store) ListObjects(ctx context.Context, bucketName, prefix string) ([]s
 	return result, nil
 }
 
+// DeleteObjects deletes the objects with the prefix. The prefix must be empty or end with the delimiter.
 func (s *store) DeleteObjects(ctx context.Context, bucketName, prefix string) error {
+	if err := checkDeletePrefix(prefix); err != nil {
+		return err
+	}
+
 	objects, err := s.listObjects(ctx, bucketName, prefix)
 	if err != nil {
 		return err
 	}
-	if len(objects) == 0 {
-		return nil
+
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
 	}
 
-	objectsCh := make(chan string)
-	go func(objects map[string]minio.ObjectInfo) {
-		defer close(objectsCh)
+	return s.removeObjects(ctx, bucketName, keys)
+}
 
-		for key := range objects {
-			objectsCh <- key
-		}
-	}(objects)
+// DeleteStaleObjects deletes the objects of the asset which do not belong to any of the files, and returns their names
+func (s *store) DeleteStaleObjects(ctx context.Context, bucketName, assetName string, files []string) ([]string, error) {
+	objects, err := s.listObjects(ctx, bucketName, AssetPrefix(assetName))
+	if err != nil {
+		return nil, err
+	}
 
-	errs := make([]error, 0)
-	for err := range s.client.RemoveObjectsWithContext(ctx, bucketName, objectsCh) {
-		errs = append(errs, err.Err)
+	return s.deleteStaleObjects(ctx, bucketName, assetName, objects, files)
+}
+
+func (s *store) deleteStaleObjects(ctx context.Context, bucketName, assetName string, objects map[string]minio.ObjectInfo, files []string) ([]string, error) {
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
 	}
 
-	if len(errs) > 0 {
-		messages := s.extractErrorMessages(errs)
-		return fmt.Errorf("cannot delete objects from bucket: %+v", messages)
+	stale := staleObjects(assetName, keys, files)
+	if err := s.removeObjects(ctx, bucketName, stale); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return stale, nil
 }
 
 // Helpers
@@ -295,6 +664,33 @@ func (s *store) getBucketPolicy(name string) (*policy.BucketAccessPolicy, error)
 	return result, nil
 }
 
+func (s *store) removeObjects(ctx context.Context, bucketName string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	objectsCh := make(chan string)
+	go func(keys []string) {
+		defer close(objectsCh)
+
+		for _, key := range keys {
+			objectsCh <- key
+		}
+	}(keys)
+
+	errs := make([]error, 0)
+	for err := range s.client.RemoveObjectsWithContext(ctx, bucketName, objectsCh) {
+		errs = append(errs, err.Err)
+	}
+
+	if len(errs) > 0 {
+		messages := s.extractErrorMessages(errs)
+		return fmt.Errorf("cannot delete objects from bucket: %+v", messages)
+	}
+
+	return nil
+}
+
 func (*store) extractErrorMessages(errs []error) []string {
 	messages := make([]string, 0, len(errs))
 	for _, err := range errs {
@@ -323,11 +719,50 @@ func (s *store) listObjects(ctx context.Context, bucketName, prefix string) (map
 	return result, nil
 }
 
-func (s *store) findBucketName(name string) (string, error) {
+// staleObjects returns the objects of the asset which do not belong to any of the files
+func staleObjects(assetName string, objects, files []string) []string {
+	current := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		current[fmt.Sprintf("%s/%s", assetName, file)] = struct{}{}
+	}
+
+	var stale []string
+	for _, object := range objects {
+		if _, ok := current[object]; !ok {
+			stale = append(stale, object)
+		}
+	}
+
+	return stale
+}
+
+// hashFile returns the hex encoded SHA256 and MD5 sums of the file
+func hashFile(path string) (string, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+
+	sha256Hash, md5Hash := sha256.New(), md5.New()
+	if _, err := io.Copy(io.MultiWriter(sha256Hash, md5Hash), file); err != nil {
+		return "", "", err
+	}
+
+	return hex.EncodeToString(sha256Hash.Sum(nil)), hex.EncodeToString(md5Hash.Sum(nil)), nil
+}
+
+// ValidateBucketName makes sure that the name of the existing bucket follows the S3 bucket naming rules
+func ValidateBucketName(name string) error {
+	return s3utils.CheckValidBucketNameStrict(name)
+}
+
+// findBucketName returns a free bucket name made of the resource name and a unique suffix
+func findBucketName(name string, bucketExists func(name string) (bool, error)) (string, error) {
 	sleep := time.Millisecond
 	for i := 0; i < 10; i++ {
-		name := s.generateBucketName(name)
-		exists, err := s.BucketExists(name)
+		name := generateBucketName(name)
+		exists, err := bucketExists(name)
 		if err != nil {
 			return "", errors.Wrap(err, "while checking if bucket name is available")
 		}
@@ -341,32 +776,13 @@ func (s *store) findBucketName(name string) (string, error) {
 	return "", errors.New("cannot find bucket name")
 }
 
-func (s *store) generateBucketName(name string) string {
+func generateBucketName(name string) string {
 	unixNano := time.Now().UnixNano()
 	suffix := strconv.FormatInt(unixNano, 32)
 
 	return fmt.Sprintf("%s-%s", name, suffix)
 }
 
-func (s *store) prepareBucketPolicy(bucketName string, bucketPolicy v1beta1.BucketPolicy) policy.BucketAccessPolicy {
-	statements := make([]policy.Statement, 0)
-	switch {
-	case bucketPolicy == v1beta1.BucketPolicyReadOnly:
-		statements = policy.SetPolicy(statements, policy.BucketPolicyReadOnly, bucketName, "")
-	case bucketPolicy == v1beta1.BucketPolicyWriteOnly:
-		statements = policy.SetPolicy(statements, policy.BucketPolicyWriteOnly, bucketName, "")
-	case bucketPolicy == v1beta1.BucketPolicyReadWrite:
-		statements = policy.SetPolicy(statements, policy.BucketPolicyReadWrite, bucketName, "")
-	default:
-		statements = policy.SetPolicy(statements, policy.BucketPolicyNone, bucketName, "")
-	}
-
-	return policy.BucketAccessPolicy{
-		Version:    "2012-10-17", // Fixed version
-		Statements: statements,
-	}
-}
-
 func (s *store) marshalBucketPolicy(policy policy.BucketAccessPolicy) (string, error) {
 	bytes, err := json.Marshal(&policy)
 	if err != nil {
@@ -385,26 +801,3 @@ func (s *store) unmarshalBucketPolicy(marshaledPolicy string) (*policy.BucketAcc
 
 	return bucketPolicy, nil
 }
-
-func (s *store) compareMergedPolicy(expected policy.BucketAccessPolicy, current *policy.BucketAccessPolicy) bool {
-	if current == nil {
-		return false
-	}
-
-	merged := policy.BucketAccessPolicy{
-		Version:    expected.Version,
-		Statements: []policy.Statement{expected.Statements[0]},
-	}
-
-	for _, statement := range expected.Statements[1:] {
-		for action := range statement.Actions {
-			merged.Statements[0].Actions.Add(action)
-		}
-
-		for resource := range statement.Resources {
-			merged.Statements[0].Resources.Add(resource)
-		}
-	}
-
-	return reflect.DeepEqual(&merged, current)
-}
@@ -2,8 +2,15 @@ package store_test
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
 	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kyma-project/rafter/internal/store"
@@ -74,6 +81,91 @@ func TestStore_BucketExists(t *testing.T) {
 	})
 }
 
+func TestStore_CompareBucketLifecycle(t *testing.T) {
+	expirationDays := int64(7)
+	rules := []v1beta1.BucketLifecycleRule{{ID: "expire", Prefix: "tmp/", ExpirationDays: &expirationDays}, {ID: "uploads", AbortIncompleteUploadDays: &expirationDays}}
+
+	for testName, testCase := range map[string]struct {
+		rules     []v1beta1.BucketLifecycleRule
+		lifecycle string
+		err       error
+		equal     bool
+		failed    bool
+	}{
+		"Equal": {
+			rules:     rules,
+			lifecycle: `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ID>uploads</ID><Status>Enabled</Status><Filter><Prefix></Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule><Rule><ID>expire</ID><Status>Enabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>7</Days></Expiration></Rule></LifecycleConfiguration>`,
+			equal:     true,
+		},
+		"LegacyPrefix": {
+			rules:     rules[:1],
+			lifecycle: `<LifecycleConfiguration><Rule><ID>expire</ID><Prefix>tmp/</Prefix><Status>Enabled</Status><Expiration><Days>7</Days></Expiration></Rule></LifecycleConfiguration>`,
+			equal:     true,
+		},
+		"Changed": {
+			rules:     rules[:1],
+			lifecycle: `<LifecycleConfiguration><Rule><ID>expire</ID><Status>Enabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`,
+			equal:     false,
+		},
+		"Disabled": {
+			rules:     rules[:1],
+			lifecycle: `<LifecycleConfiguration><Rule><ID>expire</ID><Status>Disabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>7</Days></Expiration></Rule></LifecycleConfiguration>`,
+			equal:     false,
+		},
+		"Removed": {
+			rules:     rules,
+			lifecycle: "",
+			equal:     false,
+		},
+		"Added": {
+			lifecycle: `<LifecycleConfiguration><Rule><ID>expire</ID><Status>Enabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>7</Days></Expiration></Rule></LifecycleConfiguration>`,
+			equal:     false,
+		},
+		"NotImplementedWithoutRules": {
+			err:   minio.ErrorResponse{Code: "NotImplemented"},
+			equal: true,
+		},
+		"NotImplemented": {
+			rules:  rules,
+			err:    minio.ErrorResponse{Code: "NotImplemented"},
+			failed: true,
+		},
+		"InvalidLifecycle": {
+			rules:     rules,
+			lifecycle: "<LifecycleConfiguration>",
+			failed:    true,
+		},
+		"Error": {
+			rules:  rules,
+			err:    errors.New("test-error"),
+			failed: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			bucketName := "test-bucket"
+
+			minio := new(automock.MinioClient)
+			minio.On("GetBucketLifecycle", bucketName).Return(testCase.lifecycle, testCase.err).Once()
+			defer minio.AssertExpectations(t)
+
+			store := store.New(minio, 1)
+
+			// When
+			equal, err := store.CompareBucketLifecycle(bucketName, testCase.rules)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(equal).To(gomega.Equal(testCase.equal))
+		})
+	}
+}
+
 func TestStore_CompareBucketPolicy(t *testing.T) {
 	t.Run("SuccessNone", func(t *testing.T) {
 		// Given
@@ -89,7 +181,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
+		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -110,7 +202,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
+		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -131,7 +223,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
+		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -152,7 +244,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
+		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -173,13 +265,60 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
+		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
 		g.Expect(equal).To(gomega.Equal(true))
 	})
 
+	t.Run("SuccessAccessPolicy", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		accessPolicy := &v1beta1.BucketAccessPolicy{
+			Rules:      []v1beta1.BucketPolicyRule{{Prefix: "assets/", Policy: v1beta1.BucketPolicyReadOnly}},
+			Statements: []v1beta1.BucketPolicyStatement{{Sid: "Locked", Effect: v1beta1.BucketPolicyEffectDeny, Actions: []string{"s3:PutObject"}, Resources: []string{"assets/locked/*"}}},
+		}
+		remotePolicy := "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Action\":[\"s3:GetBucketLocation\",\"s3:GetObject\"],\"Effect\":\"Allow\",\"Principal\":\"*\",\"Resource\":[\"arn:aws:s3:::test-bucket\",\"arn:aws:s3:::test-bucket/assets/*\"]},{\"Action\":\"s3:ListBucket\",\"Condition\":{\"StringEquals\":{\"s3:prefix\":\"assets/\"}},\"Effect\":\"Allow\",\"Principal\":{\"AWS\":\"*\"},\"Resource\":\"arn:aws:s3:::test-bucket\"},{\"Action\":[\"s3:PutObject\"],\"Effect\":\"Deny\",\"Principal\":{\"AWS\":[\"*\"]},\"Resource\":[\"arn:aws:s3:::test-bucket/assets/locked/*\"]}]}"
+
+		minio := new(automock.MinioClient)
+		minio.On("GetBucketPolicy", bucketName).Return(remotePolicy, nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		equal, err := store.CompareBucketPolicy(bucketName, v1beta1.BucketPolicyNone, accessPolicy)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(equal).To(gomega.Equal(true))
+	})
+
+	t.Run("ChangedAccessPolicy", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		accessPolicy := &v1beta1.BucketAccessPolicy{
+			Rules: []v1beta1.BucketPolicyRule{{Prefix: "assets/", Policy: v1beta1.BucketPolicyReadOnly}},
+		}
+		remotePolicy := "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Action\":[\"s3:GetBucketLocation\",\"s3:GetObject\"],\"Effect\":\"Allow\",\"Principal\":\"*\",\"Resource\":[\"arn:aws:s3:::test-bucket\",\"arn:aws:s3:::test-bucket/*\"]},{\"Action\":\"s3:ListBucket\",\"Condition\":{\"StringEquals\":{\"s3:prefix\":\"assets/\"}},\"Effect\":\"Allow\",\"Principal\":{\"AWS\":\"*\"},\"Resource\":\"arn:aws:s3:::test-bucket\"}]}"
+
+		minio := new(automock.MinioClient)
+		minio.On("GetBucketPolicy", bucketName).Return(remotePolicy, nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		equal, err := store.CompareBucketPolicy(bucketName, v1beta1.BucketPolicyNone, accessPolicy)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(equal).To(gomega.Equal(false))
+	})
+
 	t.Run("EmptyRemotePolicy", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
@@ -193,7 +332,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
+		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -213,7 +352,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		_, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
+		_, err := store.CompareBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -231,7 +370,7 @@ func TestStore_ContainsAllObjects(t *testing.T) {
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, assetName+"/", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
 		store := store.New(minio, 1)
@@ -254,7 +393,7 @@ func TestStore_ContainsAllObjects(t *testing.T) {
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, assetName+"/", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
 		store := store.New(minio, 1)
@@ -277,7 +416,7 @@ func TestStore_ContainsAllObjects(t *testing.T) {
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, assetName+"/", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
 		store := store.New(minio, 1)
@@ -300,7 +439,7 @@ func TestStore_ContainsAllObjects(t *testing.T) {
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, assetName+"/", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
 		store := store.New(minio, 1)
@@ -323,7 +462,7 @@ func TestStore_ContainsAllObjects(t *testing.T) {
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, assetName+"/", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
 		store := store.New(minio, 1)
@@ -469,6 +608,76 @@ func TestStore_CreateBucket(t *testing.T) {
 	})
 }
 
+func TestStore_CreateNamedBucket(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "restored-bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		minio.On("MakeBucket", bucketName, region).Return(nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.CreateNamedBucket(bucketName, region)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "restored-bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		minio.On("MakeBucket", bucketName, region).Return(errors.New("test-error")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.CreateNamedBucket(bucketName, region)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestValidateBucketName(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		name   string
+		failed bool
+	}{
+		"Valid":         {name: "restored-bucket-1"},
+		"TooShort":      {name: "ab", failed: true},
+		"UpperCase":     {name: "Restored-Bucket", failed: true},
+		"Underscore":    {name: "restored_bucket", failed: true},
+		"IPAddress":     {name: "192.168.1.1", failed: true},
+		"TrailingDash":  {name: "restored-", failed: true},
+		"SuccessiveDot": {name: "restored..bucket", failed: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			err := store.ValidateBucketName(testCase.name)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	}
+}
+
 func TestStore_DeleteBucket(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		// Given
@@ -627,7 +836,7 @@ func TestStore_DeleteObjects(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
 		name := "test-bucket"
-		prefix := "test"
+		prefix := "test/"
 		ctx := context.TODO()
 		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test/obj1"}, minio.ObjectInfo{Key: "test/obj2"}, minio.ObjectInfo{Key: "test/obj3"})
 		errCh := fixRemoveObjectErrorChannel()
@@ -707,6 +916,24 @@ func TestStore_DeleteObjects(t *testing.T) {
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
 	})
+
+	t.Run("PrefixWithoutDelimiter", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		name := "test-bucket"
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.DeleteObjects(ctx, name, "api")
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
 }
 
 func TestStore_PutObjects(t *testing.T) {
@@ -724,10 +951,11 @@ func TestStore_PutObjects(t *testing.T) {
 		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[1]), filepath.Join(sourceBasePath, files[1]), mock.Anything).Return(int64(1), nil).Once()
 		defer minio.AssertExpectations(t)
 
+		metadata := store.ObjectMetadata{}
 		store := store.New(minio, 2)
 
 		// When
-		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files)
+		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, metadata)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -747,10 +975,11 @@ func TestStore_PutObjects(t *testing.T) {
 		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[1]), filepath.Join(sourceBasePath, files[1]), mock.Anything).Return(int64(1), errors.New("test-error")).Once()
 		defer minio.AssertExpectations(t)
 
+		metadata := store.ObjectMetadata{}
 		store := store.New(minio, 1)
 
 		// When
-		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files)
+		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, metadata)
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -768,16 +997,606 @@ func TestStore_PutObjects(t *testing.T) {
 		minio := new(automock.MinioClient)
 		defer minio.AssertExpectations(t)
 
+		metadata := store.ObjectMetadata{}
 		store := store.New(minio, 1)
 
 		// When
-		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files)
+		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, metadata)
 
 		// Then
 		g.Expect(err).ToNot(gomega.HaveOccurred())
 	})
 }
 
+func TestStore_PutObject(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		reader := strings.NewReader("test")
+		ctx := context.TODO()
+
+		metadata, err := store.NewObjectMetadata([]v1beta1.AssetObjectMetadata{{Pattern: "*.txt", CacheControl: "no-cache"}})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		options := minio.PutObjectOptions{ContentType: mime.TypeByExtension(".txt"), CacheControl: "no-cache"}
+
+		minio := new(automock.MinioClient)
+		minio.On("PutObjectWithContext", ctx, bucketName, "test-asset/test/a.txt", reader, int64(4), options).Return(int64(4), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err = store.PutObject(ctx, bucketName, assetName, "test/a.txt", reader, 4, metadata)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		reader := strings.NewReader("test")
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("PutObjectWithContext", ctx, bucketName, "test-asset/test/a.txt", reader, int64(4), mock.Anything).Return(int64(0), errors.New("test-error")).Once()
+		defer minio.AssertExpectations(t)
+
+		metadata := store.ObjectMetadata{}
+		store := store.New(minio, 1)
+
+		// When
+		err := store.PutObject(ctx, bucketName, assetName, "test/a.txt", reader, 4, metadata)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_SyncObjects(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		sourceBasePath := fixSourceDirectory(t, map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c", "d.txt": "d"})
+		defer os.RemoveAll(sourceBasePath)
+		files := []string{"a.txt", "b.txt", "c.txt", "d.txt"}
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(
+			minio.ObjectInfo{Key: "test-asset/a.txt", ETag: fmt.Sprintf(`"%x"`, md5.Sum([]byte("a")))},
+			minio.ObjectInfo{Key: "test-asset/b.txt", ETag: `"multipart-2"`},
+			minio.ObjectInfo{Key: "test-asset/c.txt", ETag: `"multipart-2"`},
+			minio.ObjectInfo{Key: "test-asset/removed.txt"},
+		)
+		errCh := fixRemoveObjectErrorChannel()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/a.txt", mock.Anything).Return(fixObjectInfo("a"), nil).Once()
+		minio.On("StatObject", bucketName, "test-asset/b.txt", mock.Anything).Return(fixObjectInfo("b"), nil).Once()
+		minio.On("StatObject", bucketName, "test-asset/c.txt", mock.Anything).Return(fixObjectInfo("old"), nil).Once()
+		minio.On("FPutObjectWithContext", ctx, bucketName, "test-asset/c.txt", filepath.Join(sourceBasePath, "c.txt"), fixPutObjectOptions("c")).Return(int64(1), nil).Once()
+		minio.On("FPutObjectWithContext", ctx, bucketName, "test-asset/d.txt", filepath.Join(sourceBasePath, "d.txt"), fixPutObjectOptions("d")).Return(int64(1), nil).Once()
+		minio.On("RemoveObjectsWithContext", ctx, bucketName, mock.Anything).Return(errCh).Once()
+		defer minio.AssertExpectations(t)
+
+		metadata := store.ObjectMetadata{}
+		store := store.New(minio, 2)
+
+		// When
+		result, err := store.SyncObjects(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Uploaded).To(gomega.ConsistOf("c.txt", "d.txt"))
+		g.Expect(result.Deleted).To(gomega.ConsistOf("test-asset/removed.txt"))
+	})
+
+	t.Run("ChangedMetadata", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		sourceBasePath := fixSourceDirectory(t, map[string]string{"a.txt": "a"})
+		defer os.RemoveAll(sourceBasePath)
+		files := []string{"a.txt"}
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/a.txt", ETag: fmt.Sprintf(`"%x"`, md5.Sum([]byte("a")))})
+		metadata, err := store.NewObjectMetadata([]v1beta1.AssetObjectMetadata{{CacheControl: "max-age=60"}})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		options := fixPutObjectOptions("a")
+		options.CacheControl = "max-age=60"
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/a.txt", mock.Anything).Return(fixObjectInfo("a"), nil).Once()
+		minio.On("FPutObjectWithContext", ctx, bucketName, "test-asset/a.txt", filepath.Join(sourceBasePath, "a.txt"), options).Return(int64(1), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		result, err := store.SyncObjects(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Uploaded).To(gomega.ConsistOf("a.txt"))
+	})
+
+	t.Run("UnchangedRules", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		sourceBasePath := fixSourceDirectory(t, map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"})
+		defer os.RemoveAll(sourceBasePath)
+		files := []string{"a.txt", "b.txt", "c.txt"}
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(
+			minio.ObjectInfo{Key: "test-asset/a.txt", ETag: fmt.Sprintf(`"%x"`, md5.Sum([]byte("a")))},
+			minio.ObjectInfo{Key: "test-asset/b.txt", ETag: `"multipart-2"`},
+			minio.ObjectInfo{Key: "test-asset/c.txt", ETag: fmt.Sprintf(`"%x"`, md5.Sum([]byte("old")))},
+		)
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/b.txt", mock.Anything).Return(fixObjectInfo("b"), nil).Once()
+		minio.On("StatObject", bucketName, "test-asset/c.txt", mock.Anything).Return(fixObjectInfo("old"), nil).Once()
+		minio.On("FPutObjectWithContext", ctx, bucketName, "test-asset/c.txt", filepath.Join(sourceBasePath, "c.txt"), fixPutObjectOptions("c")).Return(int64(1), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		metadata := store.ObjectMetadata{}.WithUnchangedRules()
+		store := store.New(minio, 1)
+
+		// When
+		result, err := store.SyncObjects(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Uploaded).To(gomega.ConsistOf("c.txt"))
+	})
+
+	t.Run("Encryption", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		sourceBasePath := fixSourceDirectory(t, map[string]string{"a.txt": "a", "b.txt": "b"})
+		defer os.RemoveAll(sourceBasePath)
+		files := []string{"a.txt", "b.txt"}
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(
+			minio.ObjectInfo{Key: "test-asset/a.txt", ETag: `"multipart-2"`},
+			minio.ObjectInfo{Key: "test-asset/b.txt", ETag: `"multipart-2"`},
+		)
+		sse, err := store.NewServerSideEncryption(v1beta1.BucketEncryptionSSEC, []byte(strings.Repeat("k", 32)))
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		metadata := store.ObjectMetadata{}.WithEncryption(sse)
+		encrypted := fixObjectInfo("a")
+		encrypted.Metadata.Set("X-Amz-Server-Side-Encryption-Customer-Algorithm", "AES256")
+		options := fixPutObjectOptions("b")
+		options.ServerSideEncryption = sse
+		statOptions := minio.StatObjectOptions{GetObjectOptions: minio.GetObjectOptions{ServerSideEncryption: sse}}
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/a.txt", statOptions).Return(encrypted, nil).Once()
+		minio.On("StatObject", bucketName, "test-asset/b.txt", statOptions).Return(fixObjectInfo("b"), nil).Once()
+		minio.On("FPutObjectWithContext", ctx, bucketName, "test-asset/b.txt", filepath.Join(sourceBasePath, "b.txt"), options).Return(int64(1), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		result, err := store.SyncObjects(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Uploaded).To(gomega.ConsistOf("b.txt"))
+	})
+
+	t.Run("UploadError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		sourceBasePath := fixSourceDirectory(t, map[string]string{"a.txt": "a"})
+		defer os.RemoveAll(sourceBasePath)
+		files := []string{"a.txt"}
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/removed.txt"})
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("FPutObjectWithContext", ctx, bucketName, "test-asset/a.txt", filepath.Join(sourceBasePath, "a.txt"), mock.Anything).Return(int64(0), errors.New("test-error")).Once()
+		defer minio.AssertExpectations(t)
+
+		metadata := store.ObjectMetadata{}
+		store := store.New(minio, 1)
+
+		// When
+		_, err := store.SyncObjects(ctx, bucketName, assetName, sourceBasePath, files, metadata)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		ctx := context.TODO()
+		objCh := fixObjectsChannel()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		defer minio.AssertExpectations(t)
+
+		metadata := store.ObjectMetadata{}
+		store := store.New(minio, 1)
+
+		// When
+		_, err := store.SyncObjects(ctx, bucketName, "test-asset", "/tmp/not-existing", []string{"a.txt"}, metadata)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_DeleteStaleObjects(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/a.txt"}, minio.ObjectInfo{Key: "test-asset/b.txt"})
+		errCh := fixRemoveObjectErrorChannel()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("RemoveObjectsWithContext", ctx, bucketName, mock.Anything).Return(errCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		deleted, err := store.DeleteStaleObjects(ctx, bucketName, "test-asset", []string{"a.txt"})
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(deleted).To(gomega.ConsistOf("test-asset/b.txt"))
+	})
+
+	t.Run("NothingStale", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/a.txt"})
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		deleted, err := store.DeleteStaleObjects(ctx, bucketName, "test-asset", []string{"a.txt"})
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(deleted).To(gomega.BeEmpty())
+	})
+
+	t.Run("RemoveObjectsError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/b.txt"})
+		errCh := fixRemoveObjectErrorChannel(errors.New("test-error"))
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("RemoveObjectsWithContext", ctx, bucketName, mock.Anything).Return(errCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		_, err := store.DeleteStaleObjects(ctx, bucketName, "test-asset", []string{"a.txt"})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_CopyObject(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("CopyObject", mock.Anything, mock.Anything).Return(nil).Once()
+		defer minio.AssertExpectations(t)
+
+		metadata := store.ObjectMetadata{}
+		store := store.New(minio, 1)
+
+		// When
+		err := store.CopyObject(ctx, bucketName, assetName, "docs/guide.md", "docs/index.md", metadata)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("CopyObject", mock.Anything, mock.Anything).Return(errors.New("test-error")).Once()
+		defer minio.AssertExpectations(t)
+
+		metadata := store.ObjectMetadata{}
+		store := store.New(minio, 1)
+
+		// When
+		err := store.CopyObject(ctx, bucketName, assetName, "docs/guide.md", "docs/index.md", metadata)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_CopyObjects(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/.v/1/a.txt"}, minio.ObjectInfo{Key: "test-asset/.v/1/docs/b.txt"})
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/.v/1/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("CopyObject", mock.Anything, mock.Anything).Return(nil).Twice()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 2)
+
+		// When
+		err := store.CopyObjects(ctx, bucketName, "test-asset/.v/1/", "test-asset/.v/2/", nil)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		ctx := context.TODO()
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/.v/1/a.txt"})
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/.v/1/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("CopyObject", mock.Anything, mock.Anything).Return(errors.New("test-error")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.CopyObjects(ctx, bucketName, "test-asset/.v/1/", "test-asset/.v/2/", nil)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_SetBucketLifecycle(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		expirationDays := int64(7)
+		rules := []v1beta1.BucketLifecycleRule{{Prefix: "tmp/", ExpirationDays: &expirationDays}}
+		lifecycle := "<LifecycleConfiguration><Rule><ID>rule-1</ID><Status>Enabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>7</Days></Expiration></Rule></LifecycleConfiguration>"
+
+		minio := new(automock.MinioClient)
+		minio.On("SetBucketLifecycle", bucketName, lifecycle).Return(nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.SetBucketLifecycle(bucketName, rules)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("NoRules", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+
+		minio := new(automock.MinioClient)
+		minio.On("SetBucketLifecycle", bucketName, "").Return(nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.SetBucketLifecycle(bucketName, nil)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("InvalidRules", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+
+		minio := new(automock.MinioClient)
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.SetBucketLifecycle(bucketName, []v1beta1.BucketLifecycleRule{{Prefix: "tmp/"}})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+
+		minio := new(automock.MinioClient)
+		minio.On("SetBucketLifecycle", bucketName, "").Return(errors.New("test-error")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.SetBucketLifecycle(bucketName, nil)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_SetBucketEncryption(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		encryption *v1beta1.BucketEncryption
+		removed    bool
+		err        error
+	}{
+		"SSE-S3": {
+			encryption: &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3},
+		},
+		"SSE-C": {
+			encryption: &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSEC, SecretRef: &v1beta1.BucketEncryptionSecretRef{Name: "key"}},
+			removed:    true,
+		},
+		"None": {
+			removed: true,
+		},
+		"SetError": {
+			encryption: &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3},
+			err:        errors.New("test-error"),
+		},
+		"RemoveError": {
+			removed: true,
+			err:     errors.New("test-error"),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			bucketName := "test-bucket"
+
+			minio := new(automock.MinioClient)
+			if testCase.removed {
+				minio.On("RemoveBucketEncryption", bucketName).Return(testCase.err).Once()
+			} else {
+				minio.On("SetBucketEncryption", bucketName, "AES256").Return(testCase.err).Once()
+			}
+			defer minio.AssertExpectations(t)
+
+			store := store.New(minio, 1)
+
+			// When
+			err := store.SetBucketEncryption(bucketName, testCase.encryption)
+
+			// Then
+			if testCase.err != nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	}
+}
+
+func TestStore_CompareBucketEncryption(t *testing.T) {
+	sses3 := &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSES3}
+	ssec := &v1beta1.BucketEncryption{Type: v1beta1.BucketEncryptionSSEC, SecretRef: &v1beta1.BucketEncryptionSecretRef{Name: "key"}}
+
+	for testName, testCase := range map[string]struct {
+		encryption *v1beta1.BucketEncryption
+		algorithm  string
+		err        error
+		equal      bool
+		failed     bool
+	}{
+		"Equal": {
+			encryption: sses3,
+			algorithm:  "AES256",
+			equal:      true,
+		},
+		"Removed": {
+			encryption: sses3,
+			equal:      false,
+		},
+		"Added": {
+			algorithm: "AES256",
+			equal:     false,
+		},
+		"CustomerKey": {
+			encryption: ssec,
+			equal:      true,
+		},
+		"CustomerKeyWithDefault": {
+			encryption: ssec,
+			algorithm:  "AES256",
+			equal:      false,
+		},
+		"Error": {
+			encryption: sses3,
+			err:        errors.New("test-error"),
+			failed:     true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			bucketName := "test-bucket"
+
+			minio := new(automock.MinioClient)
+			minio.On("GetBucketEncryption", bucketName).Return(testCase.algorithm, testCase.err).Once()
+			defer minio.AssertExpectations(t)
+
+			store := store.New(minio, 1)
+
+			// When
+			equal, err := store.CompareBucketEncryption(bucketName, testCase.encryption)
+
+			// Then
+			if testCase.failed {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(equal).To(gomega.Equal(testCase.equal))
+		})
+	}
+}
+
 func TestStore_SetBucketPolicy(t *testing.T) {
 	t.Run("SuccessNone", func(t *testing.T) {
 		// Given
@@ -793,7 +1612,7 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		err := store.SetBucketPolicy(bucketName, policy)
+		err := store.SetBucketPolicy(bucketName, policy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -813,7 +1632,7 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		err := store.SetBucketPolicy(bucketName, expectedPolicy)
+		err := store.SetBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -833,7 +1652,7 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		err := store.SetBucketPolicy(bucketName, expectedPolicy)
+		err := store.SetBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
@@ -853,12 +1672,76 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		err := store.SetBucketPolicy(bucketName, expectedPolicy)
+		err := store.SetBucketPolicy(bucketName, expectedPolicy, nil)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("SuccessRules", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		accessPolicy := &v1beta1.BucketAccessPolicy{
+			Rules: []v1beta1.BucketPolicyRule{{Prefix: "assets/", Policy: v1beta1.BucketPolicyReadOnly}},
+		}
+		marshaledPolicy := "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Action\":[\"s3:GetBucketLocation\"],\"Effect\":\"Allow\",\"Principal\":{\"AWS\":[\"*\"]},\"Resource\":[\"arn:aws:s3:::test-bucket\"],\"Sid\":\"\"},{\"Action\":[\"s3:ListBucket\"],\"Condition\":{\"StringEquals\":{\"s3:prefix\":[\"assets/\"]}},\"Effect\":\"Allow\",\"Principal\":{\"AWS\":[\"*\"]},\"Resource\":[\"arn:aws:s3:::test-bucket\"],\"Sid\":\"\"},{\"Action\":[\"s3:GetObject\"],\"Effect\":\"Allow\",\"Principal\":{\"AWS\":[\"*\"]},\"Resource\":[\"arn:aws:s3:::test-bucket/assets/*\"],\"Sid\":\"\"}]}"
+
+		minio := new(automock.MinioClient)
+		minio.On("SetBucketPolicy", bucketName, marshaledPolicy).Return(nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.SetBucketPolicy(bucketName, v1beta1.BucketPolicyNone, accessPolicy)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("SuccessRaw", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		accessPolicy := &v1beta1.BucketAccessPolicy{
+			Raw: `{"Statement":[{"Action":"s3:GetObject","Effect":"Allow","Principal":"*","Resource":"arn:aws:s3:::${bucket}/public/*"}]}`,
+		}
+		marshaledPolicy := "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Action\":[\"s3:GetObject\"],\"Effect\":\"Allow\",\"Principal\":{\"AWS\":[\"*\"]},\"Resource\":[\"arn:aws:s3:::test-bucket/public/*\"],\"Sid\":\"\"}]}"
+
+		minio := new(automock.MinioClient)
+		minio.On("SetBucketPolicy", bucketName, marshaledPolicy).Return(nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.SetBucketPolicy(bucketName, "", accessPolicy)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	t.Run("InvalidAccessPolicy", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		accessPolicy := &v1beta1.BucketAccessPolicy{
+			Raw: `{"Statement":[{"NotAction":"s3:GetObject","Effect":"Allow","Principal":"*","Resource":"arn:aws:s3:::${bucket}/*"}]}`,
+		}
+
+		minio := new(automock.MinioClient)
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1)
+
+		// When
+		err := store.SetBucketPolicy(bucketName, "", accessPolicy)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
@@ -873,13 +1756,44 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		store := store.New(minio, 1)
 
 		// When
-		err := store.SetBucketPolicy(bucketName, expectedPolicy)
+		err := store.SetBucketPolicy(bucketName, expectedPolicy, nil)
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
 	})
 }
 
+func TestAssetPrefix(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		assetName string
+		prefix    string
+	}{
+		"Asset": {
+			assetName: "api",
+			prefix:    "api/",
+		},
+		"WithDelimiter": {
+			assetName: "api/",
+			prefix:    "api/",
+		},
+		"Version": {
+			assetName: "api/.v/1",
+			prefix:    "api/.v/1/",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			prefix := store.AssetPrefix(testCase.assetName)
+
+			// Then
+			g.Expect(prefix).To(gomega.Equal(testCase.prefix))
+		})
+	}
+}
+
 func fixObjectsChannel(objects ...minio.ObjectInfo) <-chan minio.ObjectInfo {
 	objCh := make(chan minio.ObjectInfo, len(objects)+1)
 	defer close(objCh)
@@ -903,3 +1817,33 @@ func fixRemoveObjectErrorChannel(errs ...error) <-chan minio.RemoveObjectError {
 
 	return objCh
 }
+
+func fixSourceDirectory(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func fixObjectInfo(content string) minio.ObjectInfo {
+	return minio.ObjectInfo{
+		ContentType: mime.TypeByExtension(".txt"),
+		Metadata: http.Header{
+			"X-Amz-Meta-Sha256": []string{fmt.Sprintf("%x", sha256.Sum256([]byte(content)))},
+		},
+	}
+}
+
+func fixPutObjectOptions(content string) minio.PutObjectOptions {
+	return minio.PutObjectOptions{
+		ContentType:  mime.TypeByExtension(".txt"),
+		UserMetadata: map[string]string{"sha256": fmt.Sprintf("%x", sha256.Sum256([]byte(content)))},
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/kyma-project/rafter/internal/fileheader"
+	"github.com/kyma-project/rafter/internal/store"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
@@ -27,6 +28,7 @@ type FileUpload struct {
 	Bucket    string
 	File      fileheader.FileHeader
 	Directory string
+	Metadata  store.ObjectMetadata
 }
 
 type UploadResult struct {
@@ -160,7 +162,7 @@ func (u *Uploader) uploadFile(ctx context.Context, fileUpload FileUpload) (*Uplo
 
 	glog.Infof("Uploading `%s` into bucket `%s`...\n", objectName, fileUpload.Bucket)
 
-	_, err = u.client.PutObjectWithContext(ctx, fileUpload.Bucket, objectName, f, fileSize, minio.PutObjectOptions{})
+	_, err = u.client.PutObjectWithContext(ctx, fileUpload.Bucket, objectName, f, fileSize, fileUpload.Metadata.Options(fileName))
 	if err != nil {
 		error := errors.Wrapf(err, "Error while uploading file `%s` into `%s`", objectName, fileUpload.Bucket)
 		glog.Error(error)
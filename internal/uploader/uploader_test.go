@@ -7,12 +7,11 @@ import (
 	"time"
 
 	fautomock "github.com/kyma-project/rafter/internal/fileheader/automock"
+	"github.com/kyma-project/rafter/internal/store"
 	"github.com/kyma-project/rafter/internal/uploader"
 	"github.com/kyma-project/rafter/internal/uploader/automock"
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
-
-	"github.com/minio/minio-go"
 )
 
 func TestUploader_UploadFiles(t *testing.T) {
@@ -68,8 +67,8 @@ func TestUploader_UploadFiles(t *testing.T) {
 		ctxArgFn := func(ctx context.Context) bool { return true }
 
 		clientMock := new(automock.MinioClient)
-		clientMock.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "test", "testDir/test1.yaml", file, int64(-1), minio.PutObjectOptions{}).Return(int64(1), nil).Once()
-		clientMock.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "test2", "testDir/test2.yaml", file, int64(-1), minio.PutObjectOptions{}).Return(int64(1), nil).Once()
+		clientMock.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "test", "testDir/test1.yaml", file, int64(-1), store.ObjectMetadata{}.Options("test1.yaml")).Return(int64(1), nil).Once()
+		clientMock.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), "test2", "testDir/test2.yaml", file, int64(-1), store.ObjectMetadata{}.Options("test2.yaml")).Return(int64(1), nil).Once()
 		defer clientMock.AssertExpectations(t)
 
 		uploadClient := uploader.New(clientMock, "https://minio.example.com", timeout, 5)
@@ -123,8 +122,8 @@ func TestUploader_UploadFiles(t *testing.T) {
 		ctxArgFn := func(ctx context.Context) bool { return true }
 
 		clientMock := new(automock.MinioClient)
-		clientMock.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), bucketName, "testDir/test1.yaml", file, int64(-1), minio.PutObjectOptions{}).Return(int64(1), testErr).Once()
-		clientMock.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), bucketName, "testDir/test2.yaml", file, int64(-1), minio.PutObjectOptions{}).Return(int64(1), testErr).Once()
+		clientMock.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), bucketName, "testDir/test1.yaml", file, int64(-1), store.ObjectMetadata{}.Options("test1.yaml")).Return(int64(1), testErr).Once()
+		clientMock.On("PutObjectWithContext", mock.MatchedBy(ctxArgFn), bucketName, "testDir/test2.yaml", file, int64(-1), store.ObjectMetadata{}.Options("test2.yaml")).Return(int64(1), testErr).Once()
 		defer clientMock.AssertExpectations(t)
 
 		uploadClient := uploader.New(clientMock, "https://minio.example.com", timeout, 5)
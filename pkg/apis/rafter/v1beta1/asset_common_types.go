@@ -13,6 +13,12 @@ type CommonAssetSpec struct {
 	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
 	// +optional
 	DisplayName string `json:"displayName,omitempty"`
+	// +optional
+	Versioning *AssetVersioning `json:"versioning,omitempty"`
+	// +optional
+	ObjectMetadata []AssetObjectMetadata `json:"objectMetadata,omitempty"`
+	// +optional
+	Compression *AssetCompression `json:"compression,omitempty"`
 }
 
 // CommonAssetStatus defines the observed state of Asset
@@ -23,6 +29,8 @@ type CommonAssetStatus struct {
 	AssetRef           AssetStatusRef `json:"assetRef,omitempty"`
 	LastHeartbeatTime  metav1.Time    `json:"lastHeartbeatTime"`
 	ObservedGeneration int64          `json:"observedGeneration"`
+	// +optional
+	Versions []AssetVersion `json:"versions,omitempty"`
 }
 
 type AssetPhase string
@@ -36,6 +44,41 @@ const (
 type AssetStatusRef struct {
 	BaseURL string      `json:"baseUrl"`
 	Files   []AssetFile `json:"files,omitempty"`
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// +optional
+	ETag string `json:"etag,omitempty"`
+	// +optional
+	LastModified string `json:"lastModified,omitempty"`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// +optional
+	Version int64 `json:"version,omitempty"`
+	// +optional
+	Entries []AssetEntryRef `json:"entries,omitempty"`
+}
+
+// AssetEntryRef holds the validators of a file loaded from an index, so its changes are detected
+// without downloading it again. The digest is stored only if the server returns no other validators.
+type AssetEntryRef struct {
+	URL string `json:"url"`
+	// +optional
+	ETag string `json:"etag,omitempty"`
+	// +optional
+	LastModified string `json:"lastModified,omitempty"`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// AssetVersion is a published version of the asset content kept in the bucket
+type AssetVersion struct {
+	Version     int64       `json:"version"`
+	Generation  int64       `json:"generation"`
+	PublishedAt metav1.Time `json:"publishedAt"`
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// +optional
+	Digest string `json:"digest,omitempty"`
 }
 
 type AssetFile struct {
@@ -58,7 +101,7 @@ type AssetWebhookService struct {
 	Parameters     *runtime.RawExtension `json:"parameters,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=single;package;index;configmap
+// +kubebuilder:validation:Enum=single;package;index;configmap;secret;git
 type AssetMode string
 
 const (
@@ -66,6 +109,84 @@ const (
 	AssetPackage   AssetMode = "package"
 	AssetIndex     AssetMode = "index"
 	AssetConfigMap AssetMode = "configmap"
+	AssetSecret    AssetMode = "secret"
+	AssetGit       AssetMode = "git"
+)
+
+// +kubebuilder:validation:Enum=zip;tar;tar.gz;tar.bz2;tar.xz;tar.zst
+type AssetArchiveFormat string
+
+const (
+	AssetArchiveZIP    AssetArchiveFormat = "zip"
+	AssetArchiveTAR    AssetArchiveFormat = "tar"
+	AssetArchiveTARGZ  AssetArchiveFormat = "tar.gz"
+	AssetArchiveTARBZ2 AssetArchiveFormat = "tar.bz2"
+	AssetArchiveTARXZ  AssetArchiveFormat = "tar.xz"
+	AssetArchiveTARZST AssetArchiveFormat = "tar.zst"
+)
+
+// +kubebuilder:validation:Enum=Once;Periodic
+type AssetSyncPolicy string
+
+const (
+	AssetSyncOnce     AssetSyncPolicy = "Once"
+	AssetSyncPeriodic AssetSyncPolicy = "Periodic"
+)
+
+// AssetVersioning configures how many previous versions of the asset content are kept, and pins one of them
+type AssetVersioning struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Keep *int `json:"keep,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RollbackTo int64 `json:"rollbackTo,omitempty"`
+}
+
+// AssetObjectMetadata sets the headers of the uploaded files matching the pattern. Rules without a pattern apply
+// to all files, and later rules override the headers set by earlier ones.
+type AssetObjectMetadata struct {
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+	// +optional
+	CacheControl string `json:"cacheControl,omitempty"`
+	// +optional
+	ContentDisposition string `json:"contentDisposition,omitempty"`
+	// +optional
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	// +optional
+	UserMetadata map[string]string `json:"userMetadata,omitempty"`
+}
+
+// AssetCompression configures the precompressed variants of compressible files stored in the bucket
+type AssetCompression struct {
+	// +kubebuilder:validation:MinItems=1
+	Encodings []AssetEncoding `json:"encodings"`
+	// +optional
+	Mode AssetCompressionMode `json:"mode,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinSize *int64 `json:"minSize,omitempty"`
+	// +optional
+	ContentTypes []string `json:"contentTypes,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=gzip;br
+type AssetEncoding string
+
+const (
+	AssetEncodingGzip   AssetEncoding = "gzip"
+	AssetEncodingBrotli AssetEncoding = "br"
+)
+
+// +kubebuilder:validation:Enum=Variants;Replace
+type AssetCompressionMode string
+
+const (
+	AssetCompressionVariants AssetCompressionMode = "Variants"
+	AssetCompressionReplace  AssetCompressionMode = "Replace"
 )
 
 type AssetBucketRef struct {
@@ -77,6 +198,25 @@ type AssetSource struct {
 	URL  string    `json:"url"`
 	// +optional
 	Filter string `json:"filter,omitempty"`
+	// +optional
+	ArchiveFormat AssetArchiveFormat `json:"archiveFormat,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StripComponents int `json:"stripComponents,omitempty"`
+	// +optional
+	TargetPrefix string `json:"targetPrefix,omitempty"`
+	// +optional
+	Git *AssetGitSource `json:"git,omitempty"`
+	// +optional
+	SecretRef *AssetSecretRef `json:"secretRef,omitempty"`
+	// +optional
+	Checksum AssetChecksum `json:"checksum,omitempty"`
+	// +optional
+	Signature *AssetSignature `json:"signature,omitempty"`
+	// +optional
+	SyncPolicy AssetSyncPolicy `json:"syncPolicy,omitempty"`
+	// +optional
+	Download *AssetDownload `json:"download,omitempty"`
 
 	// +optional
 	ValidationWebhookService []AssetWebhookService `json:"validationWebhookService,omitempty"`
@@ -88,6 +228,78 @@ type AssetSource struct {
 	MetadataWebhookService []WebhookService `json:"metadataWebhookService,omitempty"`
 }
 
+type AssetGitSource struct {
+	// +optional
+	Ref string `json:"ref,omitempty"`
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// AssetDownload overrides the HTTP client settings configured for the loader
+type AssetDownload struct {
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries *int `json:"maxRetries,omitempty"`
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+	// +optional
+	CABundleRef *AssetCABundleRef `json:"caBundleRef,omitempty"`
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// +optional
+	Proxy *AssetProxy `json:"proxy,omitempty"`
+}
+
+// AssetCABundleRef points to PEM encoded CA certificates trusted in addition to the system ones
+type AssetCABundleRef struct {
+	Kind AssetPublicKeyKind `json:"kind"`
+	Name string             `json:"name"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+type AssetProxy struct {
+	URL string `json:"url"`
+	// +optional
+	SecretRef *AssetSecretRef `json:"secretRef,omitempty"`
+}
+
+type AssetSecretRef struct {
+	Name string `json:"name"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AssetChecksum is a digest of the downloaded content in the <algorithm>:<hex> format
+// +kubebuilder:validation:Pattern=^(sha256|sha512):[a-fA-F0-9]+$
+type AssetChecksum string
+
+type AssetSignature struct {
+	URL          string            `json:"url"`
+	PublicKeyRef AssetPublicKeyRef `json:"publicKeyRef"`
+}
+
+// +kubebuilder:validation:Enum=Secret;ConfigMap
+type AssetPublicKeyKind string
+
+const (
+	AssetPublicKeySecret    AssetPublicKeyKind = "Secret"
+	AssetPublicKeyConfigMap AssetPublicKeyKind = "ConfigMap"
+)
+
+type AssetPublicKeyRef struct {
+	Kind AssetPublicKeyKind `json:"kind"`
+	Name string             `json:"name"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
 type AssetReason string
 
 const (
@@ -110,6 +322,18 @@ const (
 	AssetCleanupError                   AssetReason = "CleanupError"
 	AssetCleaned                        AssetReason = "Cleaned"
 	AssetScheduled                      AssetReason = "Scheduled"
+	AssetIntegrityCheckFailed           AssetReason = "IntegrityCheckFailed"
+	AssetSourceChanged                  AssetReason = "SourceChanged"
+	AssetSourceCheckFailed              AssetReason = "SourceCheckFailed"
+	AssetArchiveRejected                AssetReason = "ArchiveRejected"
+	AssetAccessDenied                   AssetReason = "AccessDenied"
+	AssetSourceNotAllowed               AssetReason = "SourceNotAllowed"
+	AssetRolledBack                     AssetReason = "RolledBack"
+	AssetRollbackFailed                 AssetReason = "RollbackFailed"
+	AssetInvalidObjectMetadata          AssetReason = "InvalidObjectMetadata"
+	AssetCompressed                     AssetReason = "Compressed"
+	AssetCompressionFailed              AssetReason = "CompressionFailed"
+	AssetEncryptionFailed               AssetReason = "EncryptionFailed"
 )
 
 func (r AssetReason) String() string {
@@ -156,6 +380,30 @@ func (r AssetReason) Message() string {
 		return "Old asset content hes been removed"
 	case AssetScheduled:
 		return "Asset scheduled for processing"
+	case AssetIntegrityCheckFailed:
+		return "Asset content integrity check failed due to error %s"
+	case AssetSourceChanged:
+		return "Asset source content has changed"
+	case AssetSourceCheckFailed:
+		return "Checking asset source for changes failed due to error %s"
+	case AssetArchiveRejected:
+		return "Asset package was rejected by the extraction policy due to error %s"
+	case AssetAccessDenied:
+		return "Access to the asset source was denied due to error %s"
+	case AssetSourceNotAllowed:
+		return "Asset source is not allowed by the source policy due to error %s"
+	case AssetRolledBack:
+		return "Asset content has been rolled back to version %d"
+	case AssetRollbackFailed:
+		return "Rolling back asset content failed due to error %s"
+	case AssetInvalidObjectMetadata:
+		return "Object metadata rules are invalid due to error %s"
+	case AssetCompressed:
+		return "Asset content has been compressed"
+	case AssetCompressionFailed:
+		return "Asset content compression failed due to error %s"
+	case AssetEncryptionFailed:
+		return "Bucket encryption couldn't be applied due to error %s"
 	default:
 		return ""
 	}
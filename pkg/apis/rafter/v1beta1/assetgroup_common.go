@@ -17,13 +17,15 @@ type AssetGroupBucketRef struct {
 	Name string `json:"name"`
 }
 
-// +kubebuilder:validation:Enum=single;package;index
+// +kubebuilder:validation:Enum=single;package;index;secret;git
 type AssetGroupSourceMode string
 
 const (
 	AssetGroupSingle  AssetGroupSourceMode = "single"
 	AssetGroupPackage AssetGroupSourceMode = "package"
 	AssetGroupIndex   AssetGroupSourceMode = "index"
+	AssetGroupSecret  AssetGroupSourceMode = "secret"
+	AssetGroupGit     AssetGroupSourceMode = "git"
 )
 
 // +kubebuilder:validation:Pattern=^[a-z][a-zA-Z0-9-]*[a-zA-Z0-9]$
@@ -39,6 +41,25 @@ type Source struct {
 	Mode   AssetGroupSourceMode `json:"mode"`
 	Filter string               `json:"filter,omitempty"`
 	// +optional
+	ArchiveFormat AssetArchiveFormat `json:"archiveFormat,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StripComponents int `json:"stripComponents,omitempty"`
+	// +optional
+	TargetPrefix string `json:"targetPrefix,omitempty"`
+	// +optional
+	Git *AssetGitSource `json:"git,omitempty"`
+	// +optional
+	SecretRef *AssetSecretRef `json:"secretRef,omitempty"`
+	// +optional
+	Checksum AssetChecksum `json:"checksum,omitempty"`
+	// +optional
+	Signature *AssetSignature `json:"signature,omitempty"`
+	// +optional
+	SyncPolicy AssetSyncPolicy `json:"syncPolicy,omitempty"`
+	// +optional
+	Download *AssetDownload `json:"download,omitempty"`
+	// +optional
 	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
 	// +optional
 	DisplayName string `json:"displayName,omitempty"`
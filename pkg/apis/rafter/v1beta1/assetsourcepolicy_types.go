@@ -0,0 +1,49 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AssetSourcePolicySpec defines the restrictions for the URLs assets are loaded from
+type AssetSourcePolicySpec struct {
+	// +optional
+	AllowedSchemes []string `json:"allowedSchemes,omitempty"`
+
+	// +optional
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+
+	// +optional
+	DeniedHosts []string `json:"deniedHosts,omitempty"`
+
+	// +optional
+	BlockPrivateNetworks bool `json:"blockPrivateNetworks,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRedirects *int `json:"maxRedirects,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// AssetSourcePolicy is the Schema for the assetsourcepolicies API
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type AssetSourcePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AssetSourcePolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AssetSourcePolicyList contains a list of AssetSourcePolicy
+type AssetSourcePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AssetSourcePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AssetSourcePolicy{}, &AssetSourcePolicyList{})
+}
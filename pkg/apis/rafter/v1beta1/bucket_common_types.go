@@ -11,6 +11,26 @@ type CommonBucketSpec struct {
 
 	// +optional
 	Policy BucketPolicy `json:"policy,omitempty"`
+
+	// +optional
+	AccessPolicy *BucketAccessPolicy `json:"accessPolicy,omitempty"`
+
+	// +optional
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	// +optional
+	Lifecycle []BucketLifecycleRule `json:"lifecycle,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	RemoteName string `json:"remoteName,omitempty"`
+
+	// +optional
+	AdoptionPolicy BucketAdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// +optional
+	ReclaimPolicy BucketReclaimPolicy `json:"reclaimPolicy,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=us-east-1;us-west-1;us-west-2;eu-west-1;eu-central-1;ap-southeast-1;ap-southeast-2;ap-northeast-1;sa-east-1;""
@@ -38,6 +58,114 @@ const (
 	BucketPolicyReadWrite BucketPolicy = "readwrite"
 )
 
+// BucketAccessPolicy extends the policy of the whole bucket with the policies of the objects with given prefixes,
+// and with explicit statements. The raw policy document replaces all of them.
+type BucketAccessPolicy struct {
+	// +optional
+	Rules []BucketPolicyRule `json:"rules,omitempty"`
+	// +optional
+	Statements []BucketPolicyStatement `json:"statements,omitempty"`
+	// +optional
+	Raw string `json:"raw,omitempty"`
+}
+
+// BucketPolicyRule grants the access to the objects with names starting with the prefix
+type BucketPolicyRule struct {
+	// +kubebuilder:validation:MinLength=1
+	Prefix string `json:"prefix"`
+	// +kubebuilder:validation:Enum=readonly;writeonly;readwrite
+	Policy BucketPolicy `json:"policy"`
+}
+
+// BucketPolicyStatement allows or denies the actions on the bucket or the objects matching the resource patterns.
+// The patterns are relative to the bucket, and the empty pattern refers to the bucket itself.
+type BucketPolicyStatement struct {
+	// +optional
+	Sid    string             `json:"sid,omitempty"`
+	Effect BucketPolicyEffect `json:"effect"`
+	// +optional
+	Principals []string `json:"principals,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	Actions []string `json:"actions"`
+	// +kubebuilder:validation:MinItems=1
+	Resources []string `json:"resources"`
+}
+
+// +kubebuilder:validation:Enum=Allow;Deny
+type BucketPolicyEffect string
+
+const (
+	BucketPolicyEffectAllow BucketPolicyEffect = "Allow"
+	BucketPolicyEffectDeny  BucketPolicyEffect = "Deny"
+)
+
+// BucketLifecycleRule expires the objects with names starting with the prefix, and aborts the multipart uploads
+// of such objects which are not completed in time. Objects with any name match the empty prefix.
+type BucketLifecycleRule struct {
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ExpirationDays *int64 `json:"expirationDays,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	AbortIncompleteUploadDays *int64 `json:"abortIncompleteUploadDays,omitempty"`
+}
+
+// BucketAdoptionPolicy defines how the remote bucket with the name given in the spec is bound
+// +kubebuilder:validation:Enum=Existing;CreateIfMissing;""
+type BucketAdoptionPolicy string
+
+const (
+	// BucketAdoptionExisting binds only to the existing bucket, and waits until it is created
+	BucketAdoptionExisting BucketAdoptionPolicy = "Existing"
+
+	// BucketAdoptionCreateIfMissing binds to the existing bucket, or creates it if it doesn't exist
+	BucketAdoptionCreateIfMissing BucketAdoptionPolicy = "CreateIfMissing"
+)
+
+// BucketReclaimPolicy defines what happens with the remote bucket when the CR is deleted
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan;""
+type BucketReclaimPolicy string
+
+const (
+	// BucketReclaimDelete removes the remote bucket with all its objects
+	BucketReclaimDelete BucketReclaimPolicy = "Delete"
+
+	// BucketReclaimRetain keeps the remote bucket, and records it in a RetainedBucket, so it can be adopted again
+	BucketReclaimRetain BucketReclaimPolicy = "Retain"
+
+	// BucketReclaimOrphan keeps the remote bucket without any record
+	BucketReclaimOrphan BucketReclaimPolicy = "Orphan"
+)
+
+// BucketEncryption encrypts the objects at rest with keys managed by the server (SSE-S3),
+// or with the key of the customer sent with every request (SSE-C)
+type BucketEncryption struct {
+	Type BucketEncryptionType `json:"type"`
+	// +optional
+	SecretRef *BucketEncryptionSecretRef `json:"secretRef,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=SSE-S3;SSE-C
+type BucketEncryptionType string
+
+const (
+	BucketEncryptionSSES3 BucketEncryptionType = "SSE-S3"
+	BucketEncryptionSSEC  BucketEncryptionType = "SSE-C"
+)
+
+// BucketEncryptionSecretRef points to the 32 bytes long SSE-C key in a Secret
+type BucketEncryptionSecretRef struct {
+	Name string `json:"name"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
 // CommonBucketStatus defines the observed state of Bucket
 type CommonBucketStatus struct {
 	URL                string       `json:"url,omitempty"`
@@ -47,6 +175,8 @@ type CommonBucketStatus struct {
 	RemoteName         string       `json:"remoteName,omitempty"`
 	LastHeartbeatTime  metav1.Time  `json:"lastHeartbeatTime,omitempty"`
 	ObservedGeneration int64        `json:"observedGeneration"`
+	// +optional
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
 }
 
 type BucketPhase string
@@ -62,14 +192,30 @@ const (
 type BucketReason string
 
 const (
-	BucketNotFound                 BucketReason = "BucketNotFound"
-	BucketCreationFailure          BucketReason = "BucketCreationFailure"
-	BucketVerificationFailure      BucketReason = "BucketVerificationFailure"
-	BucketCreated                  BucketReason = "BucketCreated"
-	BucketPolicyUpdated            BucketReason = "BucketPolicyUpdated"
-	BucketPolicyUpdateFailed       BucketReason = "BucketPolicyUpdateFailed"
-	BucketPolicyVerificationFailed BucketReason = "BucketPolicyVerificationFailed"
-	BucketPolicyHasBeenChanged     BucketReason = "BucketPolicyHasBeenChanged"
+	BucketNotFound                     BucketReason = "BucketNotFound"
+	BucketCreationFailure              BucketReason = "BucketCreationFailure"
+	BucketVerificationFailure          BucketReason = "BucketVerificationFailure"
+	BucketCreated                      BucketReason = "BucketCreated"
+	BucketPolicyUpdated                BucketReason = "BucketPolicyUpdated"
+	BucketPolicyUpdateFailed           BucketReason = "BucketPolicyUpdateFailed"
+	BucketPolicyVerificationFailed     BucketReason = "BucketPolicyVerificationFailed"
+	BucketPolicyHasBeenChanged         BucketReason = "BucketPolicyHasBeenChanged"
+	BucketInvalidEncryption            BucketReason = "BucketInvalidEncryption"
+	BucketInvalidPolicy                BucketReason = "BucketInvalidPolicy"
+	BucketInvalidLifecycle             BucketReason = "BucketInvalidLifecycle"
+	BucketLifecycleUpdated             BucketReason = "BucketLifecycleUpdated"
+	BucketLifecycleUpdateFailed        BucketReason = "BucketLifecycleUpdateFailed"
+	BucketLifecycleVerificationFailed  BucketReason = "BucketLifecycleVerificationFailed"
+	BucketLifecycleHasBeenChanged      BucketReason = "BucketLifecycleHasBeenChanged"
+	BucketEncryptionUpdated            BucketReason = "BucketEncryptionUpdated"
+	BucketEncryptionUpdateFailed       BucketReason = "BucketEncryptionUpdateFailed"
+	BucketEncryptionVerificationFailed BucketReason = "BucketEncryptionVerificationFailed"
+	BucketEncryptionHasBeenChanged     BucketReason = "BucketEncryptionHasBeenChanged"
+	BucketAdopted                      BucketReason = "BucketAdopted"
+	BucketInvalidRemoteName            BucketReason = "BucketInvalidRemoteName"
+	BucketRemoteNameInUse              BucketReason = "BucketRemoteNameInUse"
+	BucketRetained                     BucketReason = "BucketRetained"
+	BucketOrphaned                     BucketReason = "BucketOrphaned"
 )
 
 func (r BucketReason) String() string {
@@ -94,6 +240,38 @@ func (r BucketReason) Message() string {
 		return "Bucket policy couldn't be verified due to error %s"
 	case BucketPolicyHasBeenChanged:
 		return "Remote bucket policy has been changed"
+	case BucketInvalidEncryption:
+		return "Bucket encryption is invalid due to error %s"
+	case BucketInvalidPolicy:
+		return "Bucket policy is invalid due to error %s"
+	case BucketInvalidLifecycle:
+		return "Bucket lifecycle rules are invalid due to error %s"
+	case BucketLifecycleUpdated:
+		return "Bucket lifecycle rules have been updated"
+	case BucketLifecycleUpdateFailed:
+		return "Bucket lifecycle rules couldn't be set due to error %s"
+	case BucketLifecycleVerificationFailed:
+		return "Bucket lifecycle rules couldn't be verified due to error %s"
+	case BucketLifecycleHasBeenChanged:
+		return "Remote bucket lifecycle rules have been changed"
+	case BucketEncryptionUpdated:
+		return "Bucket encryption has been updated"
+	case BucketEncryptionUpdateFailed:
+		return "Bucket encryption couldn't be set due to error %s"
+	case BucketEncryptionVerificationFailed:
+		return "Bucket encryption couldn't be verified due to error %s"
+	case BucketEncryptionHasBeenChanged:
+		return "Remote bucket encryption has been changed"
+	case BucketAdopted:
+		return "Existing bucket %s has been adopted"
+	case BucketInvalidRemoteName:
+		return "Bucket remote name is invalid due to error %s"
+	case BucketRemoteNameInUse:
+		return "Bucket %s is already bound to %s"
+	case BucketRetained:
+		return "Bucket %s has been retained"
+	case BucketOrphaned:
+		return "Bucket %s has been orphaned"
 	default:
 		return ""
 	}
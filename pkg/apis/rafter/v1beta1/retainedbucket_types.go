@@ -0,0 +1,56 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RetainedBucketSpec describes the remote bucket kept in the storage after its Bucket or ClusterBucket was deleted
+type RetainedBucketSpec struct {
+	RemoteName string `json:"remoteName"`
+
+	// +optional
+	Region BucketRegion `json:"region,omitempty"`
+
+	// +optional
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	Source RetainedBucketSource `json:"source"`
+
+	RetainedAt metav1.Time `json:"retainedAt"`
+}
+
+// RetainedBucketSource points to the deleted Bucket or ClusterBucket, which the remote bucket was bound to
+type RetainedBucketSource struct {
+	// +kubebuilder:validation:Enum=Bucket;ClusterBucket
+	Kind string `json:"kind"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// RetainedBucket is the Schema for the retainedbuckets API
+// +kubebuilder:printcolumn:name="Remote Name",type="string",JSONPath=".spec.remoteName"
+// +kubebuilder:printcolumn:name="Source Kind",type="string",JSONPath=".spec.source.kind"
+// +kubebuilder:printcolumn:name="Retained At",type="date",JSONPath=".spec.retainedAt"
+type RetainedBucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RetainedBucketSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RetainedBucketList contains a list of RetainedBucket
+type RetainedBucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RetainedBucket `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RetainedBucket{}, &RetainedBucketList{})
+}
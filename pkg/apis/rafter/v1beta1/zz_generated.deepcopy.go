@@ -5,6 +5,7 @@
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -50,6 +51,106 @@ func (in *AssetBucketRef) DeepCopy() *AssetBucketRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetCABundleRef) DeepCopyInto(out *AssetCABundleRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetCABundleRef.
+func (in *AssetCABundleRef) DeepCopy() *AssetCABundleRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetCABundleRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetCompression) DeepCopyInto(out *AssetCompression) {
+	*out = *in
+	if in.Encodings != nil {
+		in, out := &in.Encodings, &out.Encodings
+		*out = make([]AssetEncoding, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ContentTypes != nil {
+		in, out := &in.ContentTypes, &out.ContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetCompression.
+func (in *AssetCompression) DeepCopy() *AssetCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetDownload) DeepCopyInto(out *AssetDownload) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CABundleRef != nil {
+		in, out := &in.CABundleRef, &out.CABundleRef
+		*out = new(AssetCABundleRef)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(AssetProxy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetDownload.
+func (in *AssetDownload) DeepCopy() *AssetDownload {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetDownload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetEntryRef) DeepCopyInto(out *AssetEntryRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetEntryRef.
+func (in *AssetEntryRef) DeepCopy() *AssetEntryRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetEntryRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetFile) DeepCopyInto(out *AssetFile) {
 	*out = *in
@@ -70,6 +171,21 @@ func (in *AssetFile) DeepCopy() *AssetFile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetGitSource) DeepCopyInto(out *AssetGitSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetGitSource.
+func (in *AssetGitSource) DeepCopy() *AssetGitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetGitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetGroup) DeepCopyInto(out *AssetGroup) {
 	*out = *in
@@ -208,9 +324,117 @@ func (in *AssetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetObjectMetadata) DeepCopyInto(out *AssetObjectMetadata) {
+	*out = *in
+	if in.UserMetadata != nil {
+		in, out := &in.UserMetadata, &out.UserMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetObjectMetadata.
+func (in *AssetObjectMetadata) DeepCopy() *AssetObjectMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetObjectMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetProxy) DeepCopyInto(out *AssetProxy) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(AssetSecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetProxy.
+func (in *AssetProxy) DeepCopy() *AssetProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetPublicKeyRef) DeepCopyInto(out *AssetPublicKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetPublicKeyRef.
+func (in *AssetPublicKeyRef) DeepCopy() *AssetPublicKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetPublicKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetSecretRef) DeepCopyInto(out *AssetSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetSecretRef.
+func (in *AssetSecretRef) DeepCopy() *AssetSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetSignature) DeepCopyInto(out *AssetSignature) {
+	*out = *in
+	out.PublicKeyRef = in.PublicKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetSignature.
+func (in *AssetSignature) DeepCopy() *AssetSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetSource) DeepCopyInto(out *AssetSource) {
 	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(AssetGitSource)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(AssetSecretRef)
+		**out = **in
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(AssetSignature)
+		**out = **in
+	}
+	if in.Download != nil {
+		in, out := &in.Download, &out.Download
+		*out = new(AssetDownload)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ValidationWebhookService != nil {
 		in, out := &in.ValidationWebhookService, &out.ValidationWebhookService
 		*out = make([]AssetWebhookService, len(*in))
@@ -242,6 +466,99 @@ func (in *AssetSource) DeepCopy() *AssetSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetSourcePolicy) DeepCopyInto(out *AssetSourcePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetSourcePolicy.
+func (in *AssetSourcePolicy) DeepCopy() *AssetSourcePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetSourcePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AssetSourcePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetSourcePolicyList) DeepCopyInto(out *AssetSourcePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AssetSourcePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetSourcePolicyList.
+func (in *AssetSourcePolicyList) DeepCopy() *AssetSourcePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetSourcePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AssetSourcePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetSourcePolicySpec) DeepCopyInto(out *AssetSourcePolicySpec) {
+	*out = *in
+	if in.AllowedSchemes != nil {
+		in, out := &in.AllowedSchemes, &out.AllowedSchemes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedHosts != nil {
+		in, out := &in.DeniedHosts, &out.DeniedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxRedirects != nil {
+		in, out := &in.MaxRedirects, &out.MaxRedirects
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetSourcePolicySpec.
+func (in *AssetSourcePolicySpec) DeepCopy() *AssetSourcePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetSourcePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetSpec) DeepCopyInto(out *AssetSpec) {
 	*out = *in
@@ -284,6 +601,11 @@ func (in *AssetStatusRef) DeepCopyInto(out *AssetStatusRef) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]AssetEntryRef, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetStatusRef.
@@ -296,6 +618,42 @@ func (in *AssetStatusRef) DeepCopy() *AssetStatusRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetVersion) DeepCopyInto(out *AssetVersion) {
+	*out = *in
+	in.PublishedAt.DeepCopyInto(&out.PublishedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetVersion.
+func (in *AssetVersion) DeepCopy() *AssetVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetVersioning) DeepCopyInto(out *AssetVersioning) {
+	*out = *in
+	if in.Keep != nil {
+		in, out := &in.Keep, &out.Keep
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetVersioning.
+func (in *AssetVersioning) DeepCopy() *AssetVersioning {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetVersioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetWebhookService) DeepCopyInto(out *AssetWebhookService) {
 	*out = *in
@@ -322,8 +680,8 @@ func (in *Bucket) DeepCopyInto(out *Bucket) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bucket.
@@ -344,6 +702,93 @@ func (in *Bucket) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketAccessPolicy) DeepCopyInto(out *BucketAccessPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]BucketPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Statements != nil {
+		in, out := &in.Statements, &out.Statements
+		*out = make([]BucketPolicyStatement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketAccessPolicy.
+func (in *BucketAccessPolicy) DeepCopy() *BucketAccessPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketAccessPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketEncryption) DeepCopyInto(out *BucketEncryption) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(BucketEncryptionSecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketEncryption.
+func (in *BucketEncryption) DeepCopy() *BucketEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketEncryptionSecretRef) DeepCopyInto(out *BucketEncryptionSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketEncryptionSecretRef.
+func (in *BucketEncryptionSecretRef) DeepCopy() *BucketEncryptionSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketEncryptionSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketLifecycleRule) DeepCopyInto(out *BucketLifecycleRule) {
+	*out = *in
+	if in.ExpirationDays != nil {
+		in, out := &in.ExpirationDays, &out.ExpirationDays
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AbortIncompleteUploadDays != nil {
+		in, out := &in.AbortIncompleteUploadDays, &out.AbortIncompleteUploadDays
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketLifecycleRule.
+func (in *BucketLifecycleRule) DeepCopy() *BucketLifecycleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketLifecycleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketList) DeepCopyInto(out *BucketList) {
 	*out = *in
@@ -376,10 +821,55 @@ func (in *BucketList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyRule) DeepCopyInto(out *BucketPolicyRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyRule.
+func (in *BucketPolicyRule) DeepCopy() *BucketPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyStatement) DeepCopyInto(out *BucketPolicyStatement) {
+	*out = *in
+	if in.Principals != nil {
+		in, out := &in.Principals, &out.Principals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyStatement.
+func (in *BucketPolicyStatement) DeepCopy() *BucketPolicyStatement {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyStatement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 	*out = *in
-	out.CommonBucketSpec = in.CommonBucketSpec
+	in.CommonBucketSpec.DeepCopyInto(&out.CommonBucketSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketSpec.
@@ -395,7 +885,7 @@ func (in *BucketSpec) DeepCopy() *BucketSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketStatus) DeepCopyInto(out *BucketStatus) {
 	*out = *in
-	out.CommonBucketStatus = in.CommonBucketStatus
+	in.CommonBucketStatus.DeepCopyInto(&out.CommonBucketStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketStatus.
@@ -595,8 +1085,8 @@ func (in *ClusterBucket) DeepCopyInto(out *ClusterBucket) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBucket.
@@ -652,7 +1142,7 @@ func (in *ClusterBucketList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBucketSpec) DeepCopyInto(out *ClusterBucketSpec) {
 	*out = *in
-	out.CommonBucketSpec = in.CommonBucketSpec
+	in.CommonBucketSpec.DeepCopyInto(&out.CommonBucketSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBucketSpec.
@@ -668,7 +1158,7 @@ func (in *ClusterBucketSpec) DeepCopy() *ClusterBucketSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBucketStatus) DeepCopyInto(out *ClusterBucketStatus) {
 	*out = *in
-	out.CommonBucketStatus = in.CommonBucketStatus
+	in.CommonBucketStatus.DeepCopyInto(&out.CommonBucketStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBucketStatus.
@@ -730,6 +1220,23 @@ func (in *CommonAssetSpec) DeepCopyInto(out *CommonAssetSpec) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Versioning != nil {
+		in, out := &in.Versioning, &out.Versioning
+		*out = new(AssetVersioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectMetadata != nil {
+		in, out := &in.ObjectMetadata, &out.ObjectMetadata
+		*out = make([]AssetObjectMetadata, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(AssetCompression)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonAssetSpec.
@@ -747,6 +1254,13 @@ func (in *CommonAssetStatus) DeepCopyInto(out *CommonAssetStatus) {
 	*out = *in
 	in.AssetRef.DeepCopyInto(&out.AssetRef)
 	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]AssetVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonAssetStatus.
@@ -762,6 +1276,23 @@ func (in *CommonAssetStatus) DeepCopy() *CommonAssetStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonBucketSpec) DeepCopyInto(out *CommonBucketSpec) {
 	*out = *in
+	if in.AccessPolicy != nil {
+		in, out := &in.AccessPolicy, &out.AccessPolicy
+		*out = new(BucketAccessPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BucketEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = make([]BucketLifecycleRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonBucketSpec.
@@ -778,6 +1309,11 @@ func (in *CommonBucketSpec) DeepCopy() *CommonBucketSpec {
 func (in *CommonBucketStatus) DeepCopyInto(out *CommonBucketStatus) {
 	*out = *in
 	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BucketEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonBucketStatus.
@@ -790,9 +1326,124 @@ func (in *CommonBucketStatus) DeepCopy() *CommonBucketStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedBucket) DeepCopyInto(out *RetainedBucket) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedBucket.
+func (in *RetainedBucket) DeepCopy() *RetainedBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RetainedBucket) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedBucketList) DeepCopyInto(out *RetainedBucketList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RetainedBucket, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedBucketList.
+func (in *RetainedBucketList) DeepCopy() *RetainedBucketList {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedBucketList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RetainedBucketList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedBucketSource) DeepCopyInto(out *RetainedBucketSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedBucketSource.
+func (in *RetainedBucketSource) DeepCopy() *RetainedBucketSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedBucketSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedBucketSpec) DeepCopyInto(out *RetainedBucketSpec) {
+	*out = *in
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BucketEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Source = in.Source
+	in.RetainedAt.DeepCopyInto(&out.RetainedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedBucketSpec.
+func (in *RetainedBucketSpec) DeepCopy() *RetainedBucketSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedBucketSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(AssetGitSource)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(AssetSecretRef)
+		**out = **in
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(AssetSignature)
+		**out = **in
+	}
+	if in.Download != nil {
+		in, out := &in.Download, &out.Download
+		*out = new(AssetDownload)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = new(runtime.RawExtension)